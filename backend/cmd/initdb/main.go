@@ -92,11 +92,14 @@ type Message struct {
 }
 
 type Report struct {
-	ID              uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	TargetContentID uuid.UUID `gorm:"type:uuid;not null;index"`
-	ReporterID      uuid.UUID `gorm:"type:uuid;not null;index"`
-	Reason          string    `gorm:"not null"`
-	CreatedAt       time.Time `gorm:"column:created_at;autoCreateTime"`
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TargetType string    `gorm:"size:16;not null;default:'content';index:idx_report_target"`
+	TargetID   uuid.UUID `gorm:"type:uuid;not null;index:idx_report_target"`
+	ReporterID uuid.UUID `gorm:"type:uuid;not null;index"`
+	ReasonCode string    `gorm:"size:32;not null;default:'other'"`
+	Details    string    `gorm:"type:text"`
+	Status     string    `gorm:"size:16;not null;default:'open';index"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
 }
 
 type Feature struct {
@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -28,32 +33,110 @@ func main() {
 	userRepo := repositories.NewUserRepository()
 	authSvc := services.NewAuthService(userRepo)
 	handlers.SetAuthService(authSvc)
+	sanctionSvc := services.NewSanctionService(repositories.NewSanctionRepository(), userRepo, repositories.NewSubscriptionRepository())
+	sanctionHandler := handlers.NewSanctionHandler(sanctionSvc)
+	authSvc.SetSanctions(sanctionSvc)
+	middleware.SetAccessCheck(sanctionSvc.CheckAccess)
 
 	subRepo := repositories.NewSubscriptionRepository()
 	publicContentRepo := repositories.NewPublicContentRepository()
-	handlers.SetCreatorRepos(userRepo, subRepo, publicContentRepo)
+	tagRepo := repositories.NewTagRepository()
+	collectionRepo := repositories.NewCollectionRepository()
+	handlers.SetCreatorRepos(userRepo, subRepo, publicContentRepo, tagRepo, collectionRepo)
+	ageSvc := services.NewAgeVerificationService(userRepo)
+	handlers.SetCreatorAgeVerification(ageSvc)
+	ageHandler := handlers.NewAgeVerificationHandler(ageSvc)
 
 	contentRepo := repositories.NewContentRepository()
 	uploadPath := config.C.UploadPath
 	if err := os.MkdirAll(uploadPath, 0o755); err != nil {
 		log.Fatalf("Impossible de créer UPLOAD_PATH %s: %v", uploadPath, err)
 	}
-	contentSvc := services.NewContentService(contentRepo, uploadPath)
-	contentHandler := handlers.NewHandler(contentSvc)
+	forensicRepo := repositories.NewForensicMarkRepository()
+	forensicSvc := services.NewForensicService(forensicRepo, contentRepo, userRepo, uploadPath)
+	forensicHandler := handlers.NewForensicHandler(forensicSvc)
+	contentRevisionRepo := repositories.NewContentRevisionRepository()
+	notificationSvc := services.NewNotificationService(repositories.NewNotificationRepository())
+	notificationHandler := handlers.NewNotificationHandler(notificationSvc)
+	moderationRepo := repositories.NewModerationRepository()
+	moderationSvc := services.NewModerationService(contentRepo, moderationRepo, notificationSvc)
+	services.StartQueueMetrics(moderationRepo, time.Minute)
+	moderationHandler := handlers.NewModerationHandler(moderationSvc)
+	duplicateSvc := services.NewDuplicateService(repositories.NewImageHashRepository(), contentRepo, moderationSvc)
+	duplicateHandler := handlers.NewDuplicateHandler(duplicateSvc)
+	contentSvc := services.NewContentService(contentRepo, contentRevisionRepo, uploadPath, forensicSvc, duplicateSvc, sanctionSvc)
+	feedRanker, err := services.NewDefaultFeedRanker(config.C.FeedWeights, config.C.FeedHalfLife)
+	if err != nil {
+		log.Fatalf("❌ Poids du fil « pour vous » invalides : %v", err)
+	}
+	contentSvc.SetFeedRanker(feedRanker)
+	contentSvc.StartPublishScheduler(time.Minute)
+	go func() {
+		if n, err := contentSvc.BackfillHashes(100); err != nil {
+			log.Printf("⚠️ Indexation des hashs perceptuels : %v", err)
+		} else if n > 0 {
+			log.Printf("🕵️ %d contenus existants indexés pour la détection de doublons", n)
+		}
+	}()
+	contentHandler := handlers.NewHandler(contentSvc, ageSvc)
+	taxonomySvc := services.NewTaxonomyService(tagRepo, repositories.NewCategoryRepository(), collectionRepo, contentRepo)
+	taxonomyHandler := handlers.NewTaxonomyHandler(taxonomySvc, ageSvc)
+	uploadSessionRepo := repositories.NewUploadSessionRepository()
+	uploadSvc := services.NewUploadService(uploadSessionRepo, userRepo, contentSvc, config.C.UploadTmpPath)
+	uploadSvc.StartCleanup(time.Hour)
+	uploadHandler := handlers.NewUploadHandler(uploadSvc)
 	subscriptionRepo := repositories.NewSubscriptionRepository()
-	subscriptionSvc := services.NewSubscriptionService(subscriptionRepo)
+	subscriptionSvc := services.NewSubscriptionService(subscriptionRepo, sanctionSvc)
 	subscriptionHandler := handlers.NewSubscriptionHandler(subscriptionSvc)
 	commentRepo := repositories.NewCommentRepository()
 	commentLikeRepo := repositories.NewCommentLikeRepository()
-	commentSvc := services.NewCommentService(commentRepo, commentLikeRepo, userRepo)
+	commentSvc := services.NewCommentService(commentRepo, commentLikeRepo, userRepo, sanctionSvc)
 	commentHandler := handlers.NewCommentHandler(commentSvc)
-	searchHandler := handlers.NewSearchHandler(database.DB)
+	searchSvc := services.NewSearchService(repositories.NewSearchRepository(), tagRepo, repositories.NewSearchHistoryRepository())
+	searchSvc.StartCleanup(24 * time.Hour)
+	searchHandler := handlers.NewSearchHandler(searchSvc, ageSvc)
+	trendingSvc := services.NewTrendingService(repositories.NewTrendingRepository())
+	trendingSvc.StartRefresher(10 * time.Minute)
+	trendingHandler := handlers.NewTrendingHandler(trendingSvc, ageSvc)
+	contentEventSvc := services.NewContentEventService(repositories.NewContentEventRepository(), contentRepo)
+	contentEventSvc.Start(2*time.Second, 10*time.Minute)
+	contentSvc.SetEventRecorder(contentEventSvc)
+	commentSvc.SetEventRecorder(contentEventSvc)
+	subscriptionSvc.SetEventRecorder(contentEventSvc)
+	creatorAnalyticsHandler := handlers.NewCreatorAnalyticsHandler(contentEventSvc,
+		services.NewCreatorAnalyticsService(repositories.NewCreatorAnalyticsRepository()))
 
 	messageRepo := repositories.NewMessageRepository()
-	messageSvc := services.NewMessageService(messageRepo, userRepo)
+	messageSvc := services.NewMessageService(messageRepo, userRepo, sanctionSvc)
 	messageHandler := handlers.NewMessageHandler(messageSvc)
 
-	adminStatsHandler := handlers.NewAdminStatsHandler()
+	textModerator, err := services.NewDefaultTextModerator(config.C.TextBlocklist, config.C.TextHoldlist,
+		config.C.TextMaxLinks, config.C.TextRepeatLimit)
+	if err != nil {
+		log.Fatalf("❌ Règles de modération des textes invalides : %v", err)
+	}
+	textModerationSvc := services.NewTextModerationService(textModerator, repositories.NewTextReviewRepository(),
+		commentRepo, messageRepo, moderationSvc, notificationSvc)
+	commentSvc.SetTextModeration(textModerationSvc)
+	messageSvc.SetTextModeration(textModerationSvc)
+	contentSvc.SetTextModeration(textModerationSvc)
+	textModerationHandler := handlers.NewTextModerationHandler(textModerationSvc)
+
+	reportSvc := services.NewReportService(repositories.NewReportRepository(), contentRepo, commentRepo, messageRepo, userRepo, moderationSvc)
+	reportHandler := handlers.NewReportHandler(reportSvc)
+
+	auditSvc := services.NewAuditService(repositories.NewAuditRepository())
+	handlers.SetAuditService(auditSvc)
+	auditHandler := handlers.NewAuditHandler(auditSvc)
+
+	adminStatsSvc := services.NewAdminStatsService(repositories.NewAdminStatsRepository())
+	adminStatsSvc.StartNightlyRollup()
+	adminStatsHandler := handlers.NewAdminStatsHandler(adminStatsSvc)
+	exportSvc := services.NewExportService(repositories.NewExportRepository(), repositories.NewExportJobRepository(),
+		adminStatsSvc, config.C.ExportPath)
+	exportSvc.FailInterrupted()
+	exportSvc.StartCleanup(time.Hour)
+	exportHandler := handlers.NewExportHandler(exportSvc)
 	adminCommentHandler := handlers.NewAdminCommentHandler(commentSvc)
 
 	if err := sentry.InitSentry(); err != nil {
@@ -62,13 +145,13 @@ func main() {
 
 	r := gin.New()
 	r.Use(sentry.Middleware())
-	r.Use(logger.GinLogger(), gin.Recovery())
+	r.Use(middleware.RequestID(), logger.GinLogger(), gin.Recovery())
 	r.Use(middleware.PrometheusMiddleware())
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "If-None-Match", "If-Modified-Since", "Upload-Offset", "Upload-Checksum", middleware.RequestIDHeader},
+		ExposeHeaders:    []string{"Content-Length", "ETag", "Last-Modified", "Cache-Control", "Location", "Upload-Offset", "Upload-Length", middleware.RequestIDHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -80,7 +163,8 @@ func main() {
 		r.GET("/test/sentry-payment", handlers.TestSentryPaymentHandler)
 	}
 
-	r.Static("/uploads", uploadPath)
+	// Pas de route statique sur UPLOAD_PATH : les fichiers originaux n'ont pas
+	// de watermark, ils ne sortent que par /api/contents/:id/image et /download.
 
 	r.GET("/health", handlers.HealthCheck)
 
@@ -90,23 +174,63 @@ func main() {
 		auth.POST("/login", handlers.LoginHandler)
 	}
 
-	r.GET("/api/contents", contentHandler.GetAllContents)
+	r.GET("/api/contents", middleware.OptionalJWTAuth(), contentHandler.GetAllContents)
 	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	r.POST("/api/metrics/client", handlers.ClientMetricsHandler)
-	r.GET("/api/creators/:username", handlers.GetPublicCreatorProfileHandler)
+	r.GET("/api/creators/:username", middleware.OptionalJWTAuth(), handlers.GetPublicCreatorProfileHandler)
+	r.GET("/api/tags/autocomplete", taxonomyHandler.AutocompleteTags)
+	r.GET("/api/tags/:tag/contents", middleware.OptionalJWTAuth(), taxonomyHandler.ContentsByTag)
+	r.GET("/api/categories", taxonomyHandler.ListCategories)
+	r.GET("/api/categories/:slug/contents", middleware.OptionalJWTAuth(), taxonomyHandler.ContentsByCategory)
+	r.GET("/api/collections/:id", middleware.OptionalJWTAuth(), taxonomyHandler.GetCollection)
+	r.GET("/api/trending/contents", middleware.OptionalJWTAuth(), trendingHandler.Contents)
+	r.GET("/api/trending/creators", trendingHandler.Creators)
 
 	protected := r.Group("/api", middleware.JWTAuth())
 	{
 		protected.GET("/users/me", handlers.CurrentUserHandler)
+		protected.POST("/users/me/age-verification", ageHandler.SubmitAgeVerification)
+		protected.PUT("/users/me/mature-content", ageHandler.UpdateMaturePreference)
+		protected.GET("/users/me/sanctions", sanctionHandler.Mine)
 		protected.POST("/contents", contentHandler.CreateContent)
+		protected.POST("/uploads", uploadHandler.CreateSession)
+		protected.GET("/uploads/:id", uploadHandler.GetSession)
+		protected.HEAD("/uploads/:id", uploadHandler.GetSession)
+		protected.PATCH("/uploads/:id", uploadHandler.WriteChunk)
+		protected.POST("/uploads/:id/finalize", uploadHandler.Finalize)
+		protected.DELETE("/uploads/:id", uploadHandler.Abort)
 		protected.GET("/search", searchHandler.Search)
+		protected.GET("/search/suggest", searchHandler.Suggest)
+		protected.GET("/search/recent", searchHandler.RecentSearches)
+		protected.DELETE("/search/recent", searchHandler.ClearRecentSearches)
+		protected.DELETE("/search/recent/:id", searchHandler.DeleteRecentSearch)
 		protected.GET("/contents/:id/download", contentHandler.DownloadContent)
 		protected.GET("/contents/:id/image", contentHandler.GetContentImage)
 		protected.GET("/contents/:id", contentHandler.GetContentByID)
 		protected.PUT("/contents/:id", contentHandler.UpdateContent)
+		protected.PUT("/contents/:id/preview", contentHandler.UpdatePreview)
+		protected.PUT("/contents/:id/maturity", contentHandler.SetMaturity)
+		protected.PUT("/contents/:id/file", contentHandler.ReplaceContentFile)
+		protected.GET("/contents/:id/revisions", contentHandler.GetRevisions)
+		protected.PUT("/contents/:id/tags", taxonomyHandler.SetContentTags)
+		protected.PUT("/contents/:id/category", taxonomyHandler.SetContentCategory)
+		protected.POST("/collections", taxonomyHandler.CreateCollection)
+		protected.PUT("/collections/:id", taxonomyHandler.UpdateCollection)
+		protected.DELETE("/collections/:id", taxonomyHandler.DeleteCollection)
+		protected.PUT("/collections/:id/items", taxonomyHandler.SetCollectionItems)
+		protected.POST("/contents/:id/submit", contentHandler.SubmitContent)
+		protected.PUT("/contents/:id/schedule", contentHandler.ScheduleContent)
+		protected.POST("/contents/:id/archive", contentHandler.ArchiveContent)
+		protected.POST("/contents/:id/unarchive", contentHandler.UnarchiveContent)
+		protected.GET("/creator/contents", contentHandler.GetMyContents)
+		protected.GET("/creator/moderation", moderationHandler.CreatorHistory)
+		protected.POST("/contents/:id/appeal", moderationHandler.Appeal)
+		protected.GET("/notifications", notificationHandler.List)
+		protected.POST("/notifications/:id/read", notificationHandler.MarkRead)
 		protected.DELETE("/contents/:id", contentHandler.DeleteContent)
 		protected.POST("/contents/:id/like", contentHandler.LikeContent)
 		protected.DELETE("/contents/:id/like", contentHandler.UnlikeContent)
+		protected.POST("/contents/:id/forensics/identify", forensicHandler.IdentifyLeak)
 		protected.GET("/feed", contentHandler.GetFeed)
 		protected.POST("/subscriptions/:creatorID", subscriptionHandler.Subscribe)
 		protected.DELETE("/subscriptions/:creatorID", subscriptionHandler.Unsubscribe)
@@ -114,6 +238,15 @@ func main() {
 		protected.GET("/subscriptions", subscriptionHandler.GetFollowedCreatorIDs)
 		protected.GET("/subscriptions/my", subscriptionHandler.GetMySubscriptions)
 		protected.GET("/creator/stats", subscriptionHandler.GetCreatorStats)
+		protected.GET("/creator/analytics/overview", creatorAnalyticsHandler.Overview)
+		protected.GET("/creator/analytics/subscribers", creatorAnalyticsHandler.Subscribers)
+		protected.GET("/creator/analytics/cohorts", creatorAnalyticsHandler.Cohorts)
+		protected.GET("/creator/analytics/fans", creatorAnalyticsHandler.Fans)
+		protected.GET("/creator/analytics/contents/:id", creatorAnalyticsHandler.Content)
+		protected.GET("/creator/exports/:dataset", exportHandler.CreatorExport)
+		protected.GET("/exports", exportHandler.ListJobs)
+		protected.GET("/exports/:id", exportHandler.GetJob)
+		protected.GET("/exports/:id/download", exportHandler.Download)
 		protected.GET("/subscriptions/:creatorID/status", subscriptionHandler.CheckSubscriptionStatus)
 		protected.GET("/contents/:id/comments", commentHandler.GetComments)
 		protected.POST("/contents/:id/comments", commentHandler.PostComment)
@@ -124,7 +257,9 @@ func main() {
 		protected.GET("/messages", messageHandler.GetConversations)
 		protected.GET("/messages/:userId", messageHandler.GetConversation)
 
-		protected.POST("/contents/:id/report", handlers.ReportContentHandler)
+		protected.POST("/contents/:id/report", reportHandler.ReportContent)
+		protected.POST("/reports", reportHandler.Create)
+		protected.GET("/reports/reasons", reportHandler.ListReasons)
 	}
 
 	admin := r.Group("/api/admin",
@@ -135,22 +270,49 @@ func main() {
 		admin.GET("/contents", handlers.ListContentsHandler)
 		admin.GET("/users", handlers.ListUsersHandler)
 		admin.PUT("/users/:id/role", handlers.ChangeUserRoleHandler)
+		admin.GET("/users/:id/sanctions", sanctionHandler.UserHistory)
+		admin.POST("/users/:id/sanctions", sanctionHandler.Issue)
+		admin.GET("/sanctions", sanctionHandler.ListActive)
+		admin.PUT("/sanctions/:id/lift", sanctionHandler.Lift)
 		admin.DELETE("/contents/:id", handlers.DeleteContentHandler)
-		admin.PUT("/contents/:id/approve", handlers.ApproveContentHandler)
-		admin.PUT("/contents/:id/reject", handlers.RejectContentHandler)
+		admin.PUT("/contents/:id/approve", moderationHandler.Approve)
+		admin.PUT("/contents/:id/reject", moderationHandler.Reject)
+		admin.GET("/contents/:id/moderation", moderationHandler.ContentHistory)
+		admin.GET("/moderation/reasons", moderationHandler.ListReasons)
+		admin.GET("/moderation/queue", textModerationHandler.Queue)
+		admin.PUT("/moderation/queue/:id/approve", textModerationHandler.Approve)
+		admin.PUT("/moderation/queue/:id/reject", textModerationHandler.Reject)
+		admin.PUT("/contents/:id/maturity", contentHandler.OverrideMaturity)
+		admin.GET("/duplicates", duplicateHandler.ListFlags)
+		admin.PUT("/duplicates/:id", duplicateHandler.ReviewFlag)
+		admin.POST("/search/image", duplicateHandler.SearchByImage)
+		admin.GET("/search/misses", searchHandler.SearchMisses)
+		admin.GET("/age-verifications", ageHandler.ListPendingAgeVerifications)
+		admin.PUT("/users/:id/age-verification", ageHandler.ReviewAgeVerification)
+		admin.DELETE("/users/:id/age-verification", ageHandler.ResetAgeVerification)
 
 		admin.GET("/stats", adminStatsHandler.GetStats)
+		admin.GET("/stats/daily", adminStatsHandler.GetDailyStats)
 		admin.GET("/dashboard", adminStatsHandler.GetDashboard)
 		admin.GET("/top-creators", adminStatsHandler.GetTopCreators)
 		admin.GET("/top-contents", adminStatsHandler.GetTopContents)
 		admin.GET("/flop-contents", adminStatsHandler.GetFlopContents)
 		admin.GET("/revenue-chart", adminStatsHandler.GetRevenueChart)
 		admin.GET("/quick-stats", adminStatsHandler.GetQuickStats)
+		admin.GET("/exports/:dataset", exportHandler.AdminExport)
 		admin.GET("/features", handlers.ListFeaturesHandler)
 		admin.PUT("/features/:key", handlers.UpdateFeatureHandler)
 		admin.GET("/comments", adminCommentHandler.ListComments)
 		admin.DELETE("/comments/:id", adminCommentHandler.DeleteComment)
-		admin.GET("/reports", handlers.ListReportsHandler)
+		admin.GET("/reports", reportHandler.List)
+		admin.PUT("/reports/:id/assign", reportHandler.Assign)
+		admin.PUT("/reports/:id/resolve", reportHandler.Resolve)
+		admin.PUT("/reports/:id/dismiss", reportHandler.Dismiss)
+		admin.POST("/categories", taxonomyHandler.CreateCategory)
+		admin.PUT("/categories/:id", taxonomyHandler.UpdateCategory)
+		admin.DELETE("/categories/:id", taxonomyHandler.DeleteCategory)
+		admin.GET("/audit", auditHandler.List)
+		admin.GET("/audit/verify", auditHandler.Verify)
 	}
 
 	logger.LogBusinessEvent("application_started", map[string]interface{}{
@@ -159,9 +321,27 @@ func main() {
 	})
 
 	addr := fmt.Sprintf(":%s", config.C.Port)
-	log.Printf("🚀 Serveur sur %s…", addr)
-	if err := r.Run(addr); err != nil {
-		logger.LogError(err, "server_failed", nil)
-		log.Fatalf("❌ Erreur serveur : %v", err)
+	srv := &http.Server{Addr: addr, Handler: r}
+	go func() {
+		log.Printf("🚀 Serveur sur %s…", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.LogError(err, "server_failed", nil)
+			log.Fatalf("❌ Erreur serveur : %v", err)
+		}
+	}()
+
+	// Arrêt propre : on laisse finir les requêtes en cours, puis on écrit
+	// les événements de contenu encore en file.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	log.Printf("🛑 Arrêt du serveur…")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.LogError(err, "server_shutdown_failed", nil)
 	}
+	contentEventSvc.Stop()
+	exportSvc.Wait()
+	log.Printf("👋 Serveur arrêté")
 }
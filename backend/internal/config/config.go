@@ -3,6 +3,10 @@ package config
 import (
 	"log"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -13,6 +17,42 @@ type Config struct {
 	StripeKey   string
 	Port        string
 	UploadPath  string
+
+	ImageCacheMaxBytes int64
+	WatermarkSecret    string
+
+	UploadMaxBytes     int64
+	UploadMaxPixels    int64
+	UploadMaxDimension int
+
+	UploadTmpPath            string
+	ResumableMaxBytesCreator int64
+	ResumableMaxBytesAdmin   int64
+	UploadSessionTTL         time.Duration
+
+	DuplicateMaxDistance int
+
+	// Listes séparées par des virgules ; un terme préfixé par "re:" est une
+	// expression régulière.
+	TextBlocklist   []string
+	TextHoldlist    []string
+	TextMaxLinks    int
+	TextRepeatLimit int
+
+	// Poids des scoreurs du fil « pour vous », au format "recency=1,seen=-0.4".
+	FeedWeights  map[string]float64
+	FeedHalfLife time.Duration
+
+	// Au-delà de ExportAsyncRows lignes, un export est généré en tâche de
+	// fond dans ExportPath (volume partagé entre les réplicas) et conservé
+	// ExportTTL.
+	ExportPath      string
+	ExportAsyncRows int64
+	ExportTTL       time.Duration
+
+	// Identifiant de la réplica (nom du pod), enregistré sur les exports
+	// qu'elle génère.
+	InstanceID string
 }
 
 var C Config
@@ -31,7 +71,138 @@ func LoadEnv() {
 	} else {
 		C.Port = os.Getenv("PORT")
 	}
+	C.WatermarkSecret = os.Getenv("WATERMARK_SECRET")
+	if C.WatermarkSecret == "" {
+		C.WatermarkSecret = C.JwtSecret
+	}
+
+	// Par réplica : à garder bien en dessous de la limite mémoire du pod
+	// (512 Mi), qui doit aussi absorber le décodage des images.
+	C.ImageCacheMaxBytes = 64 << 20
+	if v, err := strconv.ParseInt(os.Getenv("IMAGE_CACHE_MAX_BYTES"), 10, 64); err == nil && v > 0 {
+		C.ImageCacheMaxBytes = v
+	}
+
+	C.UploadMaxBytes = 25 << 20
+	if v, err := strconv.ParseInt(os.Getenv("UPLOAD_MAX_BYTES"), 10, 64); err == nil && v > 0 {
+		C.UploadMaxBytes = v
+	}
+	C.UploadMaxPixels = 50_000_000
+	if v, err := strconv.ParseInt(os.Getenv("UPLOAD_MAX_PIXELS"), 10, 64); err == nil && v > 0 {
+		C.UploadMaxPixels = v
+	}
+	C.UploadMaxDimension = 12000
+	if v, err := strconv.Atoi(os.Getenv("UPLOAD_MAX_DIMENSION")); err == nil && v > 0 {
+		C.UploadMaxDimension = v
+	}
+
+	// Les morceaux d'un upload reprenable peuvent arriver sur n'importe
+	// quelle réplica : ils vont par défaut sur le volume partagé des uploads.
+	C.UploadTmpPath = os.Getenv("UPLOAD_TMP_PATH")
+	if C.UploadTmpPath == "" {
+		C.UploadTmpPath = filepath.Join(C.UploadPath, ".resumable")
+	}
+	C.ResumableMaxBytesCreator = 512 << 20
+	if v, err := strconv.ParseInt(os.Getenv("RESUMABLE_MAX_BYTES_CREATOR"), 10, 64); err == nil && v > 0 {
+		C.ResumableMaxBytesCreator = v
+	}
+	C.ResumableMaxBytesAdmin = 2 << 30
+	if v, err := strconv.ParseInt(os.Getenv("RESUMABLE_MAX_BYTES_ADMIN"), 10, 64); err == nil && v > 0 {
+		C.ResumableMaxBytesAdmin = v
+	}
+	C.UploadSessionTTL = 24 * time.Hour
+	if v, err := time.ParseDuration(os.Getenv("UPLOAD_SESSION_TTL")); err == nil && v > 0 {
+		C.UploadSessionTTL = v
+	}
+
+	C.DuplicateMaxDistance = 6
+	if v, err := strconv.Atoi(os.Getenv("DUPLICATE_MAX_DISTANCE")); err == nil && v >= 0 {
+		C.DuplicateMaxDistance = v
+	}
+
+	C.TextBlocklist = loadTerms("TEXT_BLOCKLIST")
+	C.TextHoldlist = loadTerms("TEXT_HOLDLIST")
+	C.TextMaxLinks = 2
+	if v, err := strconv.Atoi(os.Getenv("TEXT_MAX_LINKS")); err == nil && v >= 0 {
+		C.TextMaxLinks = v
+	}
+	C.TextRepeatLimit = 3
+	if v, err := strconv.Atoi(os.Getenv("TEXT_REPEAT_LIMIT")); err == nil && v > 0 {
+		C.TextRepeatLimit = v
+	}
+
+	C.FeedWeights = parseWeights(os.Getenv("FEED_WEIGHTS"))
+	C.FeedHalfLife = 24 * time.Hour
+	if v, err := time.ParseDuration(os.Getenv("FEED_HALF_LIFE")); err == nil && v > 0 {
+		C.FeedHalfLife = v
+	}
+
+	// Un export peut être téléchargé depuis n'importe quelle réplica : il va
+	// par défaut sur le volume partagé des uploads.
+	C.ExportPath = os.Getenv("EXPORT_PATH")
+	if C.ExportPath == "" {
+		C.ExportPath = filepath.Join(C.UploadPath, ".exports")
+	}
+	C.ExportAsyncRows = 5000
+	if v, err := strconv.ParseInt(os.Getenv("EXPORT_ASYNC_ROWS"), 10, 64); err == nil && v > 0 {
+		C.ExportAsyncRows = v
+	}
+	C.ExportTTL = 24 * time.Hour
+	if v, err := time.ParseDuration(os.Getenv("EXPORT_TTL")); err == nil && v > 0 {
+		C.ExportTTL = v
+	}
+	C.InstanceID = os.Getenv("INSTANCE_ID")
+	if C.InstanceID == "" {
+		C.InstanceID, _ = os.Hostname()
+	}
+
 	if C.DatabaseURL == "" {
 		log.Fatal("DATABASE_URL manquant")
 	}
 }
+
+func splitList(raw string) []string {
+	var out []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// loadTerms lit une liste de termes de modération, un par ligne : la virgule
+// peut apparaître dans une expression "re:", pas le saut de ligne. La liste
+// vient du fichier <name>_FILE s'il est défini (lignes "#" ignorées), sinon
+// de la variable <name> elle-même.
+func loadTerms(name string) []string {
+	raw := os.Getenv(name)
+	if path := os.Getenv(name + "_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatalf("%s_FILE illisible: %v", name, err)
+		}
+		raw = string(data)
+	}
+	var out []string
+	for _, line := range strings.Split(raw, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+func parseWeights(raw string) map[string]float64 {
+	out := map[string]float64{}
+	for _, item := range splitList(raw) {
+		name, value, ok := strings.Cut(item, "=")
+		w, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !ok || err != nil {
+			log.Printf("Poids de fil ignoré : %q", item)
+			continue
+		}
+		out[strings.TrimSpace(name)] = w
+	}
+	return out
+}
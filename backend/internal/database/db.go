@@ -54,6 +54,23 @@ func Init() {
       END$$;`).Error; err != nil {
 		log.Fatalf("❌ Impossible de créer enum content_status : %v", err)
 	}
+	for _, status := range []string{"draft", "scheduled", "archived"} {
+		if err := DB.Exec(fmt.Sprintf(`ALTER TYPE content_status ADD VALUE IF NOT EXISTS '%s'`, status)).Error; err != nil {
+			log.Fatalf("❌ Impossible d'ajouter %s à content_status : %v", status, err)
+		}
+	}
+
+	// Les anciens signalements ne visaient que des contenus, avec un motif libre.
+	if err := DB.Exec(`
+      DO $$ BEGIN
+        IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'report' AND column_name = 'target_content_id') THEN
+          ALTER TABLE report RENAME COLUMN target_content_id TO target_id;
+          ALTER TABLE report RENAME COLUMN reason TO details;
+          ALTER TABLE report ALTER COLUMN details DROP NOT NULL;
+        END IF;
+      END$$;`).Error; err != nil {
+		log.Fatalf("❌ Migration des signalements impossible : %v", err)
+	}
 
 	if err := DB.AutoMigrate(
 		&models.User{},
@@ -64,9 +81,119 @@ func Init() {
 		&models.Like{},
 		&models.Message{},
 		&models.Report{},
+		&models.ForensicMark{},
+		&models.UploadSession{},
+		&models.ContentRevision{},
+		&models.Tag{},
+		&models.ContentTag{},
+		&models.Category{},
+		&models.Collection{},
+		&models.CollectionItem{},
+		&models.ImageHashBand{},
+		&models.DuplicateFlag{},
+		&models.ModerationDecision{},
+		&models.Notification{},
+		&models.Sanction{},
+		&models.AuditEntry{},
+		&models.TextReview{},
+		&models.ActivityBucket{},
+		&models.TrendingScore{},
+		&models.RecentSearch{},
+		&models.SearchMiss{},
+		&models.ContentEvent{},
+		&models.ContentDailyStat{},
+		&models.PlatformDailyStat{},
+		&models.ExportJob{},
 	); err != nil {
 		log.Fatalf("❌ AutoMigrate a échoué : %v", err)
 	}
 
+	// Les anciens contenus floutés passent en mode d'aperçu "blur".
+	if err := DB.Exec(`UPDATE content SET preview_mode = 'blur' WHERE is_blurred = true AND preview_mode = 'watermark'`).Error; err != nil {
+		log.Printf("⚠️ Migration des aperçus floutés : %v", err)
+	}
+
+	// Un seul signalement ouvert par auteur et par cible. Les doublons
+	// antérieurs sont classés (le signalement en cours d'examen, sinon le plus
+	// ancien, est gardé) : sans l'index, le refus des doublons ne tient plus.
+	if err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+      UPDATE report SET status = 'dismissed', resolution_action = 'no_action',
+        resolution_note = 'Doublon d''un signalement ouvert', resolved_at = now(), updated_at = now()
+      WHERE id IN (
+        SELECT id FROM (
+          SELECT id, row_number() OVER (
+            PARTITION BY reporter_id, target_type, target_id
+            ORDER BY (status = 'in_review') DESC, created_at, id) AS rank
+          FROM report WHERE status IN ('open', 'in_review')) dup
+        WHERE rank > 1)`).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_report_open_unique ON report (reporter_id, target_type, target_id) WHERE status IN ('open', 'in_review')`).Error
+	}); err != nil {
+		log.Fatalf("❌ Index d'unicité des signalements impossible : %v", err)
+	}
+
+	// Le journal d'audit est en ajout seul : la base refuse toute
+	// modification ou suppression, en plus du chaînage des hashs.
+	if err := DB.Exec(`
+      CREATE OR REPLACE FUNCTION audit_entry_immutable() RETURNS trigger AS $$
+      BEGIN
+        RAISE EXCEPTION 'audit_entry est en ajout seul';
+      END;
+      $$ LANGUAGE plpgsql;
+      DROP TRIGGER IF EXISTS trg_audit_entry_immutable ON audit_entry;
+      CREATE TRIGGER trg_audit_entry_immutable
+        BEFORE UPDATE OR DELETE ON audit_entry
+        FOR EACH ROW EXECUTE FUNCTION audit_entry_immutable();`).Error; err != nil {
+		log.Printf("⚠️ Protection du journal d'audit : %v", err)
+	}
+
+	// Recherche plein texte : vecteurs français et anglais générés par la
+	// base, index trigrammes pour la tolérance aux fautes de frappe.
+	if err := DB.Exec(`
+      CREATE EXTENSION IF NOT EXISTS pg_trgm;
+      ALTER TABLE content ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('french', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('french', coalesce(body, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(body, '')), 'B')) STORED;
+      ALTER TABLE "user" ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(username, '')), 'A') ||
+        setweight(to_tsvector('french', coalesce(bio, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(bio, '')), 'B')) STORED;
+      CREATE INDEX IF NOT EXISTS idx_content_search ON content USING GIN (search_vector);
+      CREATE INDEX IF NOT EXISTS idx_user_search ON "user" USING GIN (search_vector);
+      CREATE INDEX IF NOT EXISTS idx_content_title_trgm ON content USING GIN (title gin_trgm_ops);
+      CREATE INDEX IF NOT EXISTS idx_content_title_lower_trgm ON content USING GIN (lower(title) gin_trgm_ops);
+      CREATE INDEX IF NOT EXISTS idx_user_username_trgm ON "user" USING GIN (username gin_trgm_ops);
+      CREATE INDEX IF NOT EXISTS idx_user_username_lower_trgm ON "user" USING GIN (lower(username) gin_trgm_ops);
+      CREATE INDEX IF NOT EXISTS idx_tag_name_trgm ON tag USING GIN (name gin_trgm_ops);`).Error; err != nil {
+		log.Printf("⚠️ Index de recherche plein texte : %v", err)
+	}
+
+	seedCategories()
+
 	fmt.Println("✅ Base de données prête.")
 }
+
+// seedCategories crée la taxonomie de catégories initiale ; les admins la
+// gèrent ensuite via /api/admin/categories.
+func seedCategories() {
+	defaults := []models.Category{
+		{Slug: "illustration", Name: "Illustration", Position: 1},
+		{Slug: "painting", Name: "Peinture", Position: 2},
+		{Slug: "digital-art", Name: "Art numérique", Position: 3},
+		{Slug: "3d", Name: "3D", Position: 4},
+		{Slug: "photography", Name: "Photographie", Position: 5},
+		{Slug: "sculpture", Name: "Sculpture", Position: 6},
+		{Slug: "comics", Name: "BD & Manga", Position: 7},
+	}
+	var count int64
+	if err := DB.Model(&models.Category{}).Count(&count).Error; err != nil || count > 0 {
+		return
+	}
+	if err := DB.Create(&defaults).Error; err != nil {
+		log.Printf("⚠️ Impossible de créer les catégories par défaut : %v", err)
+	}
+}
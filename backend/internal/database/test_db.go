@@ -2,9 +2,12 @@ package database
 
 import (
 	"log"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 
@@ -42,3 +45,53 @@ func TestInitTest(t *testing.T) {
 	db := InitTest()
 	assert.NotNil(t, db)
 }
+
+// MigrateSQLite migre des modèles sur une base SQLite de test. Les défauts
+// Postgres comme uuid_generate_v4() ne sont pas compris par SQLite : ils sont
+// retirés du schéma et les clés primaires UUID sont générées côté Go.
+func MigrateSQLite(db *gorm.DB, dst ...interface{}) error {
+	for _, model := range dst {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		for _, field := range stmt.Schema.Fields {
+			if strings.Contains(field.DefaultValue, "(") {
+				field.DefaultValue = ""
+				field.DefaultValueInterface = nil
+				field.HasDefaultValue = false
+			}
+		}
+	}
+
+	if db.Callback().Create().Get("test:uuid") == nil {
+		err := db.Callback().Create().Before("gorm:create").Register("test:uuid", func(tx *gorm.DB) {
+			if tx.Statement.Schema == nil {
+				return
+			}
+			field := tx.Statement.Schema.PrioritizedPrimaryField
+			if field == nil || field.FieldType != reflect.TypeOf(uuid.UUID{}) {
+				return
+			}
+			setID := func(rv reflect.Value) {
+				if _, zero := field.ValueOf(tx.Statement.Context, rv); zero {
+					_ = field.Set(tx.Statement.Context, rv, uuid.New())
+				}
+			}
+			rv := tx.Statement.ReflectValue
+			switch rv.Kind() {
+			case reflect.Slice, reflect.Array:
+				for i := 0; i < rv.Len(); i++ {
+					setID(reflect.Indirect(rv.Index(i)))
+				}
+			case reflect.Struct:
+				setID(rv)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return db.AutoMigrate(dst...)
+}
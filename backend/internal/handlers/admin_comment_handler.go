@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
 )
 
@@ -50,6 +51,7 @@ func (h *AdminCommentHandler) ListComments(c *gin.Context) {
 			"text":        cm.Text,
 			"created_at":  cm.CreatedAt.Format(time.RFC3339),
 			"parent_id":   cm.ParentID,
+			"status":      cm.Status,
 			"author_name": cm.Author.Username,
 			"content": gin.H{
 				"id":         cm.Content.ID.String(),
@@ -72,9 +74,17 @@ func (h *AdminCommentHandler) DeleteComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de commentaire invalide"})
 		return
 	}
+	before, err := h.commentSvc.GetCommentByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	if err := h.commentSvc.DeleteCommentByID(id); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if !recordAudit(c, models.AuditCommentDeleted, "comment", id.String(), before, nil) {
+		return
+	}
 	c.Status(http.StatusNoContent)
 }
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -107,6 +108,15 @@ func ChangeUserRoleHandler(c *gin.Context) {
 		newRole = models.RoleSubscriber
 	}
 	userRepo := repositories.NewUserRepository()
+	target, err := userRepo.FindByID(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de changer le rôle"})
+		return
+	}
+	if target == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Utilisateur non trouvé"})
+		return
+	}
 	if err := userRepo.UpdateRole(userID, newRole); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Utilisateur non trouvé"})
@@ -115,6 +125,11 @@ func ChangeUserRoleHandler(c *gin.Context) {
 		}
 		return
 	}
+	if !recordAudit(c, models.AuditUserRoleChanged, "user", userID.String(),
+		gin.H{"role": target.Role}, gin.H{"role": newRole}) {
+		return
+	}
+
 	action := "promu"
 	if newRole == models.RoleSubscriber {
 		action = "rétrogradé"
@@ -130,15 +145,25 @@ func ListContentsHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de récupérer les contenus"})
 		return
 	}
+	flags, err := repositories.NewImageHashRepository().OpenFlagsByContent()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de récupérer les doublons signalés"})
+		return
+	}
 
 	var out []gin.H
 	for _, ct := range contents {
+		duplicates := make([]gin.H, 0, len(flags[ct.ID]))
+		for _, f := range flags[ct.ID] {
+			duplicates = append(duplicates, duplicateFlagLink(f))
+		}
 		out = append(out, gin.H{
-			"ID":        ct.ID,
-			"Title":     ct.Title,
-			"AuthorID":  ct.CreatorID,
-			"Status":    ct.Status,
-			"CreatedAt": ct.CreatedAt.Format(time.RFC3339),
+			"ID":          ct.ID,
+			"Title":       ct.Title,
+			"AuthorID":    ct.CreatorID,
+			"Status":      ct.Status,
+			"CreatedAt":   ct.CreatedAt.Format(time.RFC3339),
+			"DuplicateOf": duplicates,
 		})
 	}
 	c.JSON(http.StatusOK, gin.H{"contents": out})
@@ -152,8 +177,19 @@ func DeleteContentHandler(c *gin.Context) {
 		return
 	}
 
+	contentRepo := repositories.NewContentRepository()
+	before, err := contentRepo.FindByID(contentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Contenu non trouvé"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de supprimer le contenu"})
+		}
+		return
+	}
+
 	reportRepo := repositories.NewReportRepository()
-	if err := reportRepo.DeleteByContentID(contentID); err != nil {
+	if err := reportRepo.DeleteByTarget(models.ReportTargetContent, contentID); err != nil {
 		logger.LogError(err, "delete_reports_failed", map[string]interface{}{
 			"content_id": contentID,
 		})
@@ -161,7 +197,6 @@ func DeleteContentHandler(c *gin.Context) {
 		return
 	}
 
-	contentRepo := repositories.NewContentRepository()
 	uploadPath := config.C.UploadPath
 	if err := contentRepo.Delete(contentID, uploadPath); err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -175,10 +210,15 @@ func DeleteContentHandler(c *gin.Context) {
 		return
 	}
 
+	if !recordAudit(c, models.AuditContentDeleted, "content", contentID.String(), before, nil) {
+		return
+	}
+	adminID, _ := auditActor(c)
+
 	sentry.WithScope(func(scope *sentry.Scope) {
 		scope.SetLevel(sentry.LevelInfo)
 		scope.SetContext("admin_action", map[string]any{
-			"admin_id":   idStr,
+			"admin_id":   adminID.String(),
 			"action":     "delete_content",
 			"content_id": contentID.String(),
 		})
@@ -188,46 +228,6 @@ func DeleteContentHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Contenu supprimé"})
 }
 
-// ApproveContentHandler PUT /api/admin/contents/:id/approve
-func ApproveContentHandler(c *gin.Context) {
-	idStr := c.Param("id")
-	contentID, err := uuid.Parse(idStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
-		return
-	}
-	repo := repositories.NewContentRepository()
-	if err := repo.UpdateStatus(contentID, models.ContentStatusApproved); err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Contenu non trouvé"})
-		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible d’approuver le contenu"})
-		}
-		return
-	}
-	c.JSON(http.StatusOK, gin.H{"message": "Contenu approuvé"})
-}
-
-// RejectContentHandler PUT /api/admin/contents/:id/reject
-func RejectContentHandler(c *gin.Context) {
-	idStr := c.Param("id")
-	contentID, err := uuid.Parse(idStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
-		return
-	}
-	repo := repositories.NewContentRepository()
-	if err := repo.UpdateStatus(contentID, models.ContentStatusRejected); err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Contenu non trouvé"})
-		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de rejeter le contenu"})
-		}
-		return
-	}
-	c.JSON(http.StatusOK, gin.H{"message": "Contenu rejeté"})
-}
-
 // ListFeaturesHandler GET /api/admin/features
 func ListFeaturesHandler(c *gin.Context) {
 	featRepo := repositories.NewFeatureRepository(database.DB)
@@ -251,6 +251,11 @@ func UpdateFeatureHandler(c *gin.Context) {
 	}
 
 	featRepo := repositories.NewFeatureRepository(database.DB)
+	previous, err := featRepo.Find(c.Request.Context(), key)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de mettre à jour la feature"})
+		return
+	}
 	if err := featRepo.Update(c.Request.Context(), key, body.Enabled); err != nil {
 		switch err.Error() {
 		case "feature not found":
@@ -261,5 +266,13 @@ func UpdateFeatureHandler(c *gin.Context) {
 		return
 	}
 
+	var before interface{}
+	if previous != nil {
+		before = gin.H{"enabled": previous.Enabled}
+	}
+	if !recordAudit(c, models.AuditFeatureToggled, "feature", key, before, gin.H{"enabled": body.Enabled}) {
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Feature mise à jour"})
 }
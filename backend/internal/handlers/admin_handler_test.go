@@ -30,7 +30,11 @@ func setupAdminTest(t *testing.T) (router *gin.Engine, db *gorm.DB, adminToken,
 	if err := d.AutoMigrate(&models.User{}); err != nil {
 		t.Fatalf("Échec migration: %v", err)
 	}
+	if err := database.MigrateSQLite(d, &models.AuditEntry{}); err != nil {
+		t.Fatalf("Échec migration: %v", err)
+	}
 	database.DB = d
+	handlers.SetAuditService(services.NewAuditService(repositories.NewAuditRepository()))
 
 	userRepo := repositories.NewUserRepository()
 	authSvc := services.NewAuthService(userRepo)
@@ -96,6 +100,13 @@ func TestPromote_Success(t *testing.T) {
 	err := db.First(&u, "id = ?", subID).Error
 	assert.NoError(t, err)
 	assert.Equal(t, models.RoleCreator, u.Role)
+
+	var entry models.AuditEntry
+	assert.NoError(t, db.First(&entry, "target_id = ?", subID.String()).Error)
+	assert.Equal(t, models.AuditUserRoleChanged, entry.Action)
+	assert.Equal(t, string(models.RoleAdmin), entry.ActorRole)
+	assert.JSONEq(t, `{"role":"subscriber"}`, entry.Before)
+	assert.JSONEq(t, `{"role":"creator"}`, entry.After)
 }
 
 func TestPromote_ForbiddenNonAdmin(t *testing.T) {
@@ -142,3 +153,17 @@ func TestPromote_DowngradeToSubscriber(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, models.RoleSubscriber, u.Role)
 }
+
+func TestPromote_AuditFailureFailsRequest(t *testing.T) {
+	router, db, adminToken, _, subID := setupAdminTest(t)
+	assert.NoError(t, db.Migrator().DropTable(&models.AuditEntry{}))
+
+	reqBody := []byte(`{"role":"creator"}`)
+	req, _ := http.NewRequest("PUT", "/api/admin/users/"+subID.String()+"/role", bytes.NewBuffer(reqBody))
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
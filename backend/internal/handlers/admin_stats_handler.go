@@ -12,10 +12,8 @@ type AdminStatsHandler struct {
 	service *services.AdminStatsService
 }
 
-func NewAdminStatsHandler() *AdminStatsHandler {
-	return &AdminStatsHandler{
-		service: services.NewAdminStatsService(),
-	}
+func NewAdminStatsHandler(service *services.AdminStatsService) *AdminStatsHandler {
+	return &AdminStatsHandler{service: service}
 }
 
 // GetStats GET /api/admin/stats
@@ -198,6 +196,35 @@ func (h *AdminStatsHandler) GetRevenueChart(c *gin.Context) {
 	})
 }
 
+// GetDailyStats GET /api/admin/stats/daily?days=
+// Activité quotidienne de la plateforme (inscriptions, contenus, abonnements,
+// revenus, engagement), issue du cumul nocturne.
+func (h *AdminStatsHandler) GetDailyStats(c *gin.Context) {
+	days := 30
+	if daysParam := c.Query("days"); daysParam != "" {
+		if d, err := strconv.Atoi(daysParam); err == nil && d > 0 && d <= 365 {
+			days = d
+		}
+	}
+
+	stats, err := h.service.GetDailyStats(days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Impossible de récupérer les statistiques quotidiennes",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    stats,
+		"meta": gin.H{
+			"days": days,
+		},
+	})
+}
+
 // GetQuickStats GET /api/admin/quick-stats (pour affichage rapide)
 func (h *AdminStatsHandler) GetQuickStats(c *gin.Context) {
 	stats, err := h.service.GetBasicStats(30)
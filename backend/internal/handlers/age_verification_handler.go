@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+type AgeVerificationHandler struct {
+	service *services.AgeVerificationService
+}
+
+func NewAgeVerificationHandler(service *services.AgeVerificationService) *AgeVerificationHandler {
+	return &AgeVerificationHandler{service: service}
+}
+
+// viewerAllowsMature indique si l'utilisateur de la requête (éventuellement
+// anonyme) peut recevoir des contenus adultes.
+func viewerAllowsMature(c *gin.Context, ages *services.AgeVerificationService) bool {
+	viewerID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		return false
+	}
+	return ages.AllowsMature(viewerID)
+}
+
+func ageVerificationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidBirthDate):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrAgeAlreadyVerified), errors.Is(err, services.ErrNoPendingAgeVerification),
+		errors.Is(err, services.ErrBirthDateLocked):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *AgeVerificationHandler) ageJSON(user *models.User) gin.H {
+	return gin.H{
+		"user_id":             user.ID,
+		"age_verification":    h.service.State(user),
+		"age_verified_at":     user.AgeVerifiedAt,
+		"hide_mature_content": user.HideMatureContent,
+	}
+}
+
+// SubmitAgeVerification POST /api/users/me/age-verification
+// Corps : {"birth_date": "2000-01-31"}.
+func (h *AgeVerificationHandler) SubmitAgeVerification(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return
+	}
+	var payload struct {
+		BirthDate string `json:"birth_date" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+	birthDate, err := time.Parse("2006-01-02", payload.BirthDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "birth_date invalide (AAAA-MM-JJ attendu)"})
+		return
+	}
+
+	user, err := h.service.SubmitBirthDate(userID, birthDate)
+	if err != nil {
+		c.JSON(ageVerificationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, h.ageJSON(user))
+}
+
+// UpdateMaturePreference PUT /api/users/me/mature-content
+// Corps : {"hide": true} pour ne plus recevoir de contenus adultes.
+func (h *AgeVerificationHandler) UpdateMaturePreference(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return
+	}
+	var payload struct {
+		Hide *bool `json:"hide" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+
+	user, err := h.service.SetHideMatureContent(userID, *payload.Hide)
+	if err != nil {
+		c.JSON(ageVerificationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, h.ageJSON(user))
+}
+
+// ListPendingAgeVerifications GET /api/admin/age-verifications
+func (h *AgeVerificationHandler) ListPendingAgeVerifications(c *gin.Context) {
+	users, err := h.service.PendingAgeVerifications()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	out := make([]gin.H, 0, len(users))
+	for i := range users {
+		item := h.ageJSON(&users[i])
+		item["username"] = users[i].Username
+		item["birth_date"] = users[i].BirthDate
+		out = append(out, item)
+	}
+	c.JSON(http.StatusOK, gin.H{"verifications": out})
+}
+
+// ReviewAgeVerification PUT /api/admin/users/:id/age-verification
+// Corps : {"approved": true}.
+func (h *AgeVerificationHandler) ReviewAgeVerification(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return
+	}
+	var payload struct {
+		Approved *bool `json:"approved" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+
+	user, err := h.service.ReviewAgeVerification(userID, *payload.Approved)
+	if err != nil {
+		c.JSON(ageVerificationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	logger.LogSecurity("age_verification_reviewed", map[string]interface{}{
+		"admin_id": c.GetString("userID"),
+		"user_id":  userID.String(),
+		"status":   user.AgeVerificationStatus,
+	})
+	if !recordAudit(c, models.AuditAgeVerificationReview, "user", userID.String(),
+		gin.H{"age_verification_status": models.AgeVerificationPending}, gin.H{"age_verification_status": user.AgeVerificationStatus}) {
+		return
+	}
+	c.JSON(http.StatusOK, h.ageJSON(user))
+}
+
+// ResetAgeVerification DELETE /api/admin/users/:id/age-verification
+// Efface la date de naissance déclarée pour permettre une nouvelle déclaration.
+func (h *AgeVerificationHandler) ResetAgeVerification(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return
+	}
+
+	user, err := h.service.ResetBirthDate(userID)
+	if err != nil {
+		c.JSON(ageVerificationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	logger.LogSecurity("age_verification_reset", map[string]interface{}{
+		"admin_id": c.GetString("userID"),
+		"user_id":  userID.String(),
+	})
+	if !recordAudit(c, models.AuditAgeVerificationReset, "user", userID.String(), nil,
+		gin.H{"age_verification_status": user.AgeVerificationStatus}) {
+		return
+	}
+	c.JSON(http.StatusOK, h.ageJSON(user))
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+var auditService *services.AuditService
+
+// SetAuditService injecte le journal d'audit depuis main().
+func SetAuditService(s *services.AuditService) {
+	auditService = s
+}
+
+// recordAudit journalise une action d'administration réussie avec l'acteur,
+// l'IP et l'identifiant de la requête. Une action non tracée ne doit pas
+// passer pour réussie : si l'écriture échoue, la requête répond 500 et
+// recordAudit renvoie false.
+func recordAudit(c *gin.Context, action, targetType, targetID string, before, after interface{}) bool {
+	rec := services.AuditRecord{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     before,
+		After:      after,
+		IP:         c.ClientIP(),
+		RequestID:  c.GetString("requestID"),
+	}
+	rec.ActorID, rec.ActorRole = auditActor(c)
+	if _, err := auditService.Record(rec); err != nil {
+		logger.LogError(err, "audit_record", map[string]interface{}{
+			"action":      action,
+			"target_type": targetType,
+			"target_id":   targetID,
+			"request_id":  rec.RequestID,
+		})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Action effectuée mais non journalisée, contactez un administrateur"})
+		return false
+	}
+	return true
+}
+
+// auditActor renvoie l'utilisateur à l'origine de la requête : l'admin posé
+// par AdminMiddleware, sinon l'utilisateur authentifié par JWTAuth.
+func auditActor(c *gin.Context) (uuid.UUID, string) {
+	if raw, ok := c.Get("currentUser"); ok {
+		if user, ok := raw.(*models.User); ok {
+			return user.ID, string(user.Role)
+		}
+	}
+	id, _ := uuid.Parse(c.GetString("userID"))
+	return id, ""
+}
+
+type AuditHandler struct {
+	service *services.AuditService
+}
+
+func NewAuditHandler(service *services.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// List GET /api/admin/audit?actor_id=&action=&target_type=&target_id=&from=&to=&limit=&offset=
+// from et to sont des dates RFC 3339.
+func (h *AuditHandler) List(c *gin.Context) {
+	filter := repositories.AuditFilter{
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+	}
+	if raw := c.Query("actor_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "actor_id invalide"})
+			return
+		}
+		filter.ActorID = &id
+	}
+	for param, dst := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if raw := c.Query(param); raw != "" {
+			t, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": param + " doit être une date RFC 3339"})
+				return
+			}
+			*dst = &t
+		}
+	}
+
+	limit, offset := pageParams(c)
+	entries, total, err := h.service.List(filter, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de lire le journal d'audit"})
+		return
+	}
+	out := make([]gin.H, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, gin.H{
+			"id":          e.ID,
+			"seq":         e.Seq,
+			"actor_id":    e.ActorID,
+			"actor_role":  e.ActorRole,
+			"action":      e.Action,
+			"target_type": e.TargetType,
+			"target_id":   e.TargetID,
+			"before":      rawSnapshot(e.Before),
+			"after":       rawSnapshot(e.After),
+			"ip":          e.IP,
+			"request_id":  e.RequestID,
+			"created_at":  e.CreatedAt,
+			"prev_hash":   e.PrevHash,
+			"hash":        e.Hash,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"entries": out, "total": total, "limit": limit, "offset": offset})
+}
+
+// Verify GET /api/admin/audit/verify
+// Contrôle l'intégrité de la chaîne de hash du journal.
+func (h *AuditHandler) Verify(c *gin.Context) {
+	result, err := h.service.Verify()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de vérifier le journal d'audit"})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func rawSnapshot(s string) json.RawMessage {
+	if s == "" {
+		return nil
+	}
+	return json.RawMessage(s)
+}
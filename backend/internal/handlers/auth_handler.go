@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"sync"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/sentry"
@@ -16,6 +19,18 @@ var authService *services.AuthService
 var loginAttempts = make(map[string]int)
 var mu sync.Mutex
 
+var (
+	loginFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_login_failures_total",
+		Help: "Failed logins by reason (invalid_credentials, banned, suspended, error)",
+	}, []string{"reason"})
+
+	loginLockouts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "auth_login_lockouts_total",
+		Help: "Logins refused after too many failed attempts",
+	})
+)
+
 // SetAuthService permet d’injecter l’instance d’AuthService depuis main()
 func SetAuthService(s *services.AuthService) {
 	authService = s
@@ -88,6 +103,7 @@ func LoginHandler(c *gin.Context) {
 	mu.Unlock()
 
 	if attempts >= 5 {
+		loginLockouts.Inc()
 		sentry.CaptureAuthError("multiple_failed_logins", email, ip, "too_many_attempts")
 		logger.LogSecurity("login_blocked", map[string]any{
 			"email":    email,
@@ -100,12 +116,18 @@ func LoginHandler(c *gin.Context) {
 
 	token, loginErr := authService.Login(email, payload.Password)
 	if loginErr != nil {
+		loginFailures.WithLabelValues(loginFailureReason(loginErr)).Inc()
 		logger.LogBusinessEvent("login_failed", map[string]any{
 			"email": email,
 			"ip":    ip,
 			"error": loginErr.Error(),
 		})
-		c.JSON(http.StatusUnauthorized, gin.H{"error": loginErr.Error()})
+		status := http.StatusUnauthorized
+		if errors.Is(loginErr, services.ErrSanctionCheckUnavailable) {
+			status = http.StatusServiceUnavailable
+			loginErr = services.ErrSanctionCheckUnavailable
+		}
+		c.JSON(status, gin.H{"error": loginErr.Error()})
 		return
 	}
 
@@ -124,3 +146,17 @@ func LoginHandler(c *gin.Context) {
 		mu.Unlock()
 	}
 }
+
+func loginFailureReason(err error) string {
+	switch {
+	case errors.Is(err, services.ErrInvalidCredentials):
+		return "invalid_credentials"
+	case errors.Is(err, services.ErrAccountBanned):
+		return "banned"
+	case errors.Is(err, services.ErrAccountSuspended):
+		return "suspended"
+	case errors.Is(err, services.ErrSanctionCheckUnavailable):
+		return "unavailable"
+	}
+	return "error"
+}
@@ -67,6 +67,14 @@ func (h *CommentHandler) PostComment(c *gin.Context) {
 	}
 
 	comment, err := h.svc.PostComment(contentID, authorID, body.Text, body.ParentID)
+	if isSanctionError(err) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if status, ok := textErrorStatus(err); ok {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de poster le commentaire"})
 		return
@@ -77,6 +85,7 @@ func (h *CommentHandler) PostComment(c *gin.Context) {
 		"author_id":  comment.AuthorID,
 		"text":       comment.Text,
 		"parent_id":  comment.ParentID,
+		"status":     comment.Status,
 		"created_at": comment.CreatedAt.Format(time.RFC3339),
 	})
 }
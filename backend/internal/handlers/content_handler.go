@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -8,20 +9,24 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
 )
 
 type ContentHandler struct {
 	service *services.ContentService
+	ages    *services.AgeVerificationService
 }
 
-func NewHandler(s *services.ContentService) *ContentHandler {
-	return &ContentHandler{service: s}
+func NewHandler(s *services.ContentService, ages *services.AgeVerificationService) *ContentHandler {
+	return &ContentHandler{service: s, ages: ages}
 }
 
 // CreateContent POST /api/contents (protégé par JWTAuth)
@@ -37,6 +42,10 @@ func (h *ContentHandler) CreateContent(c *gin.Context) {
 		return
 	}
 
+	// Fichier + logo éventuel + champs du formulaire.
+	limits := services.DefaultUploadLimits()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, 2*limits.MaxBytes+1<<20)
+
 	username := c.PostForm("username")
 	role := c.PostForm("role")
 
@@ -58,11 +67,36 @@ func (h *ContentHandler) CreateContent(c *gin.Context) {
 
 	fileHeader, err := c.FormFile("file")
 	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": services.ErrUploadTooLarge.Error()})
+			return
+		}
 		log.Printf("[CreateContent] fichier manquant: %v", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Fichier requis"})
 		return
 	}
 
+	preview, err := previewFromForm(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	logoHeader, _ := c.FormFile("preview_logo")
+
+	publication := services.ContentPublication{
+		Draft:    c.PostForm("status") == models.ContentStatusDraft,
+		Maturity: c.PostForm("maturity"),
+	}
+	if v := c.PostForm("publish_at"); v != "" {
+		publishAt, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "publish_at invalide (RFC 3339 attendu)"})
+			return
+		}
+		publication.PublishAt = &publishAt
+	}
+
 	content, err := h.service.CreateContent(
 		userID,
 		username,
@@ -71,19 +105,26 @@ func (h *ContentHandler) CreateContent(c *gin.Context) {
 		price,
 		fileHeader,
 		role,
+		preview,
+		logoHeader,
+		publication,
 	)
 	if err != nil {
 		log.Printf("[CreateContent] service error: %v", err)
-		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		c.JSON(uploadErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"id":        content.ID,
-		"title":     content.Title,
-		"body":      content.Body,
-		"price":     content.Price,
-		"file_path": content.FilePath,
+		"id":         content.ID,
+		"title":      content.Title,
+		"body":       content.Body,
+		"price":      content.Price,
+		"file_path":  content.FilePath,
+		"preview":    content.Preview,
+		"status":     content.Status,
+		"publish_at": content.PublishAt,
+		"maturity":   content.Maturity,
 	})
 
 	logger.LogContent("content_created", userID.String(), content.ID.String(), map[string]interface{}{
@@ -93,21 +134,58 @@ func (h *ContentHandler) CreateContent(c *gin.Context) {
 	})
 }
 
+// GET /api/contents
+// Liste publique des contenus publiés ; les contenus adultes n'y figurent que
+// pour un utilisateur connecté, majeur et vérifié.
 func (h *ContentHandler) GetAllContents(c *gin.Context) {
-	log.Println("➡️ GetAllContents called")
-
-	userIDRaw, ok := c.Get("userID")
-	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "non autorisé"})
+	contents, err := h.service.GetAllContents(viewerAllowsMature(c, h.ages))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
 		return
 	}
-	userID, err := uuid.Parse(userIDRaw.(string))
+
+	c.JSON(http.StatusOK, gin.H{"contents": publicContentsJSON(contents, nil)})
+}
+
+// publicContentsJSON met en forme une liste publique de contenus ; tags est
+// optionnel (contenu → noms de tags).
+func publicContentsJSON(contents []models.Content, tags map[uuid.UUID][]string) []gin.H {
+	out := make([]gin.H, 0, len(contents))
+	for _, ct := range contents {
+		item := gin.H{
+			"id":           ct.ID,
+			"title":        ct.Title,
+			"body":         ct.Body,
+			"price":        ct.Price,
+			"creator_id":   ct.CreatorID,
+			"creator_name": ct.Creator.Username,
+			"category_id":  ct.CategoryID,
+			"maturity":     ct.Maturity,
+			"created_at":   ct.CreatedAt,
+			"published_at": ct.PublishedAt,
+		}
+		if tags != nil {
+			item["tags"] = tags[ct.ID]
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// GET /api/creator/contents?status=
+// Contenus du créateur connecté, brouillons et archives compris.
+func (h *ContentHandler) GetMyContents(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID utilisateur invalide"})
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "non autorisé"})
 		return
 	}
 
-	contents, err := h.service.GetContentsByUserID(userID)
+	contents, err := h.service.GetCreatorContents(userID, c.Query("status"))
+	if errors.Is(err, services.ErrInvalidStatus) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
 		return
@@ -116,6 +194,148 @@ func (h *ContentHandler) GetAllContents(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"contents": contents})
 }
 
+// POST /api/contents/:id/submit
+func (h *ContentHandler) SubmitContent(c *gin.Context) {
+	h.changeLifecycle(c, h.service.SubmitContent)
+}
+
+// POST /api/contents/:id/archive
+func (h *ContentHandler) ArchiveContent(c *gin.Context) {
+	h.changeLifecycle(c, h.service.ArchiveContent)
+}
+
+// POST /api/contents/:id/unarchive
+func (h *ContentHandler) UnarchiveContent(c *gin.Context) {
+	h.changeLifecycle(c, h.service.UnarchiveContent)
+}
+
+// PUT /api/contents/:id/schedule
+// Corps : {"publish_at": "2025-06-01T18:00:00Z"} ou {"publish_at": null}.
+func (h *ContentHandler) ScheduleContent(c *gin.Context) {
+	var payload struct {
+		PublishAt *time.Time `json:"publish_at"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+	h.changeLifecycle(c, func(content *models.Content) error {
+		return h.service.ScheduleContent(content, payload.PublishAt)
+	})
+}
+
+// changeLifecycle applique une transition de statut sur un contenu du
+// créateur connecté.
+func (h *ContentHandler) changeLifecycle(c *gin.Context, transition func(*models.Content) error) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return
+	}
+
+	content, err := h.service.GetContentByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Contenu non trouvé"})
+		return
+	}
+	if c.GetString("userID") != content.CreatorID.String() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Interdit"})
+		return
+	}
+
+	if err := transition(content); err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidTransition):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidPublishAt), errors.Is(err, services.ErrInvalidMaturity):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrMaturityLocked):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case isSanctionError(err):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur mise à jour du statut"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id":           content.ID,
+		"status":       content.Status,
+		"publish_at":   content.PublishAt,
+		"published_at": content.PublishedAt,
+		"archived_at":  content.ArchivedAt,
+		"maturity":     content.Maturity,
+	})
+}
+
+// PUT /api/contents/:id/maturity
+// Corps : {"maturity": "general" | "mature" | "explicit"}.
+func (h *ContentHandler) SetMaturity(c *gin.Context) {
+	var payload struct {
+		Maturity string `json:"maturity" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+	h.changeLifecycle(c, func(content *models.Content) error {
+		return h.service.SetMaturity(content, payload.Maturity)
+	})
+}
+
+// PUT /api/admin/contents/:id/maturity
+// Classification imposée par la modération.
+func (h *ContentHandler) OverrideMaturity(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return
+	}
+	moderatorID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return
+	}
+	var payload struct {
+		Maturity string `json:"maturity" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+
+	previous := ""
+	if current, err := h.service.GetContentByID(contentID); err == nil && current != nil {
+		previous = current.Maturity
+	}
+	content, err := h.service.OverrideMaturity(contentID, moderatorID, payload.Maturity)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidMaturity):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrContentNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de modifier la classification"})
+		}
+		return
+	}
+
+	logger.LogContent("content_maturity_overridden", moderatorID.String(), contentID.String(), map[string]interface{}{
+		"maturity": content.Maturity,
+	})
+	if !recordAudit(c, models.AuditContentMaturity, "content", contentID.String(),
+		gin.H{"maturity": previous}, gin.H{"maturity": content.Maturity}) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"id":                    content.ID,
+		"maturity":              content.Maturity,
+		"maturity_moderated_by": content.MaturityModeratedBy,
+	})
+}
+
 // GET /api/contents/:id
 func (h *ContentHandler) GetContentByID(c *gin.Context) {
 	idParam := c.Param("id")
@@ -168,21 +388,27 @@ func (h *ContentHandler) UpdateContent(c *gin.Context) {
 		return
 	}
 
-	var payload struct {
-		Title string `json:"title"`
-		Body  string `json:"body"`
-		Price int    `json:"price"`
+	editorID, err := uuid.Parse(userID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return
 	}
+
+	var payload services.ContentEdit
 	if err := c.ShouldBindJSON(&payload); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
 		return
 	}
 
-	existing.Title = payload.Title
-	existing.Body = payload.Body
-	existing.Price = payload.Price
-
-	if err := h.service.UpdateContent(existing); err != nil {
+	if err := h.service.EditContent(existing, editorID, payload); err != nil {
+		if errors.Is(err, services.ErrInvalidContentEdit) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if status, ok := textErrorStatus(err); ok {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur update"})
 		return
 	}
@@ -190,6 +416,180 @@ func (h *ContentHandler) UpdateContent(c *gin.Context) {
 	c.JSON(http.StatusOK, existing)
 }
 
+// PUT /api/contents/:id/file
+// Remplace l'image (champ multipart "file") ; l'ancienne version est conservée
+// dans l'historique et le contenu repasse en modération.
+func (h *ContentHandler) ReplaceContentFile(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return
+	}
+
+	existing, err := h.service.GetContentByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Contenu non trouvé"})
+		return
+	}
+	editorID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil || editorID != existing.CreatorID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Interdit"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, services.DefaultUploadLimits().MaxBytes+1<<20)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": services.ErrUploadTooLarge.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Fichier requis"})
+		return
+	}
+
+	if err := h.service.ReplaceContentFile(existing, editorID, fileHeader); err != nil {
+		c.JSON(uploadErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, existing)
+}
+
+// GET /api/contents/:id/revisions
+// Historique des modifications, visible par le créateur et les admins.
+func (h *ContentHandler) GetRevisions(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return
+	}
+
+	content, err := h.service.GetContentByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Contenu non trouvé"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return
+	}
+	if userID != content.CreatorID {
+		user, err := repositories.NewUserRepository().FindByID(userID)
+		if err != nil || user == nil || user.Role != models.RoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Accès interdit"})
+			return
+		}
+	}
+
+	revisions, err := h.service.GetRevisions(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+// PUT /api/contents/:id/preview
+func (h *ContentHandler) UpdatePreview(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return
+	}
+
+	content, err := h.service.GetContentByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Contenu non trouvé"})
+		return
+	}
+	if c.GetString("userID") != content.CreatorID.String() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Interdit"})
+		return
+	}
+
+	preview, err := previewFromForm(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	logoHeader, _ := c.FormFile("preview_logo")
+	removeLogo := c.PostForm("remove_logo") == "true"
+
+	if err := h.service.UpdatePreview(content, preview, logoHeader, removeLogo); err != nil {
+		if status := uploadErrorStatus(err); status != http.StatusForbidden {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur mise à jour de l'aperçu"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preview": content.Preview})
+}
+
+// uploadErrorStatus associe les erreurs de validation d'upload à un code HTTP.
+func uploadErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrUploadTooLarge), errors.Is(err, services.ErrUploadDimensions):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, services.ErrUploadUnsupported):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, services.ErrUploadCorruptedFile), errors.Is(err, services.ErrInvalidPreview):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrTextBlocked):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrContentNotEditable):
+		return http.StatusConflict
+	default:
+		return http.StatusForbidden
+	}
+}
+
+// previewFromForm lit les réglages d'aperçu (champs preview_*) du formulaire.
+func previewFromForm(c *gin.Context) (models.PreviewSettings, error) {
+	p := models.PreviewSettings{
+		Mode:              c.PostForm("preview_mode"),
+		WatermarkText:     c.PostForm("preview_watermark_text"),
+		WatermarkPosition: c.PostForm("preview_watermark_position"),
+	}
+
+	ints := map[string]*int{
+		"preview_blur_radius": &p.BlurRadius,
+		"preview_pixel_size":  &p.PixelSize,
+	}
+	for field, dst := range ints {
+		if v := c.PostForm(field); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return p, fmt.Errorf("%s invalide", field)
+			}
+			*dst = n
+		}
+	}
+
+	floats := map[string]*float64{
+		"preview_crop_x":            &p.CropX,
+		"preview_crop_y":            &p.CropY,
+		"preview_crop_width":        &p.CropWidth,
+		"preview_crop_height":       &p.CropHeight,
+		"preview_watermark_opacity": &p.WatermarkOpacity,
+	}
+	for field, dst := range floats {
+		if v := c.PostForm(field); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return p, fmt.Errorf("%s invalide", field)
+			}
+			*dst = f
+		}
+	}
+	return p, nil
+}
+
 // DELETE /api/contents/:id
 func (h *ContentHandler) DeleteContent(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -238,13 +638,19 @@ func (h *ContentHandler) GetContentImage(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.ServeProtectedImage(c, contentID, userID); err != nil {
-		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	if err := h.service.ServeProtectedImage(c, contentID, userID, h.ages.AllowsMature(userID)); err != nil {
+		status := http.StatusForbidden
+		if errors.Is(err, services.ErrNoImagePreview) || errors.Is(err, services.ErrContentUnavailable) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 }
 
-// GET /api/feed
+// GET /api/feed?mode=foryou|following|latest&cursor=&limit=
+// Pagination par curseur : next_cursor, absent sur la dernière page, se
+// passe tel quel à la requête suivante, avec le même mode.
 func (h *ContentHandler) GetFeed(c *gin.Context) {
 	userIDRaw, ok := c.Get("userID")
 	if !ok {
@@ -257,13 +663,18 @@ func (h *ContentHandler) GetFeed(c *gin.Context) {
 		return
 	}
 
-	feed, err := h.service.GetFeedContents(userID)
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	page, err := h.service.GetFeed(userID, h.ages.AllowsMature(userID), c.Query("mode"), c.Query("cursor"), limit)
+	if errors.Is(err, services.ErrInvalidCursor) || errors.Is(err, services.ErrInvalidFeedMode) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"feed": feed})
+	c.JSON(http.StatusOK, page)
 }
 
 // POST  /api/contents/:id/like
@@ -358,6 +769,15 @@ func (h *ContentHandler) DownloadContent(c *gin.Context) {
 	}
 	creatorID := content.CreatorID
 	log.Printf("📝 DownloadContent: Contenu trouvé - titre='%s', creator=%s", content.Title, creatorID)
+	if !h.service.CanView(content, userID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Contenu introuvable"})
+		return
+	}
+
+	if content.IsMature() && creatorID != userID && !h.ages.AllowsMature(userID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": services.ErrMatureRestricted.Error()})
+		return
+	}
 
 	canDownload := h.service.CanDownload(userID, creatorID)
 	log.Printf("🔐 DownloadContent: CanDownload=%t", canDownload)
@@ -385,7 +805,19 @@ func (h *ContentHandler) DownloadContent(c *gin.Context) {
 	log.Printf("   - Nom téléchargement: %s", downloadFilename)
 
 	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, downloadFilename))
-	c.File(filePath)
+
+	marked, ok, err := h.service.RenderForensicDownload(contentID, userID)
+	if err != nil {
+		log.Printf("❌ DownloadContent: Erreur marquage forensique: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur préparation du fichier"})
+		return
+	}
+	if ok {
+		c.Data(http.StatusOK, marked.ContentType, marked.Data)
+	} else {
+		c.File(filePath)
+	}
+	h.service.RecordDownload(contentID, userID)
 
 	log.Printf("✅ DownloadContent: Fichier envoyé avec succès")
 }
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+// CreatorAnalyticsHandler expose les statistiques d'audience d'un créateur.
+type CreatorAnalyticsHandler struct {
+	events    *services.ContentEventService
+	analytics *services.CreatorAnalyticsService
+}
+
+func NewCreatorAnalyticsHandler(events *services.ContentEventService, analytics *services.CreatorAnalyticsService) *CreatorAnalyticsHandler {
+	return &CreatorAnalyticsHandler{events: events, analytics: analytics}
+}
+
+// Content GET /api/creator/analytics/contents/:id?from=AAAA-MM-JJ&to=AAAA-MM-JJ
+// Séries quotidiennes (jours inclus, 30 derniers jours par défaut) des vues,
+// conversions en abonnement et du taux d'engagement d'un contenu.
+func (h *CreatorAnalyticsHandler) Content(c *gin.Context) {
+	contentID, userID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
+	from, to, ok := dateRangeParams(c)
+	if !ok {
+		return
+	}
+	analytics, err := h.events.ContentAnalytics(contentID, userID, from, to)
+	if err != nil {
+		c.JSON(analyticsErrorStatus(err), gin.H{"error": analyticsErrorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, analytics)
+}
+
+// Overview GET /api/creator/analytics/overview?from=&to=
+// Abonnés et revenu mensuel récurrent actuels, nouveaux abonnés, départs,
+// revenu encaissé et durée de vie moyenne d'un abonné.
+func (h *CreatorAnalyticsHandler) Overview(c *gin.Context) {
+	creatorID, from, to, ok := creatorRangeParams(c)
+	if !ok {
+		return
+	}
+	overview, err := h.analytics.Overview(creatorID, from, to)
+	if err != nil {
+		c.JSON(analyticsErrorStatus(err), gin.H{"error": analyticsErrorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, overview)
+}
+
+// Subscribers GET /api/creator/analytics/subscribers?from=&to=&interval=day|week|month
+func (h *CreatorAnalyticsHandler) Subscribers(c *gin.Context) {
+	creatorID, from, to, ok := creatorRangeParams(c)
+	if !ok {
+		return
+	}
+	series, err := h.analytics.Subscribers(creatorID, from, to, c.Query("interval"))
+	if err != nil {
+		c.JSON(analyticsErrorStatus(err), gin.H{"error": analyticsErrorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, series)
+}
+
+// Cohorts GET /api/creator/analytics/cohorts?from=&to=
+// Rétention mensuelle des abonnés par mois d'arrivée (12 derniers mois par défaut).
+func (h *CreatorAnalyticsHandler) Cohorts(c *gin.Context) {
+	creatorID, from, to, ok := creatorRangeParams(c)
+	if !ok {
+		return
+	}
+	cohorts, err := h.analytics.Cohorts(creatorID, from, to)
+	if err != nil {
+		c.JSON(analyticsErrorStatus(err), gin.H{"error": analyticsErrorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"cohorts": cohorts})
+}
+
+// Fans GET /api/creator/analytics/fans?from=&to=&limit=
+func (h *CreatorAnalyticsHandler) Fans(c *gin.Context) {
+	creatorID, from, to, ok := creatorRangeParams(c)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	fans, err := h.analytics.TopFans(creatorID, from, to, limit)
+	if err != nil {
+		c.JSON(analyticsErrorStatus(err), gin.H{"error": analyticsErrorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"fans": fans})
+}
+
+// creatorRangeParams lit l'utilisateur courant et la période demandée.
+func creatorRangeParams(c *gin.Context) (uuid.UUID, *time.Time, *time.Time, bool) {
+	creatorID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return uuid.Nil, nil, nil, false
+	}
+	from, to, ok := dateRangeParams(c)
+	return creatorID, from, to, ok
+}
+
+// dateRangeParams lit les jours from et to (AAAA-MM-JJ, inclus).
+func dateRangeParams(c *gin.Context) (*time.Time, *time.Time, bool) {
+	from, err := optionalDate(c.Query("from"), false)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date de début invalide"})
+		return nil, nil, false
+	}
+	to, err := optionalDate(c.Query("to"), false)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date de fin invalide"})
+		return nil, nil, false
+	}
+	return from, to, true
+}
+
+func analyticsErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrContentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrAnalyticsForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrInvalidDateRange), errors.Is(err, services.ErrInvalidInterval):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func analyticsErrorMessage(err error) string {
+	if analyticsErrorStatus(err) == http.StatusInternalServerError {
+		return "Erreur lors du calcul des statistiques"
+	}
+	return err.Error()
+}
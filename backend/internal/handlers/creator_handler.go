@@ -6,12 +6,16 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
 )
 
 var (
-	creatorUserRepo   *repositories.UserRepository
-	subscriptionRepo  *repositories.SubscriptionRepository
-	publicContentRepo *repositories.PublicContentRepository
+	creatorUserRepo       *repositories.UserRepository
+	subscriptionRepo      *repositories.SubscriptionRepository
+	publicContentRepo     *repositories.PublicContentRepository
+	creatorTagRepo        *repositories.TagRepository
+	creatorCollectionRepo *repositories.CollectionRepository
+	creatorAgeService     *services.AgeVerificationService
 )
 
 // SetCreatorRepos injecte les repositories nécessaires.
@@ -19,10 +23,20 @@ func SetCreatorRepos(
 	userRepo *repositories.UserRepository,
 	subRepo *repositories.SubscriptionRepository,
 	contentRepo *repositories.PublicContentRepository,
+	tagRepo *repositories.TagRepository,
+	collectionRepo *repositories.CollectionRepository,
 ) {
 	creatorUserRepo = userRepo
 	subscriptionRepo = subRepo
 	publicContentRepo = contentRepo
+	creatorTagRepo = tagRepo
+	creatorCollectionRepo = collectionRepo
+}
+
+// SetCreatorAgeVerification injecte le service qui décide de l'affichage des
+// contenus adultes dans les profils publics.
+func SetCreatorAgeVerification(ages *services.AgeVerificationService) {
+	creatorAgeService = ages
 }
 
 // GetPublicCreatorProfileHandler gère GET /api/creators/:username
@@ -41,17 +55,31 @@ func GetPublicCreatorProfileHandler(c *gin.Context) {
 		return
 	}
 
-	contents, err := publicContentRepo.FindPreviewByCreator(user.ID, 3)
+	contents, err := publicContentRepo.FindPreviewByCreator(user.ID, 3, viewerAllowsMature(c, creatorAgeService))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de récupérer les contenus"})
 		return
 	}
 
+	collections, err := creatorCollectionRepo.SummariesByCreator(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de récupérer les collections"})
+		return
+	}
+
+	tags, err := creatorTagRepo.TopByCreator(user.ID, 10)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de récupérer les tags"})
+		return
+	}
+
 	response := gin.H{
 		"username":         user.Username,
 		"created_at":       user.CreatedAt,
 		"subscriber_count": subCount,
 		"content_preview":  contents,
+		"collections":      collections,
+		"top_tags":         tags,
 		"bio":              user.Bio,
 		"avatar_url":       user.AvatarURL,
 	}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+type DuplicateHandler struct {
+	service *services.DuplicateService
+}
+
+func NewDuplicateHandler(service *services.DuplicateService) *DuplicateHandler {
+	return &DuplicateHandler{service: service}
+}
+
+// duplicateFlagLink résume un signalement avec le lien vers l'original.
+func duplicateFlagLink(f models.DuplicateFlag) gin.H {
+	return gin.H{
+		"flag_id":             f.ID,
+		"original_content_id": f.OriginalContentID,
+		"original_url":        "/api/contents/" + f.OriginalContentID.String() + "/image",
+		"distance":            f.Distance,
+	}
+}
+
+func duplicateContentJSON(ct models.Content) gin.H {
+	return gin.H{
+		"id":           ct.ID,
+		"title":        ct.Title,
+		"status":       ct.Status,
+		"creator_id":   ct.CreatorID,
+		"creator_name": ct.Creator.Username,
+		"created_at":   ct.CreatedAt,
+		"image_url":    "/api/contents/" + ct.ID.String() + "/image",
+	}
+}
+
+// ListFlags GET /api/admin/duplicates?status=open&limit=&offset=
+func (h *DuplicateHandler) ListFlags(c *gin.Context) {
+	limit, offset := pageParams(c)
+	flags, err := h.service.ListFlags(c.Query("status"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	out := make([]gin.H, 0, len(flags))
+	for _, f := range flags {
+		out = append(out, gin.H{
+			"id":          f.ID,
+			"distance":    f.Distance,
+			"status":      f.Status,
+			"created_at":  f.CreatedAt,
+			"reviewed_by": f.ReviewedBy,
+			"reviewed_at": f.ReviewedAt,
+			"content":     duplicateContentJSON(f.Content),
+			"original":    duplicateContentJSON(f.OriginalContent),
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"flags": out})
+}
+
+// ReviewFlag PUT /api/admin/duplicates/:id
+// Corps : {"decision": "confirmed" | "dismissed"}.
+func (h *DuplicateHandler) ReviewFlag(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return
+	}
+	reviewerID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return
+	}
+	var payload struct {
+		Decision string `json:"decision" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+
+	flag, err := h.service.ReviewFlag(id, reviewerID, payload.Decision)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidFlagDecision):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrDuplicateFlagNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		}
+		return
+	}
+
+	logger.LogContent("duplicate_reviewed", reviewerID.String(), flag.ContentID.String(), map[string]interface{}{
+		"flag_id":             flag.ID.String(),
+		"original_content_id": flag.OriginalContentID.String(),
+		"decision":            flag.Status,
+	})
+	if !recordAudit(c, models.AuditDuplicateReviewed, "duplicate_flag", flag.ID.String(),
+		gin.H{"status": models.DuplicateFlagOpen}, gin.H{"status": flag.Status}) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"flag": flag})
+}
+
+// SearchByImage POST /api/admin/search/image (multipart, champ "file")
+// Renvoie les contenus visuellement proches de l'image envoyée.
+func (h *DuplicateHandler) SearchByImage(c *gin.Context) {
+	limits := services.DefaultUploadLimits()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limits.MaxBytes+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": services.ErrUploadTooLarge.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Fichier requis"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Fichier illisible"})
+		return
+	}
+	defer file.Close()
+
+	matches, err := h.service.SearchByImage(file)
+	if err != nil {
+		c.JSON(uploadErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	out := make([]gin.H, 0, len(matches))
+	for _, m := range matches {
+		item := duplicateContentJSON(m.Content)
+		item["distance"] = m.Distance
+		out = append(out, item)
+	}
+	c.JSON(http.StatusOK, gin.H{"matches": out})
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+// ExportHandler expose les exports CSV/XLSX et le suivi des exports différés.
+type ExportHandler struct {
+	service *services.ExportService
+}
+
+func NewExportHandler(service *services.ExportService) *ExportHandler {
+	return &ExportHandler{service: service}
+}
+
+// AdminExport GET /api/admin/exports/:dataset?format=csv|xlsx&from=&to=&async=1
+// Jeux de données et filtres, ceux des écrans d'administration :
+//   - users : role ;
+//   - contents : status, creator ;
+//   - reports : status, target_type, reason, assignee ;
+//   - payments : status, creator ;
+//   - creator-earnings : paiements réussis de la période par créateur ;
+//   - revenue : from/to, sinon days (comme /revenue-chart, 30 par défaut).
+//
+// Les dates sont des jours inclus. Un export volumineux est généré en tâche
+// de fond : la réponse est alors 202 avec le suivi de l'export.
+func (h *ExportHandler) AdminExport(c *gin.Context) {
+	h.export(c, services.ExportScopeAdmin)
+}
+
+// CreatorExport GET /api/creator/exports/:dataset?format=&from=&to=&status=&async=1
+// subscribers (abonnements par date de début) ou earnings (paiements reçus).
+func (h *ExportHandler) CreatorExport(c *gin.Context) {
+	h.export(c, services.ExportScopeCreator)
+}
+
+func (h *ExportHandler) export(c *gin.Context, scope string) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return
+	}
+	filter, ok := exportFilterParams(c)
+	if !ok {
+		return
+	}
+	req := services.ExportRequest{
+		Scope:   scope,
+		Dataset: c.Param("dataset"),
+		Format:  c.DefaultQuery("format", services.ExportFormatCSV),
+		Filter:  filter,
+		Async:   c.Query("async") == "1" || c.Query("async") == "true",
+	}
+	if d, err := strconv.Atoi(c.Query("days")); err == nil && d > 0 && d <= 365 {
+		req.Days = d
+	}
+
+	job, err := h.service.Schedule(userID, req)
+	if err != nil {
+		c.JSON(exportErrorStatus(err), gin.H{"error": exportErrorMessage(err)})
+		return
+	}
+	if job != nil {
+		c.JSON(http.StatusAccepted, gin.H{"job": job})
+		return
+	}
+
+	c.Header("Content-Type", services.ExportContentType(req.Format))
+	c.Header("Content-Disposition", `attachment; filename="`+services.ExportFilename(req.Dataset, req.Format, time.Now())+`"`)
+	c.Status(http.StatusOK)
+	if _, err := h.service.Write(c.Writer, userID, req); err != nil {
+		logger.LogError(err, "export_stream_failed", map[string]interface{}{
+			"dataset": req.Dataset,
+			"user_id": userID.String(),
+		})
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.JSON(exportErrorStatus(err), gin.H{"error": exportErrorMessage(err)})
+		}
+	}
+}
+
+// exportFilterParams lit la période et les filtres communs aux exports.
+func exportFilterParams(c *gin.Context) (repositories.ExportFilter, bool) {
+	var filter repositories.ExportFilter
+	from, err := optionalDate(c.Query("from"), false)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date de début invalide"})
+		return filter, false
+	}
+	to, err := optionalDate(c.Query("to"), true)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date de fin invalide"})
+		return filter, false
+	}
+	filter.From, filter.To = from, to
+	filter.Status = c.Query("status")
+	filter.Role = c.Query("role")
+	filter.Report = repositories.ReportFilter{
+		Status:     c.Query("status"),
+		TargetType: c.Query("target_type"),
+		ReasonCode: c.Query("reason"),
+	}
+	if raw := c.Query("assignee"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID d'assigné invalide"})
+			return filter, false
+		}
+		filter.Report.AssigneeID = &id
+	}
+	if raw := c.Query("creator"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID créateur invalide"})
+			return filter, false
+		}
+		filter.CreatorID = &id
+	}
+	return filter, true
+}
+
+// ListJobs GET /api/exports
+// Derniers exports différés de l'utilisateur connecté.
+func (h *ExportHandler) ListJobs(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return
+	}
+	jobs, err := h.service.Jobs(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": exportErrorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"jobs": jobs})
+}
+
+// GetJob GET /api/exports/:id
+func (h *ExportHandler) GetJob(c *gin.Context) {
+	jobID, userID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
+	job, err := h.service.Job(userID, jobID)
+	if err != nil {
+		c.JSON(exportErrorStatus(err), gin.H{"error": exportErrorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"job": job})
+}
+
+// Download GET /api/exports/:id/download
+func (h *ExportHandler) Download(c *gin.Context) {
+	jobID, userID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
+	path, filename, err := h.service.File(userID, jobID)
+	if err != nil {
+		c.JSON(exportErrorStatus(err), gin.H{"error": exportErrorMessage(err)})
+		return
+	}
+	c.FileAttachment(path, filename)
+}
+
+func exportErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrInvalidExportFormat),
+		errors.Is(err, services.ErrInvalidExportDataset),
+		errors.Is(err, services.ErrInvalidDateRange):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrExportNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrExportNotReady), errors.Is(err, services.ErrExportFailed):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+func exportErrorMessage(err error) string {
+	if exportErrorStatus(err) == http.StatusInternalServerError {
+		return "Erreur lors de l'export"
+	}
+	return err.Error()
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+// ForensicHandler expose l'outil d'identification des fuites.
+type ForensicHandler struct {
+	service *services.ForensicService
+}
+
+// NewForensicHandler instancie le handler forensique.
+func NewForensicHandler(service *services.ForensicService) *ForensicHandler {
+	return &ForensicHandler{service: service}
+}
+
+// IdentifyLeak POST /api/contents/:id/forensics/identify
+// Reçoit l'image fuitée (champ multipart "file") et renvoie l'abonné et la
+// date à laquelle cette copie lui a été servie.
+func (h *ForensicHandler) IdentifyLeak(c *gin.Context) {
+	requesterID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return
+	}
+
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID contenu invalide"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Fichier requis"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Fichier illisible"})
+		return
+	}
+	defer file.Close()
+
+	match, err := h.service.Identify(requesterID, contentID, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrForensicForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrForensicNoMark):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"subscriber_id":       match.Mark.SubscriberID,
+		"subscriber_username": match.SubscriberUsername,
+		"issued_at":           match.Mark.IssuedAt,
+		"kind":                match.Mark.Kind,
+		"confidence":          match.Confidence,
+	})
+}
@@ -45,6 +45,14 @@ func (h *MessageHandler) SendMessage(c *gin.Context) {
 	}
 
 	message, err := h.messageService.SendMessage(userID, receiverID, payload.Text)
+	if isSanctionError(err) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if status, ok := textErrorStatus(err); ok {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
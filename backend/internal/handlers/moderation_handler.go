@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+type ModerationHandler struct {
+	service *services.ModerationService
+}
+
+func NewModerationHandler(service *services.ModerationService) *ModerationHandler {
+	return &ModerationHandler{service: service}
+}
+
+func moderationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrContentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrNotContentOwner):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrInvalidTransition), errors.Is(err, services.ErrAlreadyAppealed):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrReasonRequired), errors.Is(err, services.ErrInvalidReason),
+		errors.Is(err, services.ErrNoteRequired), errors.Is(err, services.ErrAppealRequired),
+		errors.Is(err, services.ErrModerationNoteTooLong):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// decisionJSON ajoute le libellé du motif à une décision.
+func decisionJSON(d models.ModerationDecision) gin.H {
+	return gin.H{
+		"id":            d.ID,
+		"content_id":    d.ContentID,
+		"actor_id":      d.ActorID,
+		"action":        d.Action,
+		"reason_code":   d.ReasonCode,
+		"reason":        models.ModerationReasonLabels[d.ReasonCode],
+		"note":          d.Note,
+		"status_before": d.StatusBefore,
+		"status_after":  d.StatusAfter,
+		"created_at":    d.CreatedAt,
+	}
+}
+
+// moderationIDs décode l'ID du contenu et celui de l'utilisateur connecté.
+func moderationIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return contentID, userID, true
+}
+
+// Approve PUT /api/admin/contents/:id/approve
+// Corps optionnel : {"note": "..."}.
+func (h *ModerationHandler) Approve(c *gin.Context) {
+	contentID, moderatorID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
+	var payload struct {
+		Note string `json:"note"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+			return
+		}
+	}
+
+	decision, err := h.service.Approve(contentID, moderatorID, payload.Note)
+	if err != nil {
+		c.JSON(moderationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if !recordAudit(c, models.AuditContentApproved, "content", contentID.String(),
+		gin.H{"status": decision.StatusBefore}, gin.H{"status": decision.StatusAfter, "note": decision.Note}) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Contenu approuvé", "decision": decisionJSON(*decision)})
+}
+
+// Reject PUT /api/admin/contents/:id/reject
+// Corps : {"reason_code": "copyright", "note": "..."} ; le motif est obligatoire.
+func (h *ModerationHandler) Reject(c *gin.Context) {
+	contentID, moderatorID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
+	var payload struct {
+		ReasonCode string `json:"reason_code"`
+		Note       string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": services.ErrReasonRequired.Error()})
+		return
+	}
+
+	decision, err := h.service.Reject(contentID, moderatorID, payload.ReasonCode, payload.Note)
+	if err != nil {
+		c.JSON(moderationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if !recordAudit(c, models.AuditContentRejected, "content", contentID.String(),
+		gin.H{"status": decision.StatusBefore},
+		gin.H{"status": decision.StatusAfter, "reason_code": decision.ReasonCode, "note": decision.Note}) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Contenu rejeté", "decision": decisionJSON(*decision)})
+}
+
+// ListReasons GET /api/admin/moderation/reasons
+func (h *ModerationHandler) ListReasons(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"reasons": models.ModerationReasonLabels})
+}
+
+// ContentHistory GET /api/admin/contents/:id/moderation
+func (h *ModerationHandler) ContentHistory(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return
+	}
+	history, err := h.service.ContentHistory(contentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	out := make([]gin.H, 0, len(history))
+	for _, d := range history {
+		out = append(out, decisionJSON(d))
+	}
+	c.JSON(http.StatusOK, gin.H{"history": out})
+}
+
+// CreatorHistory GET /api/creator/moderation?limit=&offset=
+// Historique de modération des contenus du créateur connecté.
+func (h *ModerationHandler) CreatorHistory(c *gin.Context) {
+	creatorID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return
+	}
+	limit, offset := pageParams(c)
+	history, err := h.service.CreatorHistory(creatorID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	out := make([]gin.H, 0, len(history))
+	for _, d := range history {
+		item := decisionJSON(d)
+		item["content_title"] = d.Content.Title
+		item["content_status"] = d.Content.Status
+		out = append(out, item)
+	}
+	c.JSON(http.StatusOK, gin.H{"history": out})
+}
+
+// Appeal POST /api/contents/:id/appeal
+// Corps : {"message": "..."} ; le contenu rejeté repasse en modération.
+func (h *ModerationHandler) Appeal(c *gin.Context) {
+	contentID, creatorID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": services.ErrAppealRequired.Error()})
+		return
+	}
+
+	decision, err := h.service.Appeal(contentID, creatorID, payload.Message)
+	if err != nil {
+		c.JSON(moderationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"decision": decisionJSON(*decision)})
+}
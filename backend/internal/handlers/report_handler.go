@@ -1,80 +1,200 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
 )
 
-// ReportContentHandler POST /api/contents/:id/report
-func ReportContentHandler(c *gin.Context) {
-	userRaw, exists := c.Get("userID")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Utilisateur non authentifié"})
+type ReportHandler struct {
+	service *services.ReportService
+}
+
+func NewReportHandler(service *services.ReportService) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+func reportErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrReportNotFound), errors.Is(err, services.ErrReportTargetNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrDuplicateReport), errors.Is(err, services.ErrReportClosed):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidReportTarget), errors.Is(err, services.ErrInvalidReportReason),
+		errors.Is(err, services.ErrReportDetailsNeeded), errors.Is(err, services.ErrSelfReport),
+		errors.Is(err, services.ErrInvalidReportAction), errors.Is(err, services.ErrInvalidReportStatus),
+		errors.Is(err, services.ErrModerationNoteTooLong):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+type reportPayload struct {
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	ReasonCode string `json:"reason_code"`
+	Details    string `json:"details"`
+	// Reason est le motif libre des anciennes versions de l'application.
+	Reason string `json:"reason"`
+}
+
+func (h *ReportHandler) submit(c *gin.Context, targetType string, targetID uuid.UUID, p reportPayload) {
+	reporterID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
 		return
 	}
-	userIDStr, ok := userRaw.(string)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "ID utilisateur invalide"})
+	if p.ReasonCode == "" {
+		p.ReasonCode, p.Details = models.ReportReasonOther, p.Reason
+	}
+	report, err := h.service.Submit(reporterID, targetType, targetID, p.ReasonCode, p.Details)
+	if err != nil {
+		c.JSON(reportErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
-	reporterID, err := uuid.Parse(userIDStr)
+	c.JSON(http.StatusCreated, gin.H{"message": "report created", "report": report})
+}
+
+// Create POST /api/reports
+// Corps : {"target_type": "comment", "target_id": "...", "reason_code": "spam", "details": "..."}.
+func (h *ReportHandler) Create(c *gin.Context) {
+	var p reportPayload
+	if err := c.ShouldBindJSON(&p); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+	targetID, err := uuid.Parse(p.TargetID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID utilisateur mal formé"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de cible invalide"})
 		return
 	}
+	h.submit(c, p.TargetType, targetID, p)
+}
 
-	idStr := c.Param("id")
-	contentID, err := uuid.Parse(idStr)
+// ReportContent POST /api/contents/:id/report
+func (h *ReportHandler) ReportContent(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de contenu invalide"})
 		return
 	}
+	var p reportPayload
+	_ = c.ShouldBindJSON(&p)
+	h.submit(c, models.ReportTargetContent, contentID, p)
+}
+
+// ListReasons GET /api/reports/reasons
+func (h *ReportHandler) ListReasons(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"reasons": models.ReportReasonLabels})
+}
+
+// List GET /api/admin/reports?status=&target_type=&reason=&assignee=&limit=&offset=
+// Les signalements sont regroupés par cible, les plus signalées d'abord.
+func (h *ReportHandler) List(c *gin.Context) {
+	filter := repositories.ReportFilter{
+		Status:     c.Query("status"),
+		TargetType: c.Query("target_type"),
+		ReasonCode: c.Query("reason"),
+	}
+	if raw := c.Query("assignee"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID d'assigné invalide"})
+			return
+		}
+		filter.AssigneeID = &id
+	}
+	limit, offset := pageParams(c)
+	groups, total, err := h.service.List(filter, limit, offset)
+	if err != nil {
+		c.JSON(reportErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"targets": groups, "total": total, "limit": limit, "offset": offset})
+}
 
+// Assign PUT /api/admin/reports/:id/assign
+// Corps optionnel : {"assignee_id": "..."} ; par défaut l'admin connecté.
+func (h *ReportHandler) Assign(c *gin.Context) {
+	reportID, adminID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
 	var payload struct {
-		Reason string `json:"reason"`
+		AssigneeID string `json:"assignee_id"`
 	}
 	_ = c.ShouldBindJSON(&payload)
-
-	report := &models.Report{
-		TargetContentID: contentID,
-		ReporterID:      reporterID,
-		Reason:          payload.Reason,
-		CreatedAt:       time.Now().UTC(),
+	assigneeID := adminID
+	if payload.AssigneeID != "" {
+		id, err := uuid.Parse(payload.AssigneeID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID d'assigné invalide"})
+			return
+		}
+		assigneeID = id
 	}
-	if err := repositories.NewReportRepository().Create(report); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de créer le report"})
+	report, err := h.service.Assign(reportID, assigneeID)
+	if err != nil {
+		c.JSON(reportErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
-
-	c.JSON(http.StatusCreated, gin.H{"message": "report created"})
+	if !recordAudit(c, models.AuditReportAssigned, "report", report.ID.String(), nil, report) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"report": report})
 }
 
-// ListReportsHandler GET /api/admin/reports
-// Middleware: JWTAuth + AdminMiddleware
-func ListReportsHandler(c *gin.Context) {
-	repo := repositories.NewReportRepository()
-	reports, err := repo.FindAll()
+// Resolve PUT /api/admin/reports/:id/resolve
+// Corps : {"action": "content_rejected", "note": "..."}.
+func (h *ReportHandler) Resolve(c *gin.Context) {
+	reportID, adminID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
+	var payload struct {
+		Action string `json:"action"`
+		Note   string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+	report, err := h.service.Resolve(reportID, adminID, payload.Action, payload.Note)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de récupérer les reports"})
+		c.JSON(reportErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
-
-	var out []gin.H
-	for _, r := range reports {
-		out = append(out, gin.H{
-			"id":                r.ID,
-			"target_content_id": r.TargetContentID,
-			"reporter_id":       r.ReporterID,
-			"reason":            r.Reason,
-			"created_at":        r.CreatedAt.Format(time.RFC3339),
-		})
+	if !recordAudit(c, models.AuditReportResolved, "report", report.ID.String(), nil, report) {
+		return
 	}
+	c.JSON(http.StatusOK, gin.H{"report": report})
+}
 
-	c.JSON(http.StatusOK, gin.H{"reports": out})
+// Dismiss PUT /api/admin/reports/:id/dismiss
+// Corps optionnel : {"note": "..."}.
+func (h *ReportHandler) Dismiss(c *gin.Context) {
+	reportID, adminID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
+	var payload struct {
+		Note string `json:"note"`
+	}
+	_ = c.ShouldBindJSON(&payload)
+	report, err := h.service.Dismiss(reportID, adminID, payload.Note)
+	if err != nil {
+		c.JSON(reportErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if !recordAudit(c, models.AuditReportDismissed, "report", report.ID.String(), nil, report) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"report": report})
 }
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+type SanctionHandler struct {
+	service *services.SanctionService
+}
+
+func NewSanctionHandler(service *services.SanctionService) *SanctionHandler {
+	return &SanctionHandler{service: service}
+}
+
+// isSanctionError indique si l'action a été refusée par une sanction.
+func isSanctionError(err error) bool {
+	for _, target := range []error{
+		services.ErrAccountBanned,
+		services.ErrAccountSuspended,
+		services.ErrPostingRestricted,
+		services.ErrCommentingRestricted,
+		services.ErrMessagingRestricted,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func sanctionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrSanctionNotFound), errors.Is(err, services.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrSanctionLifted):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrCannotSanctionAdmin):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrInvalidSanctionKind), errors.Is(err, services.ErrSanctionReasonRequired),
+		errors.Is(err, services.ErrSanctionExpiryRequired), errors.Is(err, services.ErrSanctionExpiryInPast):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Issue POST /api/admin/users/:id/sanctions
+// Corps : {"kind": "suspension", "reason": "...", "expires_at": "2025-01-31T00:00:00Z"}
+// ou "duration_hours" à la place de expires_at.
+func (h *SanctionHandler) Issue(c *gin.Context) {
+	userID, adminID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
+	var payload struct {
+		Kind          string     `json:"kind" binding:"required"`
+		Reason        string     `json:"reason"`
+		ExpiresAt     *time.Time `json:"expires_at"`
+		DurationHours int        `json:"duration_hours"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+	expiresAt := payload.ExpiresAt
+	if expiresAt == nil && payload.DurationHours > 0 {
+		t := time.Now().Add(time.Duration(payload.DurationHours) * time.Hour)
+		expiresAt = &t
+	}
+
+	sanction, err := h.service.Issue(userID, adminID, payload.Kind, payload.Reason, expiresAt)
+	if err != nil && sanction == nil {
+		c.JSON(sanctionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if !recordAudit(c, models.AuditSanctionIssued, "user", userID.String(), nil, sanction) {
+		return
+	}
+	resp := gin.H{"sanction": sanction, "label": models.SanctionLabels[sanction.Kind]}
+	if err != nil {
+		resp["warning"] = err.Error()
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+// Lift PUT /api/admin/sanctions/:id/lift
+// Corps optionnel : {"reason": "..."}.
+func (h *SanctionHandler) Lift(c *gin.Context) {
+	sanctionID, adminID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
+	var payload struct {
+		Reason string `json:"reason"`
+	}
+	_ = c.ShouldBindJSON(&payload)
+
+	sanction, err := h.service.Lift(sanctionID, adminID, payload.Reason)
+	if err != nil && sanction == nil {
+		c.JSON(sanctionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if !recordAudit(c, models.AuditSanctionLifted, "sanction", sanction.ID.String(),
+		gin.H{"lifted_at": nil}, sanction) {
+		return
+	}
+	resp := gin.H{"sanction": sanction}
+	if err != nil {
+		resp["warning"] = err.Error()
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// UserHistory GET /api/admin/users/:id/sanctions
+func (h *SanctionHandler) UserHistory(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return
+	}
+	list, err := h.service.History(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sanctions": list})
+}
+
+// ListActive GET /api/admin/sanctions?limit=&offset=
+func (h *SanctionHandler) ListActive(c *gin.Context) {
+	limit, offset := pageParams(c)
+	list, err := h.service.ListActive(limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sanctions": list, "kinds": models.SanctionLabels})
+}
+
+// Mine GET /api/users/me/sanctions
+// Restrictions en vigueur sur le compte connecté.
+func (h *SanctionHandler) Mine(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return
+	}
+	list, err := h.service.Active(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sanctions": list})
+}
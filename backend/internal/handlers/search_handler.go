@@ -1,107 +1,177 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
-)
-
-type CreatorDTO struct {
-	ID         string `json:"id"`
-	Username   string `json:"username"`
-	AvatarURL  string `json:"avatar_url"`
-	IsFollowed bool   `json:"is_followed"`
-}
+	"github.com/google/uuid"
 
-type ContentDTO struct {
-	ID           string `json:"id"`
-	Title        string `json:"title"`
-	ThumbnailURL string `json:"thumbnail_url"`
-	CreatorName  string `json:"creator_name"`
-}
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
 
-// SearchHandler gère GET /api/search?q=…&type=creators,contents
+// SearchHandler gère GET /api/search
 type SearchHandler struct {
-	DB *gorm.DB
+	service *services.SearchService
+	ages    *services.AgeVerificationService
 }
 
 // NewSearchHandler instancie le handler
-func NewSearchHandler(db *gorm.DB) *SearchHandler {
-	return &SearchHandler{DB: db}
+func NewSearchHandler(service *services.SearchService, ages *services.AgeVerificationService) *SearchHandler {
+	return &SearchHandler{service: service, ages: ages}
 }
 
-// Search exécute la recherche créateurs et contenus
+// Search GET /api/search?q=&type=creators,contents&min_price=&max_price=&from=&to=&limit=&offset=
+// Les dates sont au format AAAA-MM-JJ (to inclus) ou RFC 3339. Les extraits
+// title_highlight, body_highlight et bio_highlight sont du HTML échappé où
+// seuls les termes trouvés sont entourés de <mark>…</mark>.
 func (h *SearchHandler) Search(c *gin.Context) {
-	q := strings.TrimSpace(c.Query("q"))
-	if q == "" || len(q) > 256 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre 'q' manquant ou trop long (max 256 caractères)"})
+	req := services.SearchRequest{
+		Query:         c.Query("q"),
+		IncludeMature: viewerAllowsMature(c, h.ages),
+	}
+	if t := c.Query("type"); t != "" {
+		req.Types = strings.Split(t, ",")
+	}
+	req.Limit, req.Offset = pageParams(c)
+	if id, err := uuid.Parse(c.GetString("userID")); err == nil {
+		req.ViewerID = id
+	}
+
+	var err error
+	if req.MinPrice, err = optionalInt(c.Query("min_price")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "min_price invalide"})
+		return
+	}
+	if req.MaxPrice, err = optionalInt(c.Query("max_price")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_price invalide"})
+		return
+	}
+	if req.From, err = optionalDate(c.Query("from"), false); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from invalide"})
+		return
+	}
+	if req.To, err = optionalDate(c.Query("to"), true); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to invalide"})
 		return
 	}
-	types := strings.Split(c.Query("type"), ",")
 
-	var uid string
-	if v, exists := c.Get("userID"); exists {
-		switch id := v.(type) {
-		case string:
-			uid = id
-		case []byte:
-			uid = string(id)
-		}
+	result, err := h.service.Search(req)
+	if errors.Is(err, services.ErrInvalidSearchQuery) || errors.Is(err, services.ErrInvalidSearchFilter) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
 
-	creators := make([]CreatorDTO, 0)
-	contents := make([]ContentDTO, 0)
+// Suggest GET /api/search/suggest?q=
+// Propositions par préfixe : créateurs, tags et titres de contenus.
+func (h *SearchHandler) Suggest(c *gin.Context) {
+	suggestions, err := h.service.Suggest(c.Request.Context(), c.Query("q"), viewerAllowsMature(c, h.ages))
+	if errors.Is(err, services.ErrInvalidSearchQuery) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	c.JSON(http.StatusOK, suggestions)
+}
 
-	if contains(types, "creators") {
-		h.DB.Table(`"user" u`).
-			Select(`
-		  u.id::text   AS id,
-		  u.username,
-		  ''            AS avatar_url,
-		  CASE WHEN s.creator_id IS NOT NULL THEN true ELSE false END AS is_followed
-		`).
-			Joins(`
-		  LEFT JOIN subscription s
-		    ON s.creator_id::text   = u.id::text
-		   AND s.subscriber_id::text = ?
-		`, uid).
-			Where("u.username ILIKE ?", "%"+q+"%").
-			Order("u.created_at DESC").
-			Limit(20).
-			Find(&creators)
+// RecentSearches GET /api/search/recent
+func (h *SearchHandler) RecentSearches(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "non autorisé"})
+		return
 	}
+	searches, err := h.service.RecentSearches(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"searches": searches})
+}
+
+// DeleteRecentSearch DELETE /api/search/recent/:id
+func (h *SearchHandler) DeleteRecentSearch(c *gin.Context) {
+	id, userID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
+	err := h.service.DeleteRecentSearch(userID, id)
+	if errors.Is(err, services.ErrRecentSearchMissing) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ClearRecentSearches DELETE /api/search/recent
+func (h *SearchHandler) ClearRecentSearches(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "non autorisé"})
+		return
+	}
+	if err := h.service.ClearRecentSearches(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
 
-	if contains(types, "contents") {
-		h.DB.Table("content p").
-			Select(`
-      p.id::text            AS id,
-      p.title,
-      p.image_url           AS thumbnail_url,
-      u.username            AS creator_name
-    `).
-			Joins(`
-      JOIN "user" u
-        ON u.id::text = p.creator_id::text
-    `).
-			Where("p.title ILIKE ? OR p.caption ILIKE ?", "%"+q+"%", "%"+q+"%").
-			Order("p.likes DESC").
-			Limit(20).
-			Find(&contents)
+// SearchMisses GET /api/admin/search/misses?days=30&limit=&offset=
+// Recherches restées sans résultat, les plus fréquentes d'abord.
+func (h *SearchHandler) SearchMisses(c *gin.Context) {
+	days, _ := strconv.Atoi(c.Query("days"))
+	limit, offset := pageParams(c)
+	misses, err := h.service.SearchMisses(days, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
 	}
+	c.JSON(http.StatusOK, gin.H{"queries": misses})
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"creators": creators,
-		"contents": contents,
-	})
+func optionalInt(raw string) (*int, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
 }
 
-func contains(slice []string, s string) bool {
-	for _, v := range slice {
-		if v == s {
-			return true
-		}
+// optionalDate lit une date AAAA-MM-JJ ou RFC 3339 ; en borne de fin, une
+// date seule inclut toute la journée.
+func optionalDate(raw string, end bool) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return nil, err
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
 	}
-	return false
+	return &t, nil
 }
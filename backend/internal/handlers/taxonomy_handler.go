@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+// TaxonomyHandler expose les tags, catégories et collections.
+type TaxonomyHandler struct {
+	service *services.TaxonomyService
+	ages    *services.AgeVerificationService
+}
+
+// NewTaxonomyHandler instancie le handler de taxonomie.
+func NewTaxonomyHandler(service *services.TaxonomyService, ages *services.AgeVerificationService) *TaxonomyHandler {
+	return &TaxonomyHandler{service: service, ages: ages}
+}
+
+// SetContentTags PUT /api/contents/:id/tags
+// Corps : {"tags": ["aquarelle", "paysage"]}
+func (h *TaxonomyHandler) SetContentTags(c *gin.Context) {
+	userID, contentID, ok := taxonomyIDs(c)
+	if !ok {
+		return
+	}
+	var payload struct {
+		Tags []string `json:"tags"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+
+	tags, err := h.service.SetContentTags(userID, contentID, payload.Tags)
+	if err != nil {
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+// SetContentCategory PUT /api/contents/:id/category
+// Corps : {"category": "illustration"} ; une valeur vide retire la catégorie.
+func (h *TaxonomyHandler) SetContentCategory(c *gin.Context) {
+	userID, contentID, ok := taxonomyIDs(c)
+	if !ok {
+		return
+	}
+	var payload struct {
+		Category string `json:"category"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+
+	cat, err := h.service.SetContentCategory(userID, contentID, payload.Category)
+	if err != nil {
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"category": cat})
+}
+
+// AutocompleteTags GET /api/tags/autocomplete?q=
+func (h *TaxonomyHandler) AutocompleteTags(c *gin.Context) {
+	tags, err := h.service.AutocompleteTags(c.Query("q"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+// ContentsByTag GET /api/tags/:tag/contents?limit=&offset=
+func (h *TaxonomyHandler) ContentsByTag(c *gin.Context) {
+	limit, offset := pageParams(c)
+	contents, err := h.service.ContentsByTag(c.Param("tag"), limit, offset, viewerAllowsMature(c, h.ages))
+	if err != nil {
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	h.respondContents(c, contents)
+}
+
+// ListCategories GET /api/categories
+func (h *TaxonomyHandler) ListCategories(c *gin.Context) {
+	cats, err := h.service.ListCategories()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"categories": cats})
+}
+
+// ContentsByCategory GET /api/categories/:slug/contents?limit=&offset=
+func (h *TaxonomyHandler) ContentsByCategory(c *gin.Context) {
+	limit, offset := pageParams(c)
+	contents, err := h.service.ContentsByCategory(c.Param("slug"), limit, offset, viewerAllowsMature(c, h.ages))
+	if err != nil {
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	h.respondContents(c, contents)
+}
+
+// CreateCategory POST /api/admin/categories
+func (h *TaxonomyHandler) CreateCategory(c *gin.Context) {
+	var cat models.Category
+	if err := c.ShouldBindJSON(&cat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+	cat.ID = uuid.Nil
+	if err := h.service.SaveCategory(&cat); err != nil {
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if !recordAudit(c, models.AuditCategoryCreated, "category", cat.ID.String(), nil, cat) {
+		return
+	}
+	c.JSON(http.StatusCreated, cat)
+}
+
+// UpdateCategory PUT /api/admin/categories/:id
+func (h *TaxonomyHandler) UpdateCategory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return
+	}
+	cat, err := h.service.GetCategory(id)
+	if err != nil {
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	before := *cat
+	if err := c.ShouldBindJSON(cat); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+	cat.ID = id
+	if err := h.service.SaveCategory(cat); err != nil {
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if !recordAudit(c, models.AuditCategoryUpdated, "category", id.String(), before, cat) {
+		return
+	}
+	c.JSON(http.StatusOK, cat)
+}
+
+// DeleteCategory DELETE /api/admin/categories/:id
+func (h *TaxonomyHandler) DeleteCategory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return
+	}
+	before, _ := h.service.GetCategory(id)
+	if err := h.service.DeleteCategory(id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": services.ErrCategoryNotFound.Error()})
+		return
+	}
+	if !recordAudit(c, models.AuditCategoryDeleted, "category", id.String(), before, nil) {
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateCollection POST /api/collections
+func (h *TaxonomyHandler) CreateCollection(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return
+	}
+	var col models.Collection
+	if err := c.ShouldBindJSON(&col); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+	col.ID = uuid.Nil
+	if err := h.service.SaveCollection(userID, &col); err != nil {
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, col)
+}
+
+// UpdateCollection PUT /api/collections/:id
+func (h *TaxonomyHandler) UpdateCollection(c *gin.Context) {
+	userID, id, ok := taxonomyIDs(c)
+	if !ok {
+		return
+	}
+	var col models.Collection
+	if err := c.ShouldBindJSON(&col); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+	col.ID = id
+	if err := h.service.SaveCollection(userID, &col); err != nil {
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, col)
+}
+
+// DeleteCollection DELETE /api/collections/:id
+func (h *TaxonomyHandler) DeleteCollection(c *gin.Context) {
+	userID, id, ok := taxonomyIDs(c)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteCollection(userID, id); err != nil {
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SetCollectionItems PUT /api/collections/:id/items
+// Corps : {"content_ids": ["…", "…"]} dans l'ordre d'affichage.
+func (h *TaxonomyHandler) SetCollectionItems(c *gin.Context) {
+	userID, id, ok := taxonomyIDs(c)
+	if !ok {
+		return
+	}
+	var payload struct {
+		ContentIDs []uuid.UUID `json:"content_ids"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload invalide"})
+		return
+	}
+	if err := h.service.SetCollectionItems(userID, id, payload.ContentIDs); err != nil {
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"content_ids": payload.ContentIDs})
+}
+
+// GetCollection GET /api/collections/:id
+func (h *TaxonomyHandler) GetCollection(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return
+	}
+	requesterID, _ := uuid.Parse(c.GetString("userID"))
+
+	col, contents, err := h.service.GetCollection(requesterID, id, h.ages.AllowsMature(requesterID))
+	if err != nil {
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	tags, err := h.service.TagNames(contentIDs(contents))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"collection": col,
+		"contents":   publicContentsJSON(contents, tags),
+	})
+}
+
+func (h *TaxonomyHandler) respondContents(c *gin.Context, contents []models.Content) {
+	tags, err := h.service.TagNames(contentIDs(contents))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur serveur"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"contents": publicContentsJSON(contents, tags)})
+}
+
+func contentIDs(contents []models.Content) []uuid.UUID {
+	ids := make([]uuid.UUID, len(contents))
+	for i, ct := range contents {
+		ids[i] = ct.ID
+	}
+	return ids
+}
+
+func pageParams(c *gin.Context) (int, int) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	return services.Pagination(limit, offset)
+}
+
+func taxonomyIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+func taxonomyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrTaxonomyForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrCategoryNotFound), errors.Is(err, services.ErrCollectionNotFound),
+		errors.Is(err, services.ErrContentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidTag), errors.Is(err, services.ErrTooManyTags),
+		errors.Is(err, services.ErrInvalidCategory), errors.Is(err, services.ErrInvalidCollection):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+type TextModerationHandler struct {
+	service *services.TextModerationService
+}
+
+func NewTextModerationHandler(service *services.TextModerationService) *TextModerationHandler {
+	return &TextModerationHandler{service: service}
+}
+
+// textErrorStatus associe les refus de la modération automatique à un code
+// HTTP ; ok vaut false pour les autres erreurs.
+func textErrorStatus(err error) (status int, ok bool) {
+	switch {
+	case errors.Is(err, services.ErrEmptyText), errors.Is(err, services.ErrTextTooLong):
+		return http.StatusBadRequest, true
+	case errors.Is(err, services.ErrTextBlocked):
+		return http.StatusUnprocessableEntity, true
+	}
+	return 0, false
+}
+
+func textReviewErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrTextReviewNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrTextReviewClosed):
+		return http.StatusConflict
+	}
+	return moderationErrorStatus(err)
+}
+
+// Queue GET /api/admin/moderation/queue?status=pending&type=comment&limit=&offset=
+// Textes retenus par la modération automatique, les plus anciens d'abord.
+func (h *TextModerationHandler) Queue(c *gin.Context) {
+	status := c.DefaultQuery("status", models.TextReviewPending)
+	limit, offset := pageParams(c)
+	reviews, total, err := h.service.Queue(status, c.Query("type"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impossible de récupérer la file de modération"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": reviews, "total": total})
+}
+
+// Approve PUT /api/admin/moderation/queue/:id/approve
+func (h *TextModerationHandler) Approve(c *gin.Context) {
+	id, moderatorID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
+	review, err := h.service.Approve(id, moderatorID)
+	if err != nil {
+		c.JSON(textReviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if !recordAudit(c, models.AuditTextApproved, review.TargetType, review.TargetID.String(),
+		gin.H{"status": models.TextStatusHeld}, gin.H{"status": models.TextStatusVisible}) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"item": review})
+}
+
+// Reject PUT /api/admin/moderation/queue/:id/reject
+// Corps : {"reason_code": "...", "note": "..."} ; le motif n'est requis que
+// pour un contenu, rejeté comme depuis la modération des contenus.
+func (h *TextModerationHandler) Reject(c *gin.Context) {
+	id, moderatorID, ok := moderationIDs(c)
+	if !ok {
+		return
+	}
+	var payload struct {
+		ReasonCode string `json:"reason_code"`
+		Note       string `json:"note"`
+	}
+	_ = c.ShouldBindJSON(&payload)
+	review, err := h.service.Reject(id, moderatorID, payload.ReasonCode, payload.Note)
+	if err != nil {
+		c.JSON(textReviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if !recordAudit(c, models.AuditTextRejected, review.TargetType, review.TargetID.String(),
+		gin.H{"status": models.TextStatusHeld}, gin.H{"status": models.TextStatusRemoved, "excerpt": review.Excerpt}) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"item": review})
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+// TrendingHandler expose les tendances calculées par la tâche de fond.
+type TrendingHandler struct {
+	service *services.TrendingService
+	ages    *services.AgeVerificationService
+}
+
+func NewTrendingHandler(service *services.TrendingService, ages *services.AgeVerificationService) *TrendingHandler {
+	return &TrendingHandler{service: service, ages: ages}
+}
+
+// Contents GET /api/trending/contents?window=24h|7d|30d&limit=&offset=
+func (h *TrendingHandler) Contents(c *gin.Context) {
+	limit, offset := pageParams(c)
+	contents, err := h.service.Contents(c.Query("window"), viewerAllowsMature(c, h.ages), limit, offset)
+	if err != nil {
+		c.JSON(trendingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"contents": contents})
+}
+
+// Creators GET /api/trending/creators?window=24h|7d|30d&limit=&offset=
+func (h *TrendingHandler) Creators(c *gin.Context) {
+	limit, offset := pageParams(c)
+	creators, err := h.service.Creators(c.Query("window"), limit, offset)
+	if err != nil {
+		c.JSON(trendingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"creators": creators})
+}
+
+func trendingErrorStatus(err error) int {
+	if errors.Is(err, services.ErrInvalidTrendingWindow) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+// statusChecksumMismatch est le code renvoyé par le protocole tus quand la
+// somme de contrôle d'un morceau ne correspond pas.
+const statusChecksumMismatch = 460
+
+// UploadHandler expose les uploads reprenables.
+type UploadHandler struct {
+	service *services.UploadService
+}
+
+// NewUploadHandler instancie le handler d'upload reprenable.
+func NewUploadHandler(service *services.UploadService) *UploadHandler {
+	return &UploadHandler{service: service}
+}
+
+// CreateSession POST /api/uploads
+func (h *UploadHandler) CreateSession(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return
+	}
+
+	var in services.CreateUploadInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON invalide"})
+		return
+	}
+
+	session, err := h.service.CreateSession(userID, in)
+	if err != nil {
+		c.JSON(uploadSessionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	setUploadHeaders(c, session)
+	c.Header("Location", "/api/uploads/"+session.ID.String())
+	c.JSON(http.StatusCreated, session)
+}
+
+// GetSession GET|HEAD /api/uploads/:id
+// Renvoie l'offset courant pour reprendre un upload interrompu.
+func (h *UploadHandler) GetSession(c *gin.Context) {
+	userID, id, ok := uploadIDs(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.service.GetSession(userID, id)
+	if err != nil {
+		c.JSON(uploadSessionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	setUploadHeaders(c, session)
+	c.Header("Cache-Control", "no-store")
+	if c.Request.Method == http.MethodHead {
+		c.Status(http.StatusOK)
+		return
+	}
+	c.JSON(http.StatusOK, session)
+}
+
+// WriteChunk PATCH /api/uploads/:id
+// Corps : octets bruts (Content-Type application/offset+octet-stream),
+// en-têtes Upload-Offset et, optionnellement, Upload-Checksum.
+func (h *UploadHandler) WriteChunk(c *gin.Context) {
+	userID, id, ok := uploadIDs(c)
+	if !ok {
+		return
+	}
+
+	if c.ContentType() != "application/offset+octet-stream" {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type application/offset+octet-stream attendu"})
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "en-tête Upload-Offset invalide"})
+		return
+	}
+
+	newOffset, err := h.service.WriteChunk(userID, id, offset, c.Request.Body, c.GetHeader("Upload-Checksum"))
+	c.Header("Upload-Offset", strconv.FormatInt(newOffset, 10))
+	if err != nil {
+		c.JSON(uploadSessionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Finalize POST /api/uploads/:id/finalize
+func (h *UploadHandler) Finalize(c *gin.Context) {
+	userID, id, ok := uploadIDs(c)
+	if !ok {
+		return
+	}
+
+	content, err := h.service.Finalize(userID, id)
+	if err != nil {
+		c.JSON(uploadSessionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"id":        content.ID,
+		"title":     content.Title,
+		"body":      content.Body,
+		"price":     content.Price,
+		"file_path": content.FilePath,
+		"preview":   content.Preview,
+	})
+}
+
+// Abort DELETE /api/uploads/:id
+func (h *UploadHandler) Abort(c *gin.Context) {
+	userID, id, ok := uploadIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Abort(userID, id); err != nil {
+		c.JSON(uploadSessionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func uploadIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ID utilisateur invalide"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de session invalide"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, id, true
+}
+
+func setUploadHeaders(c *gin.Context, session *models.UploadSession) {
+	c.Header("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(session.Size, 10))
+}
+
+// uploadSessionErrorStatus associe les erreurs d'upload reprenable à un code HTTP.
+func uploadSessionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrUploadForbidden), isSanctionError(err):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrUploadSessionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrUploadOffsetMismatch),
+		errors.Is(err, services.ErrUploadIncomplete),
+		errors.Is(err, services.ErrUploadCompleted),
+		errors.Is(err, services.ErrUploadFinalizing):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrUploadChecksumMismatch):
+		return statusChecksumMismatch
+	case errors.Is(err, services.ErrUploadChecksumAlgorithm),
+		errors.Is(err, services.ErrUploadInvalidSession):
+		return http.StatusBadRequest
+	}
+	if status := uploadErrorStatus(err); status != http.StatusForbidden {
+		return status
+	}
+	return http.StatusInternalServerError
+}
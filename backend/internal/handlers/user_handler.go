@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -40,6 +41,10 @@ func CurrentUserHandler(c *gin.Context) {
 		Email:     user.Email,
 		Role:      user.Role,
 		CreatedAt: user.CreatedAt,
+
+		AgeVerificationStatus: user.AgeVerificationState(time.Now()),
+		AgeVerifiedAt:         user.AgeVerifiedAt,
+		HideMatureContent:     user.HideMatureContent,
 	}
 
 	c.JSON(http.StatusOK, gin.H{"user": resp})
@@ -71,6 +71,9 @@ func GinLogger() gin.HandlerFunc {
 		if userID, exists := c.Get("userID"); exists {
 			entry = entry.WithField("user_id", userID)
 		}
+		if requestID := c.GetString("requestID"); requestID != "" {
+			entry = entry.WithField("request_id", requestID)
+		}
 
 		if errorMessage != "" {
 			entry.WithField("error", errorMessage).Error("Request failed")
@@ -144,7 +147,7 @@ func LogContent(event string, userID string, contentID string, data map[string]i
 	Log.WithFields(fields).Info("Content event")
 }
 
-func LogAdmin(event string, adminID uint, targetType string, targetID uint, data map[string]interface{}) {
+func LogAdmin(event string, adminID string, targetType string, targetID string, data map[string]interface{}) {
 	fields := logrus.Fields{
 		"type":        "admin_action",
 		"event":       event,
@@ -1,40 +1,97 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/config"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
 )
 
+// accessCheck refuse un compte sanctionné (banni, suspendu) ; sans contrôle
+// configuré, tout token valide est accepté.
+var accessCheck func(userID uuid.UUID) error
+
+// SetAccessCheck injecte le contrôle des sanctions depuis main().
+func SetAccessCheck(check func(userID uuid.UUID) error) {
+	accessCheck = check
+}
+
+// checkAccess renvoie le statut et le message d'erreur si le compte est
+// sanctionné, ou 503 si les sanctions n'ont pas pu être vérifiées.
+func checkAccess(subject string) (int, string) {
+	if accessCheck == nil {
+		return 0, ""
+	}
+	userID, err := uuid.Parse(subject)
+	if err != nil {
+		return 0, ""
+	}
+	if err := accessCheck(userID); err != nil {
+		if errors.Is(err, services.ErrSanctionCheckUnavailable) {
+			return http.StatusServiceUnavailable, services.ErrSanctionCheckUnavailable.Error()
+		}
+		return http.StatusForbidden, err.Error()
+	}
+	return 0, ""
+}
+
 // JWTAuth vérifie la présence et la validité du token dans l'en-tête Authorization.
 func JWTAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		header := c.GetHeader("Authorization")
-		parts := strings.SplitN(header, " ", 2)
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token manquant ou mal formé"})
+		subject, msg := bearerSubject(c)
+		if msg != "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": msg})
 			return
 		}
-		tokenStr := parts[1]
-
-		token, err := jwt.ParseWithClaims(tokenStr, &jwt.StandardClaims{}, func(t *jwt.Token) (interface{}, error) {
-			return []byte(config.C.JwtSecret), nil
-		})
-		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token invalide"})
+		if status, msg := checkAccess(subject); msg != "" {
+			c.AbortWithStatusJSON(status, gin.H{"error": msg})
 			return
 		}
 
-		claims, ok := token.Claims.(*jwt.StandardClaims)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "claims invalides"})
-			return
-		}
+		c.Set("userID", subject)
+		c.Next()
+	}
+}
 
-		c.Set("userID", claims.Subject)
+// OptionalJWTAuth renseigne userID si un token valide est fourni, sans
+// bloquer les visiteurs anonymes (routes publiques personnalisables). Un
+// compte sanctionné, ou dont les sanctions n'ont pas pu être vérifiées, est
+// traité comme un visiteur anonyme.
+func OptionalJWTAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if subject, msg := bearerSubject(c); msg == "" {
+			if _, denied := checkAccess(subject); denied == "" {
+				c.Set("userID", subject)
+			}
+		}
 		c.Next()
 	}
 }
+
+// bearerSubject extrait le sujet du token Bearer, ou un message d'erreur.
+func bearerSubject(c *gin.Context) (string, string) {
+	header := c.GetHeader("Authorization")
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return "", "token manquant ou mal formé"
+	}
+	tokenStr := parts[1]
+
+	token, err := jwt.ParseWithClaims(tokenStr, &jwt.StandardClaims{}, func(t *jwt.Token) (interface{}, error) {
+		return []byte(config.C.JwtSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return "", "token invalide"
+	}
+
+	claims, ok := token.Claims.(*jwt.StandardClaims)
+	if !ok {
+		return "", "claims invalides"
+	}
+	return claims.Subject, ""
+}
@@ -30,8 +30,20 @@ func PrometheusMiddleware() gin.HandlerFunc {
 
 		duration := time.Since(start).Seconds()
 		status := strconv.Itoa(c.Writer.Status())
+		handler := routeLabel(c)
 
-		httpDuration.WithLabelValues(c.FullPath(), c.Request.Method, status).Observe(duration)
-		httpRequests.WithLabelValues(c.FullPath(), c.Request.Method, status).Inc()
+		httpDuration.WithLabelValues(handler, c.Request.Method, status).Observe(duration)
+		httpRequests.WithLabelValues(handler, c.Request.Method, status).Inc()
 	}
 }
+
+// unmatchedRoute regroupe les requêtes sans route (404, 405) : le chemin
+// brut, choisi par le client, ferait exploser le nombre de séries.
+const unmatchedRoute = "unmatched"
+
+func routeLabel(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return unmatchedRoute
+}
@@ -36,6 +36,8 @@ type SimpleCreatorRank struct {
 	JoinedAt     time.Time `json:"joined_at"`
 }
 
+// SimpleContentRank classe un contenu par son engagement (likes,
+// commentaires visibles et vues pondérés) sur la période.
 type SimpleContentRank struct {
 	Rank        int       `json:"rank"`
 	ContentID   uuid.UUID `json:"content_id"`
@@ -44,6 +46,10 @@ type SimpleContentRank struct {
 	Price       int       `json:"price"`
 	Status      string    `json:"status"`
 	CreatedAt   time.Time `json:"created_at"`
+	Likes       int64     `json:"likes"`
+	Comments    int64     `json:"comments"`
+	Views       int64     `json:"views"`
+	Score       float64   `json:"score"`
 }
 
 type RevenueByPeriod struct {
@@ -59,6 +65,24 @@ type AdminDashboard struct {
 	RecentRevenue []RevenueByPeriod   `json:"recent_revenue"`
 }
 
+// PlatformDailyStat est l'activité de la plateforme sur un jour UTC,
+// matérialisée chaque nuit pour les tableaux de bord. Les montants sont en
+// centimes, issus des paiements réussis.
+type PlatformDailyStat struct {
+	Day              time.Time `gorm:"primaryKey" json:"day"`
+	NewUsers         int64     `gorm:"not null;default:0" json:"new_users"`
+	NewCreators      int64     `gorm:"not null;default:0" json:"new_creators"`
+	NewContents      int64     `gorm:"not null;default:0" json:"new_contents"`
+	NewSubscriptions int64     `gorm:"not null;default:0" json:"new_subscriptions"`
+	Cancellations    int64     `gorm:"not null;default:0" json:"cancellations"`
+	Payments         int64     `gorm:"not null;default:0" json:"payments"`
+	RevenueCents     int64     `gorm:"not null;default:0" json:"revenue_cents"`
+	Likes            int64     `gorm:"not null;default:0" json:"likes"`
+	Comments         int64     `gorm:"not null;default:0" json:"comments"`
+	Views            int64     `gorm:"not null;default:0" json:"views"`
+	ComputedAt       time.Time `json:"computed_at"`
+}
+
 type ContentStatusCount struct {
 	Status string `json:"status"`
 	Count  int64  `json:"count"`
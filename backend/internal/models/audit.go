@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Actions tracées dans le journal d'audit.
+const (
+	AuditUserRoleChanged       = "user.role_changed"
+	AuditAgeVerificationReview = "user.age_verification_reviewed"
+	AuditAgeVerificationReset  = "user.age_verification_reset"
+	AuditContentDeleted        = "content.deleted"
+	AuditContentApproved       = "content.approved"
+	AuditContentRejected       = "content.rejected"
+	AuditContentMaturity       = "content.maturity_overridden"
+	AuditCommentDeleted        = "comment.deleted"
+	AuditDuplicateReviewed     = "duplicate.reviewed"
+	AuditTextApproved          = "text.approved"
+	AuditTextRejected          = "text.rejected"
+	AuditReportAssigned        = "report.assigned"
+	AuditReportResolved        = "report.resolved"
+	AuditReportDismissed       = "report.dismissed"
+	AuditSanctionIssued        = "sanction.issued"
+	AuditSanctionLifted        = "sanction.lifted"
+	AuditFeatureToggled        = "feature.toggled"
+	AuditCategoryCreated       = "category.created"
+	AuditCategoryUpdated       = "category.updated"
+	AuditCategoryDeleted       = "category.deleted"
+)
+
+// AuditEntry est une ligne du journal d'audit des actions d'administration.
+// Le journal est en ajout seul : chaque entrée porte le hash de la précédente
+// (PrevHash) et son propre hash, calculé sur tous ses champs, si bien que
+// toute modification ou suppression casse la chaîne.
+type AuditEntry struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Seq        int64     `gorm:"not null;uniqueIndex" json:"seq"`
+	ActorID    uuid.UUID `gorm:"type:uuid;not null;index" json:"actor_id"`
+	ActorRole  string    `gorm:"size:16" json:"actor_role"`
+	Action     string    `gorm:"size:64;not null;index" json:"action"`
+	TargetType string    `gorm:"size:32;not null;index:idx_audit_target" json:"target_type"`
+	TargetID   string    `gorm:"size:64;not null;index:idx_audit_target" json:"target_id"`
+	Before     string    `gorm:"type:text" json:"-"`
+	After      string    `gorm:"type:text" json:"-"`
+	IP         string    `gorm:"size:64" json:"ip"`
+	RequestID  string    `gorm:"size:64" json:"request_id"`
+	CreatedAt  time.Time `gorm:"not null;index" json:"created_at"`
+	PrevHash   string    `gorm:"size:64;not null" json:"prev_hash"`
+	Hash       string    `gorm:"size:64;not null" json:"hash"`
+}
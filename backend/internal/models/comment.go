@@ -13,6 +13,7 @@ type Comment struct {
 	Text      string     `gorm:"not null"                                   json:"text"`
 	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime"           json:"created_at"`
 	ParentID  *uuid.UUID `gorm:"column:parent_id;type:uuid;index"           json:"parent_id"`
+	Status    string     `gorm:"size:16;default:'visible';not null;index"   json:"status"`
 	Author    User       `gorm:"foreignKey:AuthorID;references:ID"          json:"author"`
 	Content   Content    `gorm:"foreignKey:ContentID;references:ID"         json:"content"`
 }
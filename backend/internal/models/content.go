@@ -6,12 +6,76 @@ import (
 	"github.com/google/uuid"
 )
 
+// Cycle de vie d'un contenu : draft → pending → approved (publié) ou
+// scheduled (approuvé, publication à PublishAt) ; rejected ; archived.
 const (
-	ContentStatusPending  = "pending"
-	ContentStatusApproved = "approved"
-	ContentStatusRejected = "rejected"
+	ContentStatusDraft     = "draft"
+	ContentStatusPending   = "pending"
+	ContentStatusApproved  = "approved"
+	ContentStatusRejected  = "rejected"
+	ContentStatusScheduled = "scheduled"
+	ContentStatusArchived  = "archived"
 )
 
+// IsValidContentStatus indique si le statut fait partie du cycle de vie.
+func IsValidContentStatus(status string) bool {
+	switch status {
+	case ContentStatusDraft, ContentStatusPending, ContentStatusApproved,
+		ContentStatusRejected, ContentStatusScheduled, ContentStatusArchived:
+		return true
+	}
+	return false
+}
+
+// Classification des contenus. Tout ce qui n'est pas "general" est réservé
+// aux utilisateurs majeurs dont l'âge a été vérifié.
+const (
+	MaturityGeneral  = "general"
+	MaturityMature   = "mature"
+	MaturityExplicit = "explicit"
+)
+
+// IsValidMaturity indique si la classification est connue.
+func IsValidMaturity(m string) bool {
+	switch m {
+	case MaturityGeneral, MaturityMature, MaturityExplicit:
+		return true
+	}
+	return false
+}
+
+const (
+	PreviewModeWatermark = "watermark"
+	PreviewModeBlur      = "blur"
+	PreviewModePixelate  = "pixelate"
+	PreviewModeCrop      = "crop"
+)
+
+const (
+	WatermarkPositionTile        = "tile"
+	WatermarkPositionCenter      = "center"
+	WatermarkPositionTopLeft     = "top-left"
+	WatermarkPositionTopRight    = "top-right"
+	WatermarkPositionBottomLeft  = "bottom-left"
+	WatermarkPositionBottomRight = "bottom-right"
+)
+
+// PreviewSettings décrit l'aperçu servi aux non-abonnés, choisi par le créateur.
+// Les coordonnées de recadrage sont relatives (0..1) à l'image d'origine.
+type PreviewSettings struct {
+	Mode              string  `gorm:"default:'watermark';not null" json:"mode"`
+	BlurRadius        int     `gorm:"default:0;not null" json:"blur_radius"`
+	PixelSize         int     `gorm:"default:0;not null" json:"pixel_size"`
+	CropX             float64 `gorm:"default:0;not null" json:"crop_x"`
+	CropY             float64 `gorm:"default:0;not null" json:"crop_y"`
+	CropWidth         float64 `gorm:"default:0;not null" json:"crop_width"`
+	CropHeight        float64 `gorm:"default:0;not null" json:"crop_height"`
+	WatermarkText     string  `json:"watermark_text"`
+	WatermarkLogoPath string  `json:"watermark_logo_path"`
+	WatermarkOpacity  float64 `gorm:"default:0;not null" json:"watermark_opacity"`
+	WatermarkPosition string  `json:"watermark_position"`
+}
+
 type Content struct {
 	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
 	CreatorID uuid.UUID `gorm:"type:uuid;not null;index" json:"creator_id"`
@@ -22,4 +86,30 @@ type Content struct {
 	Price     int       `gorm:"not null" json:"price"`
 	FilePath  string    `gorm:"not null" json:"file_path"`
 	Status    string    `gorm:"type:content_status;default:'pending';not null" json:"status"`
+	IsBlurred bool      `gorm:"column:is_blurred;default:false" json:"is_blurred"`
+	SHA256    string    `gorm:"column:sha256;size:64;index" json:"sha256"`
+	PHash     *int64    `gorm:"column:phash" json:"-"`
+	Width     int       `json:"width"`
+	Height    int       `json:"height"`
+	// MediaType est le type détecté à l'upload ; vide pour les images
+	// antérieures. Seuls JPEG et PNG ont un aperçu et un marquage.
+	MediaType string `gorm:"size:100" json:"media_type"`
+
+	CategoryID *uuid.UUID `gorm:"type:uuid;index" json:"category_id"`
+
+	// Maturity est choisie par le créateur ; un modérateur peut l'imposer
+	// (MaturityModeratedBy renseigné), le créateur ne peut alors plus la modifier.
+	Maturity            string     `gorm:"size:16;default:'general';not null;index" json:"maturity"`
+	MaturityModeratedBy *uuid.UUID `gorm:"type:uuid" json:"maturity_moderated_by,omitempty"`
+
+	PublishAt   *time.Time `gorm:"index" json:"publish_at"`
+	PublishedAt *time.Time `json:"published_at"`
+	ArchivedAt  *time.Time `json:"archived_at"`
+
+	Preview PreviewSettings `gorm:"embedded;embeddedPrefix:preview_" json:"preview"`
+}
+
+// IsMature indique si le contenu est réservé aux adultes.
+func (c *Content) IsMature() bool {
+	return c.Maturity != "" && c.Maturity != MaturityGeneral
 }
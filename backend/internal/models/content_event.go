@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Types d'événements sur un contenu.
+const (
+	ContentEventView         = "view"
+	ContentEventImpression   = "impression"
+	ContentEventDownload     = "download"
+	ContentEventLike         = "like"
+	ContentEventComment      = "comment"
+	ContentEventSubscription = "subscription"
+)
+
+// ContentEvent est un événement brut sur un contenu. DedupKey, quand il est
+// renseigné, est unique : un second événement de même clé est ignoré (vue
+// d'un même utilisateur dans la même fenêtre, like déjà compté…). Un
+// événement "subscription" attribue un nouvel abonnement au dernier contenu
+// vu du créateur.
+type ContentEvent struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ContentID  uuid.UUID  `gorm:"type:uuid;not null;index:idx_content_event_content" json:"content_id"`
+	UserID     *uuid.UUID `gorm:"type:uuid;index" json:"user_id,omitempty"`
+	Type       string     `gorm:"size:16;not null" json:"type"`
+	DedupKey   *string    `gorm:"size:160;uniqueIndex" json:"-"`
+	OccurredAt time.Time  `gorm:"not null;index;index:idx_content_event_content" json:"occurred_at"`
+}
+
+// ContentDailyStat est le cumul quotidien (jour UTC) des événements d'un
+// contenu, recalculé périodiquement à partir des ContentEvent.
+type ContentDailyStat struct {
+	ContentID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	Day           time.Time `gorm:"primaryKey" json:"day"`
+	Views         int64     `gorm:"not null;default:0" json:"views"`
+	UniqueViewers int64     `gorm:"not null;default:0" json:"unique_viewers"`
+	Impressions   int64     `gorm:"not null;default:0" json:"impressions"`
+	Downloads     int64     `gorm:"not null;default:0" json:"downloads"`
+	Likes         int64     `gorm:"not null;default:0" json:"likes"`
+	Comments      int64     `gorm:"not null;default:0" json:"comments"`
+	Subscriptions int64     `gorm:"not null;default:0" json:"subscriptions"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FieldChange conserve l'ancienne et la nouvelle valeur d'un champ modifié.
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// FieldChanges est le diff d'une révision, stocké en JSON.
+type FieldChanges map[string]FieldChange
+
+func (f FieldChanges) Value() (driver.Value, error) {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (f *FieldChanges) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*f = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, f)
+	case string:
+		return json.Unmarshal([]byte(v), f)
+	default:
+		return fmt.Errorf("FieldChanges: type %T non supporté", value)
+	}
+}
+
+// ContentRevision enregistre une modification d'un contenu : auteur, champs
+// modifiés et, si le fichier a été remplacé, le chemin de l'ancien fichier.
+type ContentRevision struct {
+	ID               uuid.UUID    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ContentID        uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_content_revision" json:"content_id"`
+	Revision         int          `gorm:"not null;uniqueIndex:idx_content_revision" json:"revision"`
+	EditorID         uuid.UUID    `gorm:"type:uuid;not null;index" json:"editor_id"`
+	Changes          FieldChanges `gorm:"type:text;not null" json:"changes"`
+	PreviousFilePath string       `json:"previous_file_path,omitempty"`
+	StatusBefore     string       `gorm:"not null" json:"status_before"`
+	StatusAfter      string       `gorm:"not null" json:"status_after"`
+	CreatedAt        time.Time    `gorm:"autoCreateTime" json:"created_at"`
+}
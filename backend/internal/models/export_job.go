@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cycle de vie d'un export différé : pending → running → completed | failed.
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusRunning   = "running"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+// ExportJob suit un export trop volumineux pour être servi directement : le
+// fichier est généré en tâche de fond puis téléchargeable jusqu'à ExpiresAt.
+// Tant qu'il n'est pas terminé, l'instance qui le génère (InstanceID) renouvelle
+// HeartbeatAt.
+type ExportJob struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	OwnerID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"owner_id"`
+	Scope       string     `gorm:"size:16;not null" json:"scope"`
+	Dataset     string     `gorm:"size:32;not null" json:"dataset"`
+	Format      string     `gorm:"size:8;not null" json:"format"`
+	Params      string     `gorm:"type:text" json:"params,omitempty"`
+	Status      string     `gorm:"size:16;not null;default:'pending';index" json:"status"`
+	Rows        int64      `gorm:"not null;default:0" json:"rows"`
+	FilePath    string     `json:"-"`
+	Error       string     `gorm:"type:text" json:"error,omitempty"`
+	InstanceID  string     `gorm:"size:64;index" json:"-"`
+	HeartbeatAt time.Time  `gorm:"index" json:"-"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   time.Time  `gorm:"not null;index" json:"expires_at"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ForensicMarkKindView     = "view"
+	ForensicMarkKindDownload = "download"
+)
+
+// ForensicMark relie un jeton de watermark invisible à l'abonné qui a reçu l'image.
+type ForensicMark struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Token        int64     `gorm:"not null;uniqueIndex" json:"token"`
+	ContentID    uuid.UUID `gorm:"type:uuid;not null;index" json:"content_id"`
+	SubscriberID uuid.UUID `gorm:"type:uuid;not null;index" json:"subscriber_id"`
+	Kind         string    `gorm:"not null" json:"kind"`
+	Version      int       `gorm:"not null" json:"version"`
+	IssuedAt     time.Time `gorm:"not null" json:"issued_at"`
+}
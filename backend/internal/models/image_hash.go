@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImageHashBands est le nombre d'octets du hash perceptuel indexés séparément.
+// Deux hashs à distance de Hamming < ImageHashBands partagent forcément au
+// moins un octet : la recherche de candidats se fait sur index.
+const ImageHashBands = 8
+
+// ImageHashBand indexe un octet du hash perceptuel d'un contenu.
+type ImageHashBand struct {
+	ContentID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Band      int       `gorm:"primaryKey;autoIncrement:false;index:idx_image_hash_band_value,priority:1"`
+	Value     int       `gorm:"not null;index:idx_image_hash_band_value,priority:2"`
+}
+
+const (
+	DuplicateFlagOpen      = "open"
+	DuplicateFlagDismissed = "dismissed"
+	DuplicateFlagConfirmed = "confirmed"
+)
+
+// DuplicateFlag signale un contenu visuellement proche du contenu d'un autre
+// créateur, publié avant lui.
+type DuplicateFlag struct {
+	ID                uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ContentID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"content_id"`
+	Content           Content    `gorm:"foreignKey:ContentID" json:"-"`
+	OriginalContentID uuid.UUID  `gorm:"type:uuid;not null;index" json:"original_content_id"`
+	OriginalContent   Content    `gorm:"foreignKey:OriginalContentID" json:"-"`
+	Distance          int        `gorm:"not null" json:"distance"`
+	Status            string     `gorm:"size:16;default:'open';not null;index" json:"status"`
+	ReviewedBy        *uuid.UUID `gorm:"type:uuid" json:"reviewed_by,omitempty"`
+	ReviewedAt        *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt         time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -8,7 +8,7 @@ import (
 
 type Like struct {
 	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	ContentID uuid.UUID `gorm:"not null"`
-	UserID    uuid.UUID `gorm:"not null"`
+	ContentID uuid.UUID `gorm:"not null;index"`
+	UserID    uuid.UUID `gorm:"not null;index"`
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 }
@@ -12,6 +12,7 @@ type Message struct {
 	ReceiverID uuid.UUID `gorm:"not null"`
 	Text       string    `gorm:"not null"`
 	SentAt     time.Time `gorm:"autoCreateTime"`
+	Status     string    `gorm:"size:16;default:'visible';not null"`
 }
 
 func (Message) TableName() string {
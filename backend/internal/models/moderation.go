@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Actions enregistrées dans l'historique de modération d'un contenu.
+const (
+	ModerationActionApprove = "approve"
+	ModerationActionReject  = "reject"
+	ModerationActionAppeal  = "appeal"
+)
+
+// Motifs de rejet proposés aux modérateurs ; "other" exige une note.
+const (
+	ModerationReasonCopyright      = "copyright"
+	ModerationReasonUnlabeledAdult = "unlabeled_adult"
+	ModerationReasonIllegal        = "illegal"
+	ModerationReasonHarassment     = "harassment"
+	ModerationReasonSpam           = "spam"
+	ModerationReasonLowQuality     = "low_quality"
+	ModerationReasonOther          = "other"
+)
+
+// ModerationReasonLabels associe chaque motif à son libellé pour le créateur.
+var ModerationReasonLabels = map[string]string{
+	ModerationReasonCopyright:      "Atteinte aux droits d'auteur",
+	ModerationReasonUnlabeledAdult: "Contenu adulte non signalé",
+	ModerationReasonIllegal:        "Contenu illégal",
+	ModerationReasonHarassment:     "Harcèlement ou contenu haineux",
+	ModerationReasonSpam:           "Spam ou publicité",
+	ModerationReasonLowQuality:     "Qualité insuffisante",
+	ModerationReasonOther:          "Autre motif",
+}
+
+// ModerationDecision est une entrée, jamais modifiée, de l'historique de
+// modération : décision d'un modérateur ou appel du créateur (ActorID est
+// alors le créateur).
+type ModerationDecision struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ContentID    uuid.UUID `gorm:"type:uuid;not null;index" json:"content_id"`
+	Content      Content   `gorm:"foreignKey:ContentID" json:"-"`
+	ActorID      uuid.UUID `gorm:"type:uuid;not null;index" json:"actor_id"`
+	Action       string    `gorm:"size:16;not null" json:"action"`
+	ReasonCode   string    `gorm:"size:32" json:"reason_code,omitempty"`
+	Note         string    `gorm:"type:text" json:"note,omitempty"`
+	StatusBefore string    `gorm:"size:16;not null" json:"status_before"`
+	StatusAfter  string    `gorm:"size:16;not null" json:"status_after"`
+	CreatedAt    time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
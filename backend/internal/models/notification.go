@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotificationContentApproved = "content_approved"
+	NotificationContentRejected = "content_rejected"
+	NotificationTextRejected    = "text_rejected"
+)
+
+// Notification informe un utilisateur d'un événement qui le concerne.
+type Notification struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Type      string     `gorm:"size:32;not null" json:"type"`
+	ContentID *uuid.UUID `gorm:"type:uuid" json:"content_id,omitempty"`
+	Message   string     `gorm:"type:text;not null" json:"message"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime;index" json:"created_at"`
+}
@@ -6,10 +6,71 @@ import (
 	"github.com/google/uuid"
 )
 
+// Types d'éléments signalables.
+const (
+	ReportTargetContent = "content"
+	ReportTargetComment = "comment"
+	ReportTargetMessage = "message"
+	ReportTargetUser    = "user"
+)
+
+// Cycle de vie d'un signalement : open → in_review → resolved | dismissed.
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusInReview  = "in_review"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+// Catégories de signalement proposées aux utilisateurs.
+const (
+	ReportReasonSpam           = "spam"
+	ReportReasonHarassment     = "harassment"
+	ReportReasonCopyright      = "copyright"
+	ReportReasonUnlabeledAdult = "unlabeled_adult"
+	ReportReasonIllegal        = "illegal"
+	ReportReasonImpersonation  = "impersonation"
+	ReportReasonScam           = "scam"
+	ReportReasonOther          = "other"
+)
+
+// ReportReasonLabels associe chaque catégorie à son libellé.
+var ReportReasonLabels = map[string]string{
+	ReportReasonSpam:           "Spam ou publicité",
+	ReportReasonHarassment:     "Harcèlement ou contenu haineux",
+	ReportReasonCopyright:      "Atteinte aux droits d'auteur",
+	ReportReasonUnlabeledAdult: "Contenu adulte non signalé",
+	ReportReasonIllegal:        "Contenu illégal",
+	ReportReasonImpersonation:  "Usurpation d'identité",
+	ReportReasonScam:           "Arnaque",
+	ReportReasonOther:          "Autre",
+}
+
+// Suites données à un signalement traité.
+const (
+	ReportActionNone            = "no_action"
+	ReportActionContentRejected = "content_rejected"
+	ReportActionCommentDeleted  = "comment_deleted"
+)
+
 type Report struct {
-	ID              uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	TargetContentID uuid.UUID `gorm:"not null"`
-	ReporterID      uuid.UUID `gorm:"not null"`
-	Reason          string    `gorm:"not null"`
-	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	ID               uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TargetType       string     `gorm:"size:16;not null;default:'content';index:idx_report_target" json:"target_type"`
+	TargetID         uuid.UUID  `gorm:"type:uuid;not null;index:idx_report_target" json:"target_id"`
+	ReporterID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"reporter_id"`
+	ReasonCode       string     `gorm:"size:32;not null;default:'other'" json:"reason_code"`
+	Details          string     `gorm:"column:details;type:text" json:"details,omitempty"`
+	Status           string     `gorm:"size:16;not null;default:'open';index" json:"status"`
+	AssigneeID       *uuid.UUID `gorm:"type:uuid;index" json:"assignee_id,omitempty"`
+	ResolutionAction string     `gorm:"size:32" json:"resolution_action,omitempty"`
+	ResolutionNote   string     `gorm:"type:text" json:"resolution_note,omitempty"`
+	ResolvedBy       *uuid.UUID `gorm:"type:uuid" json:"resolved_by,omitempty"`
+	ResolvedAt       *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime;index" json:"created_at"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// IsOpen indique si le signalement attend encore une décision.
+func (r *Report) IsOpen() bool {
+	return r.Status == ReportStatusOpen || r.Status == ReportStatusInReview
 }
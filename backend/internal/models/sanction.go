@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Types de sanctions applicables à un compte.
+const (
+	SanctionSuspension         = "suspension"
+	SanctionBan                = "ban"
+	SanctionRestrictPosting    = "restrict_posting"
+	SanctionRestrictCommenting = "restrict_commenting"
+	SanctionRestrictMessaging  = "restrict_messaging"
+)
+
+// SanctionLabels associe chaque type de sanction à son libellé.
+var SanctionLabels = map[string]string{
+	SanctionSuspension:         "Suspension temporaire",
+	SanctionBan:                "Bannissement définitif",
+	SanctionRestrictPosting:    "Publication de contenus interdite",
+	SanctionRestrictCommenting: "Commentaires interdits",
+	SanctionRestrictMessaging:  "Messagerie interdite",
+}
+
+// Sanction est une mesure prise par un administrateur contre un compte.
+// ExpiresAt nil signifie sans limite de durée ; une sanction levée garde
+// son historique (LiftedAt, LiftedBy).
+type Sanction struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Kind       string     `gorm:"size:32;not null" json:"kind"`
+	Reason     string     `gorm:"type:text;not null" json:"reason"`
+	IssuedBy   uuid.UUID  `gorm:"type:uuid;not null" json:"issued_by"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LiftedAt   *time.Time `json:"lifted_at,omitempty"`
+	LiftedBy   *uuid.UUID `gorm:"type:uuid" json:"lifted_by,omitempty"`
+	LiftReason string     `gorm:"type:text" json:"lift_reason,omitempty"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// ActiveAt indique si la sanction s'applique à l'instant donné.
+func (s *Sanction) ActiveAt(now time.Time) bool {
+	return s.LiftedAt == nil && (s.ExpiresAt == nil || s.ExpiresAt.After(now))
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecentSearch est une recherche récente d'un utilisateur, normalisée
+// (minuscules, espaces réduits) pour ne la garder qu'une fois.
+type RecentSearch struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_recent_search_user_query" json:"-"`
+	Query      string    `gorm:"size:256;not null;uniqueIndex:idx_recent_search_user_query" json:"query"`
+	SearchedAt time.Time `gorm:"not null;index" json:"searched_at"`
+}
+
+// SearchMiss compte les recherches restées sans aucun résultat.
+type SearchMiss struct {
+	Query       string    `gorm:"size:256;primaryKey" json:"query"`
+	Searches    int64     `gorm:"not null;default:0" json:"searches"`
+	FirstSeenAt time.Time `gorm:"not null" json:"first_seen_at"`
+	LastSeenAt  time.Time `gorm:"not null;index" json:"last_seen_at"`
+}
@@ -21,13 +21,20 @@ type Subscription struct {
 	PaymentID    uuid.UUID `gorm:"type:uuid;not null" json:"payment_id"`
 	Price        int       `gorm:"column:price;default:3000;not null" json:"price"`
 	Status       string    `gorm:"column:status;default:'active';not null" json:"status"`
-	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	// PausedAt date la suspension de l'abonnement (créateur banni) ; la
+	// période payée restante est rendue à la reprise.
+	PausedAt *time.Time `gorm:"column:paused_at" json:"paused_at,omitempty"`
+	// CanceledAt date la résiliation : l'abonnement est conservé (statut
+	// "canceled", échéance ramenée à la résiliation) pour l'historique.
+	CanceledAt *time.Time `gorm:"column:canceled_at" json:"canceled_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
 }
 
 const (
 	SubscriptionStatusActive   = "active"
 	SubscriptionStatusExpired  = "expired"
 	SubscriptionStatusCanceled = "canceled"
+	SubscriptionStatusPaused   = "paused"
 )
 
 func (s *Subscription) IsActive() bool {
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tag est un mot-clé normalisé (minuscules, tirets) librement choisi par les créateurs.
+type Tag struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name      string    `gorm:"size:32;not null;uniqueIndex" json:"name"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// ContentTag associe un contenu à un tag.
+type ContentTag struct {
+	ContentID uuid.UUID `gorm:"type:uuid;primaryKey" json:"content_id"`
+	TagID     uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"tag_id"`
+}
+
+// Category appartient à la taxonomie fixe gérée par les admins
+// (illustration, 3D, photographie…).
+type Category struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Slug      string    `gorm:"size:64;not null;uniqueIndex" json:"slug"`
+	Name      string    `gorm:"not null" json:"name"`
+	Position  int       `gorm:"not null;default:0" json:"position"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// Collection est une série ordonnée de contenus d'un créateur (album).
+type Collection struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatorID   uuid.UUID `gorm:"type:uuid;not null;index" json:"creator_id"`
+	Title       string    `gorm:"not null" json:"title"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// CollectionItem place un contenu à une position dans une collection.
+type CollectionItem struct {
+	CollectionID uuid.UUID `gorm:"type:uuid;primaryKey" json:"collection_id"`
+	ContentID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"content_id"`
+	Position     int       `gorm:"not null" json:"position"`
+}
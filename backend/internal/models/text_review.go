@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Verdicts de la modération automatique des textes.
+const (
+	TextVerdictAllow = "allow"
+	TextVerdictHold  = "hold"
+	TextVerdictBlock = "block"
+)
+
+// Visibilité d'un commentaire ou d'un message : un texte retenu n'est visible
+// que de son auteur jusqu'à la décision d'un modérateur.
+const (
+	TextStatusVisible = "visible"
+	TextStatusHeld    = "held"
+	TextStatusRemoved = "removed"
+)
+
+// Types de textes modérés.
+const (
+	TextTargetComment = "comment"
+	TextTargetMessage = "message"
+	TextTargetContent = "content"
+)
+
+// États d'un texte dans la file de modération.
+const (
+	TextReviewPending  = "pending"
+	TextReviewApproved = "approved"
+	TextReviewRejected = "rejected"
+)
+
+// TextReview place dans la file de modération un texte retenu par les
+// filtres automatiques, avec les raisons de la mise en attente.
+type TextReview struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TargetType string     `gorm:"size:16;not null;index:idx_text_review_target" json:"target_type"`
+	TargetID   uuid.UUID  `gorm:"type:uuid;not null;index:idx_text_review_target" json:"target_id"`
+	AuthorID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"author_id"`
+	Excerpt    string     `gorm:"type:text;not null" json:"excerpt"`
+	Reasons    string     `gorm:"size:255" json:"reasons"`
+	Score      float64    `json:"score"`
+	Status     string     `gorm:"size:16;default:'pending';not null;index" json:"status"`
+	ReviewedBy *uuid.UUID `gorm:"type:uuid" json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime;index" json:"created_at"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Fenêtres de calcul des tendances.
+const (
+	TrendingWindowDay   = "24h"
+	TrendingWindowWeek  = "7d"
+	TrendingWindowMonth = "30d"
+)
+
+const (
+	TrendingTargetContent = "content"
+	TrendingTargetCreator = "creator"
+)
+
+// ActivityBucket agrège l'activité d'un contenu sur une heure. Les nouveaux
+// abonnements, propres au créateur, sont rangés avec ContentID = uuid.Nil.
+type ActivityBucket struct {
+	Hour          time.Time `gorm:"primaryKey;index" json:"hour"`
+	CreatorID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"creator_id"`
+	ContentID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"content_id"`
+	Likes         int64     `gorm:"not null;default:0" json:"likes"`
+	Comments      int64     `gorm:"not null;default:0" json:"comments"`
+	Views         int64     `gorm:"not null;default:0" json:"views"`
+	Subscriptions int64     `gorm:"not null;default:0" json:"subscriptions"`
+}
+
+// TrendingScore est le score de tendance d'un contenu ou d'un créateur sur
+// une fenêtre, recalculé périodiquement à partir des ActivityBucket.
+type TrendingScore struct {
+	Window        string    `gorm:"column:time_window;size:8;primaryKey" json:"window"`
+	TargetType    string    `gorm:"size:16;primaryKey" json:"target_type"`
+	TargetID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"target_id"`
+	Score         float64   `gorm:"not null;index" json:"score"`
+	Likes         int64     `gorm:"not null;default:0" json:"likes"`
+	Comments      int64     `gorm:"not null;default:0" json:"comments"`
+	Views         int64     `gorm:"not null;default:0" json:"views"`
+	Subscriptions int64     `gorm:"not null;default:0" json:"subscriptions"`
+	ComputedAt    time.Time `gorm:"not null" json:"computed_at"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UploadSessionStatusUploading  = "uploading"
+	UploadSessionStatusFinalizing = "finalizing"
+	UploadSessionStatusCompleted  = "completed"
+)
+
+// UploadSession suit un upload reprenable : le fichier est reçu par morceaux
+// (Offset octets déjà écrits sur Size) puis finalisé en Content.
+type UploadSession struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Filename  string     `gorm:"not null" json:"filename"`
+	Size      int64      `gorm:"not null" json:"size"`
+	Offset    int64      `gorm:"not null;default:0" json:"offset"`
+	Checksum  string     `gorm:"size:64" json:"checksum,omitempty"`
+	Status    string     `gorm:"not null;default:'uploading';index" json:"status"`
+	ContentID *uuid.UUID `gorm:"type:uuid" json:"content_id,omitempty"`
+
+	Title   string          `gorm:"not null" json:"title"`
+	Body    string          `gorm:"not null" json:"body"`
+	Price   int             `gorm:"not null" json:"price"`
+	Preview PreviewSettings `gorm:"embedded;embeddedPrefix:preview_" json:"preview"`
+
+	Draft     bool       `gorm:"not null;default:false" json:"draft"`
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	Maturity  string     `gorm:"size:16" json:"maturity,omitempty"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+}
@@ -45,6 +45,59 @@ type User struct {
 	BirthDate      *time.Time
 	Bio            string `gorm:"type:text" json:"bio"`
 	AvatarURL      string `gorm:"column:avatar_url" json:"avatar_url"`
+
+	AgeVerificationStatus string     `gorm:"size:16;default:'unverified';not null" json:"age_verification_status"`
+	AgeVerifiedAt         *time.Time `json:"age_verified_at"`
+	HideMatureContent     bool       `gorm:"default:false;not null" json:"hide_mature_content"`
+}
+
+// Vérification d'âge : la date de naissance déclarée est contrôlée par un
+// modérateur avant d'ouvrir l'accès aux contenus adultes.
+const (
+	AgeVerificationUnverified = "unverified"
+	AgeVerificationPending    = "pending"
+	AgeVerificationVerified   = "verified"
+	AgeVerificationRejected   = "rejected"
+	// AgeVerificationMinor n'est jamais stocké : il est déduit de BirthDate.
+	AgeVerificationMinor = "minor"
+)
+
+// AdultAge est l'âge minimal pour accéder aux contenus adultes.
+const AdultAge = 18
+
+// Age renvoie l'âge révolu à la date now, ou -1 sans date de naissance.
+func (u *User) Age(now time.Time) int {
+	if u.BirthDate == nil {
+		return -1
+	}
+	b := u.BirthDate.UTC()
+	now = now.UTC()
+	age := now.Year() - b.Year()
+	if now.Month() < b.Month() || (now.Month() == b.Month() && now.Day() < b.Day()) {
+		age--
+	}
+	return age
+}
+
+// AgeVerificationState renvoie l'état effectif de la vérification d'âge :
+// un utilisateur mineur d'après sa date de naissance l'est quel que soit le
+// statut enregistré.
+func (u *User) AgeVerificationState(now time.Time) string {
+	if age := u.Age(now); age >= 0 && age < AdultAge {
+		return AgeVerificationMinor
+	}
+	if u.AgeVerificationStatus == "" {
+		return AgeVerificationUnverified
+	}
+	return u.AgeVerificationStatus
+}
+
+// CanViewMature indique si l'utilisateur peut recevoir des contenus adultes :
+// majeur, vérifié, et sans avoir choisi de les masquer.
+func (u *User) CanViewMature(now time.Time) bool {
+	return u.AgeVerificationState(now) == AgeVerificationVerified &&
+		u.Age(now) >= AdultAge &&
+		!u.HideMatureContent
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
@@ -0,0 +1,272 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// PlatformTotals regroupe les compteurs de la plateforme sur une période.
+type PlatformTotals struct {
+	Users        int64
+	Creators     int64
+	Contents     int64
+	Pending      int64
+	Approved     int64
+	Rejected     int64
+	RevenueCents int64
+	Subscribers  int64
+}
+
+// AdminStatsRepository calcule les statistiques d'administration en
+// requêtes d'agrégat uniques et gère leur cumul quotidien.
+type AdminStatsRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminStatsRepository() *AdminStatsRepository {
+	return &AdminStatsRepository{db: database.DB}
+}
+
+// Totals compte les inscriptions, contenus publiés, revenus encaissés entre
+// from et to et abonnés ayant eu un abonnement en cours sur la période.
+func (r *AdminStatsRepository) Totals(from, to time.Time) (PlatformTotals, error) {
+	query := `
+	SELECT u.users, u.creators, c.contents, c.pending, c.approved, c.rejected,
+	  p.revenue_cents, s.subscribers
+	FROM (SELECT COUNT(*) AS users, COALESCE(SUM(CASE WHEN role = ? THEN 1 ELSE 0 END), 0) AS creators
+	      FROM "user" WHERE created_at >= ? AND created_at < ?) u
+	CROSS JOIN (SELECT COUNT(*) AS contents,
+	      COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0) AS pending,
+	      COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0) AS approved,
+	      COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0) AS rejected
+	      FROM content WHERE created_at >= ? AND created_at < ?) c
+	CROSS JOIN (SELECT COALESCE(SUM(amount), 0) AS revenue_cents
+	      FROM payment WHERE status = ? AND paid_at >= ? AND paid_at < ?) p
+	CROSS JOIN (SELECT COUNT(DISTINCT subscriber_id) AS subscribers
+	      FROM subscription WHERE start_date < ? AND end_date >= ?) s`
+
+	var totals PlatformTotals
+	err := r.db.Raw(query,
+		models.RoleCreator, from, to,
+		models.ContentStatusPending, models.ContentStatusApproved, models.ContentStatusRejected, from, to,
+		models.StatusSucceeded, from, to,
+		to, from,
+	).Scan(&totals).Error
+	return totals, err
+}
+
+// TopCreators classe les créateurs par revenus encaissés entre from et to,
+// puis par abonnés et contenus publiés sur la période.
+func (r *AdminStatsRepository) TopCreators(from, to time.Time, limit int) ([]models.SimpleCreatorRank, error) {
+	query := `
+	WITH revenue AS (
+	  SELECT s.creator_id, SUM(p.amount) AS total
+	  FROM payment p JOIN subscription s ON s.id = p.subscription_id
+	  WHERE p.status = ? AND p.paid_at >= ? AND p.paid_at < ?
+	  GROUP BY s.creator_id),
+	subscribers AS (
+	  SELECT creator_id, COUNT(DISTINCT subscriber_id) AS total
+	  FROM subscription WHERE start_date < ? AND end_date >= ?
+	  GROUP BY creator_id),
+	posts AS (
+	  SELECT creator_id, COUNT(*) AS total
+	  FROM content WHERE created_at >= ? AND created_at < ?
+	  GROUP BY creator_id)
+	SELECT u.id AS creator_id, u.username, u.email, u.created_at AS joined_at,
+	  COALESCE(posts.total, 0) AS content_count,
+	  COALESCE(revenue.total, 0) AS total_revenue,
+	  COALESCE(subscribers.total, 0) AS subscribers
+	FROM "user" u
+	LEFT JOIN revenue ON revenue.creator_id = u.id
+	LEFT JOIN subscribers ON subscribers.creator_id = u.id
+	LEFT JOIN posts ON posts.creator_id = u.id
+	WHERE u.role = ?
+	ORDER BY total_revenue DESC, subscribers DESC, content_count DESC, u.username
+	LIMIT ?`
+
+	var creators []models.SimpleCreatorRank
+	err := r.db.Raw(query,
+		models.StatusSucceeded, from, to,
+		to, from,
+		from, to,
+		models.RoleCreator, limit,
+	).Scan(&creators).Error
+	for i := range creators {
+		creators[i].Rank = i + 1
+	}
+	return creators, err
+}
+
+// RankContents classe les contenus approuvés publiés entre from et to par
+// leur engagement sur la période, du meilleur au moins bon (ou l'inverse
+// si ascending).
+func (r *AdminStatsRepository) RankContents(from, to time.Time, weights EngagementWeights, limit int, ascending bool) ([]models.SimpleContentRank, error) {
+	order := "score DESC, c.created_at DESC, c.id"
+	if ascending {
+		order = "score ASC, c.created_at ASC, c.id"
+	}
+	query := `
+	WITH engagement AS (
+	  SELECT content_id, SUM(likes) AS likes, SUM(comments) AS comments, SUM(views) AS views
+	  FROM (
+	    SELECT content_id, 1 AS likes, 0 AS comments, 0 AS views
+	      FROM "like" WHERE created_at >= ? AND created_at < ?
+	    UNION ALL
+	    SELECT content_id, 0, 1, 0
+	      FROM comment WHERE status = ? AND created_at >= ? AND created_at < ?
+	    UNION ALL
+	    SELECT content_id, 0, 0, 1
+	      FROM content_event WHERE type = ? AND occurred_at >= ? AND occurred_at < ?
+	  ) activity
+	  GROUP BY content_id)
+	SELECT c.id AS content_id, c.title, u.username AS creator_name, c.price, c.status, c.created_at,
+	  COALESCE(e.likes, 0) AS likes, COALESCE(e.comments, 0) AS comments, COALESCE(e.views, 0) AS views,
+	  COALESCE(e.likes, 0) * ? + COALESCE(e.comments, 0) * ? + COALESCE(e.views, 0) * ? AS score
+	FROM content c
+	JOIN "user" u ON u.id = c.creator_id
+	LEFT JOIN engagement e ON e.content_id = c.id
+	WHERE c.status = ? AND c.created_at >= ? AND c.created_at < ?
+	ORDER BY ` + order + `
+	LIMIT ?`
+
+	var contents []models.SimpleContentRank
+	err := r.db.Raw(query,
+		from, to,
+		models.TextStatusVisible, from, to,
+		models.ContentEventView, from, to,
+		weights.Like, weights.Comment, weights.View,
+		models.ContentStatusApproved, from, to,
+		limit,
+	).Scan(&contents).Error
+	for i := range contents {
+		contents[i].Rank = i + 1
+	}
+	return contents, err
+}
+
+// dailyActivity est une ligne de DailyActivity, le jour au format AAAA-MM-JJ.
+type dailyActivity struct {
+	Day              string
+	NewUsers         int64
+	NewCreators      int64
+	NewContents      int64
+	NewSubscriptions int64
+	Cancellations    int64
+	Payments         int64
+	RevenueCents     int64
+	Likes            int64
+	Comments         int64
+	Views            int64
+}
+
+// DailyActivity agrège l'activité de la plateforme par jour UTC entre from
+// et to, directement depuis les tables sources. Les jours sans activité
+// sont absents.
+func (r *AdminStatsRepository) DailyActivity(from, to time.Time) ([]models.PlatformDailyStat, error) {
+	day := func(col string) string { return periodSQL(r.db, col, IntervalDay) }
+	query := `
+	SELECT day, SUM(new_users) AS new_users, SUM(new_creators) AS new_creators, SUM(new_contents) AS new_contents,
+	  SUM(new_subscriptions) AS new_subscriptions, SUM(cancellations) AS cancellations, SUM(payments) AS payments,
+	  SUM(revenue_cents) AS revenue_cents, SUM(likes) AS likes, SUM(comments) AS comments, SUM(views) AS views
+	FROM (
+	  SELECT ` + day("created_at") + ` AS day, 1 AS new_users, CASE WHEN role = ? THEN 1 ELSE 0 END AS new_creators,
+	    0 AS new_contents, 0 AS new_subscriptions, 0 AS cancellations, 0 AS payments, 0 AS revenue_cents,
+	    0 AS likes, 0 AS comments, 0 AS views
+	    FROM "user" WHERE created_at >= ? AND created_at < ?
+	  UNION ALL
+	  SELECT ` + day("created_at") + `, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0
+	    FROM content WHERE created_at >= ? AND created_at < ?
+	  UNION ALL
+	  SELECT ` + day("start_date") + `, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0
+	    FROM subscription WHERE start_date >= ? AND start_date < ?
+	  UNION ALL
+	  SELECT ` + day("canceled_at") + `, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0
+	    FROM subscription WHERE canceled_at >= ? AND canceled_at < ?
+	  UNION ALL
+	  SELECT ` + day("paid_at") + `, 0, 0, 0, 0, 0, 1, amount, 0, 0, 0
+	    FROM payment WHERE status = ? AND paid_at >= ? AND paid_at < ?
+	  UNION ALL
+	  SELECT ` + day("created_at") + `, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0
+	    FROM "like" WHERE created_at >= ? AND created_at < ?
+	  UNION ALL
+	  SELECT ` + day("created_at") + `, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0
+	    FROM comment WHERE status = ? AND created_at >= ? AND created_at < ?
+	  UNION ALL
+	  SELECT ` + day("occurred_at") + `, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1
+	    FROM content_event WHERE type = ? AND occurred_at >= ? AND occurred_at < ?
+	) activity
+	GROUP BY day
+	ORDER BY day`
+
+	var rows []dailyActivity
+	err := r.db.Raw(query,
+		models.RoleCreator, from, to,
+		from, to,
+		from, to,
+		from, to,
+		models.StatusSucceeded, from, to,
+		from, to,
+		models.TextStatusVisible, from, to,
+		models.ContentEventView, from, to,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]models.PlatformDailyStat, 0, len(rows))
+	for _, row := range rows {
+		day, err := time.Parse(time.DateOnly, row.Day)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, models.PlatformDailyStat{
+			Day:              day,
+			NewUsers:         row.NewUsers,
+			NewCreators:      row.NewCreators,
+			NewContents:      row.NewContents,
+			NewSubscriptions: row.NewSubscriptions,
+			Cancellations:    row.Cancellations,
+			Payments:         row.Payments,
+			RevenueCents:     row.RevenueCents,
+			Likes:            row.Likes,
+			Comments:         row.Comments,
+			Views:            row.Views,
+		})
+	}
+	return stats, nil
+}
+
+// LatestDailyStat renvoie le dernier jour cumulé, nil s'il n'y en a aucun.
+func (r *AdminStatsRepository) LatestDailyStat() (*time.Time, error) {
+	var stats []models.PlatformDailyStat
+	if err := r.db.Order("day DESC").Limit(1).Find(&stats).Error; err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, nil
+	}
+	return &stats[0].Day, nil
+}
+
+// ReplaceDailyStats remplace les cumuls des jours from (inclus) à to (exclu).
+func (r *AdminStatsRepository) ReplaceDailyStats(from, to time.Time, stats []models.PlatformDailyStat) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("day >= ? AND day < ?", from, to).Delete(&models.PlatformDailyStat{}).Error; err != nil {
+			return err
+		}
+		if len(stats) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(stats, 500).Error
+	})
+}
+
+// DailyStats renvoie les cumuls entre from (inclus) et to (exclu).
+func (r *AdminStatsRepository) DailyStats(from, to time.Time) ([]models.PlatformDailyStat, error) {
+	var stats []models.PlatformDailyStat
+	err := r.db.Where("day >= ? AND day < ?", from, to).Order("day").Find(&stats).Error
+	return stats, err
+}
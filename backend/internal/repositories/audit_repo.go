@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// AuditRepository lit et complète le journal d'audit. Il n'expose ni mise à
+// jour ni suppression.
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository instancie un AuditRepository.
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{db: database.DB}
+}
+
+// AuditFilter restreint la consultation du journal ; les champs vides sont ignorés.
+type AuditFilter struct {
+	ActorID    *uuid.UUID
+	Action     string
+	TargetType string
+	TargetID   string
+	From       *time.Time
+	To         *time.Time
+}
+
+// auditLockKey identifie le verrou consultatif PostgreSQL qui sérialise les
+// ajouts au journal entre toutes les instances de l'API.
+const auditLockKey = 0x41756469
+
+// AppendNext ajoute au journal l'entrée construite par build à partir de la
+// dernière entrée (nil si le journal est vide). La lecture et l'écriture se
+// font sous un verrou de base de données, tenu jusqu'à la fin de la
+// transaction : deux ajouts concurrents ne peuvent pas chaîner la même entrée.
+func (r *AuditRepository) AppendNext(build func(last *models.AuditEntry) *models.AuditEntry) (*models.AuditEntry, error) {
+	var entry *models.AuditEntry
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditLockKey).Error; err != nil {
+				return err
+			}
+		}
+		var last models.AuditEntry
+		err := tx.Order("seq DESC").First(&last).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			entry = build(nil)
+		case err != nil:
+			return err
+		default:
+			entry = build(&last)
+		}
+		return tx.Create(entry).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Find renvoie les entrées filtrées, les plus récentes d'abord, et leur nombre total.
+func (r *AuditRepository) Find(f AuditFilter, limit, offset int) ([]models.AuditEntry, int64, error) {
+	q := r.db.Model(&models.AuditEntry{})
+	if f.ActorID != nil {
+		q = q.Where("actor_id = ?", *f.ActorID)
+	}
+	if f.Action != "" {
+		q = q.Where("action = ?", f.Action)
+	}
+	if f.TargetType != "" {
+		q = q.Where("target_type = ?", f.TargetType)
+	}
+	if f.TargetID != "" {
+		q = q.Where("target_id = ?", f.TargetID)
+	}
+	if f.From != nil {
+		q = q.Where("created_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		q = q.Where("created_at < ?", *f.To)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []models.AuditEntry
+	err := q.Order("seq DESC").Limit(limit).Offset(offset).Find(&list).Error
+	return list, total, err
+}
+
+// FindAfter renvoie au plus limit entrées de numéro supérieur à seq, dans
+// l'ordre de la chaîne.
+func (r *AuditRepository) FindAfter(seq int64, limit int) ([]models.AuditEntry, error) {
+	var list []models.AuditEntry
+	err := r.db.Where("seq > ?", seq).Order("seq ASC").Limit(limit).Find(&list).Error
+	return list, err
+}
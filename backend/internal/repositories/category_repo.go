@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// CategoryRepository gère la taxonomie des catégories.
+type CategoryRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepository instancie un CategoryRepository.
+func NewCategoryRepository() *CategoryRepository {
+	return &CategoryRepository{db: database.DB}
+}
+
+// FindAll renvoie les catégories dans l'ordre d'affichage.
+func (r *CategoryRepository) FindAll() ([]models.Category, error) {
+	var list []models.Category
+	err := r.db.Order("position, name").Find(&list).Error
+	return list, err
+}
+
+// FindBySlug renvoie nil,nil si la catégorie n'existe pas.
+func (r *CategoryRepository) FindBySlug(slug string) (*models.Category, error) {
+	var cat models.Category
+	err := r.db.Where("slug = ?", slug).First(&cat).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+// FindByID renvoie nil,nil si la catégorie n'existe pas.
+func (r *CategoryRepository) FindByID(id uuid.UUID) (*models.Category, error) {
+	var cat models.Category
+	err := r.db.First(&cat, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+func (r *CategoryRepository) Create(cat *models.Category) error {
+	return r.db.Create(cat).Error
+}
+
+func (r *CategoryRepository) Update(cat *models.Category) error {
+	return r.db.Save(cat).Error
+}
+
+// Delete supprime une catégorie et la retire des contenus qui l'utilisaient.
+func (r *CategoryRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Content{}).
+			Where("category_id = ?", id).
+			Update("category_id", nil).Error; err != nil {
+			return err
+		}
+		res := tx.Delete(&models.Category{}, "id = ?", id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// FindPublishedByCategory renvoie les contenus publiés d'une catégorie.
+func (r *CategoryRepository) FindPublishedByCategory(categoryID uuid.UUID, limit, offset int, includeMature bool) ([]models.Content, error) {
+	var list []models.Content
+	err := r.db.
+		Preload("Creator").
+		Scopes(MaturityScope(includeMature)).
+		Where("category_id = ? AND status = ?", categoryID, models.ContentStatusApproved).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&list).Error
+	return list, err
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// CollectionSummary résume une collection pour un profil public.
+type CollectionSummary struct {
+	ID           uuid.UUID `json:"id"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	ContentCount int64     `json:"content_count"`
+}
+
+// CollectionRepository gère les collections de contenus des créateurs.
+type CollectionRepository struct {
+	db *gorm.DB
+}
+
+// NewCollectionRepository instancie un CollectionRepository.
+func NewCollectionRepository() *CollectionRepository {
+	return &CollectionRepository{db: database.DB}
+}
+
+func (r *CollectionRepository) Create(col *models.Collection) error {
+	return r.db.Create(col).Error
+}
+
+func (r *CollectionRepository) Update(col *models.Collection) error {
+	return r.db.Save(col).Error
+}
+
+// FindByID renvoie nil,nil si la collection n'existe pas.
+func (r *CollectionRepository) FindByID(id uuid.UUID) (*models.Collection, error) {
+	var col models.Collection
+	err := r.db.First(&col, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &col, nil
+}
+
+// Delete supprime une collection et ses éléments (les contenus sont conservés).
+func (r *CollectionRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", id).Delete(&models.CollectionItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Collection{}, "id = ?", id).Error
+	})
+}
+
+// SetItems remplace le contenu de la collection, dans l'ordre donné.
+func (r *CollectionRepository) SetItems(collectionID uuid.UUID, contentIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", collectionID).Delete(&models.CollectionItem{}).Error; err != nil {
+			return err
+		}
+		for i, id := range contentIDs {
+			item := models.CollectionItem{CollectionID: collectionID, ContentID: id, Position: i + 1}
+			if err := tx.Create(&item).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.Collection{}).Where("id = ?", collectionID).Update("updated_at", gorm.Expr("CURRENT_TIMESTAMP")).Error
+	})
+}
+
+// FindContents renvoie les contenus d'une collection dans l'ordre ; si
+// publishedOnly, seuls les contenus publiés sont renvoyés, et les contenus
+// adultes sont exclus sans includeMature.
+func (r *CollectionRepository) FindContents(collectionID uuid.UUID, publishedOnly, includeMature bool) ([]models.Content, error) {
+	var list []models.Content
+	q := r.db.
+		Preload("Creator").
+		Scopes(MaturityScope(includeMature || !publishedOnly)).
+		Joins("JOIN collection_item ON collection_item.content_id = content.id").
+		Where("collection_item.collection_id = ?", collectionID)
+	if publishedOnly {
+		q = q.Where("content.status = ?", models.ContentStatusApproved)
+	}
+	err := q.Order("collection_item.position").Find(&list).Error
+	return list, err
+}
+
+// SummariesByCreator liste les collections d'un créateur avec le nombre de
+// contenus publiés de chacune.
+func (r *CollectionRepository) SummariesByCreator(creatorID uuid.UUID) ([]CollectionSummary, error) {
+	var out []CollectionSummary
+	err := r.db.Table("collection").
+		Select("collection.id, collection.title, collection.description, COUNT(content.id) AS content_count").
+		Joins("LEFT JOIN collection_item ON collection_item.collection_id = collection.id").
+		Joins("LEFT JOIN content ON content.id = collection_item.content_id AND content.status = ?", models.ContentStatusApproved).
+		Where("collection.creator_id = ?", creatorID).
+		Group("collection.id, collection.title, collection.description, collection.created_at").
+		Order("collection.created_at DESC").
+		Scan(&out).Error
+	return out, err
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"errors"
+
 	"github.com/google/uuid"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
@@ -15,11 +17,13 @@ func NewCommentRepository() *CommentRepository {
 	return &CommentRepository{db: database.DB}
 }
 
-// Get all comments for a given content, ordered by creation date asc
-func (r *CommentRepository) FindAllByContent(contentID uuid.UUID) ([]models.Comment, error) {
+// Get all comments for a given content, ordered by creation date asc.
+// Les commentaires retenus par la modération ne sont visibles que de leur auteur.
+func (r *CommentRepository) FindAllByContent(contentID, viewerID uuid.UUID) ([]models.Comment, error) {
 	var comments []models.Comment
 	if err := r.db.
 		Where("content_id = ?", contentID).
+		Where("status = ? OR (status = ? AND author_id = ?)", models.TextStatusVisible, models.TextStatusHeld, viewerID).
 		Order("created_at ASC").
 		Find(&comments).Error; err != nil {
 		return nil, err
@@ -32,6 +36,13 @@ func (r *CommentRepository) Create(c *models.Comment) error {
 	return r.db.Create(c).Error
 }
 
+// CreateStep prépare la création d'un commentaire dans une transaction.
+func (r *CommentRepository) CreateStep(c *models.Comment) TxStep {
+	return func(tx *gorm.DB) error {
+		return tx.Create(c).Error
+	}
+}
+
 // ListAll récupère tous les commentaires, triés par date décroissante, avec pagination.
 func (r *CommentRepository) ListAll(offset, limit int) ([]models.Comment, error) {
 	var comments []models.Comment
@@ -48,6 +59,11 @@ func (r *CommentRepository) ListAll(offset, limit int) ([]models.Comment, error)
 	return comments, nil
 }
 
+// UpdateStatus change la visibilité d'un commentaire.
+func (r *CommentRepository) UpdateStatus(id uuid.UUID, status string) error {
+	return r.db.Model(&models.Comment{}).Where("id = ?", id).Update("status", status).Error
+}
+
 // DeleteByID supprime un commentaire selon son ID.
 func (r *CommentRepository) DeleteByID(id uuid.UUID) error {
 	return database.DB.
@@ -55,3 +71,23 @@ func (r *CommentRepository) DeleteByID(id uuid.UUID) error {
 		Delete(&models.Comment{}).
 		Error
 }
+
+// DeleteStep prépare la suppression d'un commentaire dans une transaction.
+func (r *CommentRepository) DeleteStep(id uuid.UUID) TxStep {
+	return func(tx *gorm.DB) error {
+		return tx.Where("id = ?", id).Delete(&models.Comment{}).Error
+	}
+}
+
+// FindByID renvoie nil,nil si le commentaire n'existe pas.
+func (r *CommentRepository) FindByID(id uuid.UUID) (*models.Comment, error) {
+	var comment models.Comment
+	err := r.db.First(&comment, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ContentEventRepository gère les événements bruts sur les contenus et leurs
+// cumuls quotidiens.
+type ContentEventRepository struct {
+	db *gorm.DB
+}
+
+func NewContentEventRepository() *ContentEventRepository {
+	return &ContentEventRepository{db: database.DB}
+}
+
+// Insert enregistre des événements ; ceux dont la clé de dédoublonnage
+// existe déjà sont ignorés.
+func (r *ContentEventRepository) Insert(events []models.ContentEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(events, 200).Error
+}
+
+// LastViewedContent renvoie le dernier contenu du créateur vu par
+// l'utilisateur depuis since, nil s'il n'y en a pas.
+func (r *ContentEventRepository) LastViewedContent(userID, creatorID uuid.UUID, since time.Time) (*uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Table("content_event e").
+		Joins("JOIN content c ON c.id = e.content_id").
+		Where("e.user_id = ? AND c.creator_id = ? AND e.type = ? AND e.occurred_at >= ?",
+			userID, creatorID, models.ContentEventView, since).
+		Order("e.occurred_at DESC").
+		Limit(1).
+		Pluck("e.content_id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	return &ids[0], nil
+}
+
+// OldestEventTime renvoie la date du plus ancien événement, nil s'il n'y en
+// a aucun.
+func (r *ContentEventRepository) OldestEventTime() (*time.Time, error) {
+	var events []models.ContentEvent
+	if err := r.db.Order("occurred_at").Limit(1).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	return &events[0].OccurredAt, nil
+}
+
+// EachEventSince parcourt les événements survenus depuis since.
+func (r *ContentEventRepository) EachEventSince(since time.Time, fn func(models.ContentEvent)) error {
+	rows, err := r.db.Model(&models.ContentEvent{}).Where("occurred_at >= ?", since).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ev models.ContentEvent
+		if err := r.db.ScanRows(rows, &ev); err != nil {
+			return err
+		}
+		fn(ev)
+	}
+	return rows.Err()
+}
+
+// LatestStatDay renvoie le dernier jour cumulé, nil s'il n'y en a aucun.
+func (r *ContentEventRepository) LatestStatDay() (*time.Time, error) {
+	var stats []models.ContentDailyStat
+	if err := r.db.Order("day DESC").Limit(1).Find(&stats).Error; err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, nil
+	}
+	return &stats[0].Day, nil
+}
+
+// ReplaceDailyStats remplace les cumuls à partir du jour from.
+func (r *ContentEventRepository) ReplaceDailyStats(from time.Time, stats []models.ContentDailyStat) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("day >= ?", from).Delete(&models.ContentDailyStat{}).Error; err != nil {
+			return err
+		}
+		if len(stats) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(stats, 500).Error
+	})
+}
+
+// PurgeEvents supprime les événements antérieurs à before.
+func (r *ContentEventRepository) PurgeEvents(before time.Time) error {
+	return r.db.Where("occurred_at < ?", before).Delete(&models.ContentEvent{}).Error
+}
+
+// DailyStats renvoie les cumuls d'un contenu entre from (inclus) et to (exclu).
+func (r *ContentEventRepository) DailyStats(contentID uuid.UUID, from, to time.Time) ([]models.ContentDailyStat, error) {
+	var stats []models.ContentDailyStat
+	err := r.db.Where("content_id = ? AND day >= ? AND day < ?", contentID, from, to).
+		Order("day").Find(&stats).Error
+	return stats, err
+}
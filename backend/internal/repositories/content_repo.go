@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
@@ -31,6 +32,62 @@ func (r *ContentRepository) FindAll() ([]models.Content, error) {
 	return list, nil
 }
 
+// MaturityScope exclut les contenus adultes lorsque le lecteur n'y a pas accès.
+func MaturityScope(includeMature bool) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if includeMature {
+			return db
+		}
+		return db.Where("maturity = ?", models.MaturityGeneral)
+	}
+}
+
+// FindPublished renvoie les contenus publiés (approuvés), du plus récent au plus ancien.
+func (r *ContentRepository) FindPublished(includeMature bool) ([]models.Content, error) {
+	var list []models.Content
+	err := r.db.
+		Preload("Creator").
+		Scopes(MaturityScope(includeMature)).
+		Where("status = ?", models.ContentStatusApproved).
+		Order("created_at DESC").
+		Find(&list).Error
+	return list, err
+}
+
+// Approve valide un contenu en attente : il est publié immédiatement, ou
+// programmé si sa date de publication est dans le futur.
+func (r *ContentRepository) Approve(id uuid.UUID, now time.Time) error {
+	var content models.Content
+	if err := r.db.First(&content, "id = ?", id).Error; err != nil {
+		return err
+	}
+	return r.db.Model(&models.Content{}).Where("id = ?", id).Updates(ApprovalUpdates(&content, now)).Error
+}
+
+// ApprovalUpdates renvoie les colonnes à modifier pour approuver un contenu.
+func ApprovalUpdates(content *models.Content, now time.Time) map[string]interface{} {
+	updates := map[string]interface{}{
+		"status":       models.ContentStatusApproved,
+		"published_at": now,
+	}
+	if content.PublishAt != nil && content.PublishAt.After(now) {
+		updates["status"] = models.ContentStatusScheduled
+		updates["published_at"] = nil
+	}
+	return updates
+}
+
+// PublishDue publie les contenus programmés dont la date est atteinte.
+func (r *ContentRepository) PublishDue(now time.Time) (int64, error) {
+	res := r.db.Model(&models.Content{}).
+		Where("status = ? AND publish_at <= ?", models.ContentStatusScheduled, now).
+		Updates(map[string]interface{}{
+			"status":       models.ContentStatusApproved,
+			"published_at": gorm.Expr("publish_at"),
+		})
+	return res.RowsAffected, res.Error
+}
+
 func (r *ContentRepository) Delete(id uuid.UUID, uploadPath string) error {
 	var content models.Content
 	if err := r.db.First(&content, "id = ?", id).Error; err != nil {
@@ -59,7 +116,8 @@ func (r *ContentRepository) IsUserSubscribedToCreator(userID, creatorID uuid.UUI
 	var count int64
 	err := r.db.
 		Model(&models.Subscription{}).
-		Where("subscriber_id = ? AND creator_id = ? AND end_date > now()", userID, creatorID).
+		Where("subscriber_id = ? AND creator_id = ? AND status = ? AND end_date > ?",
+			userID, creatorID, models.SubscriptionStatusActive, time.Now()).
 		Count(&count).Error
 	return count > 0, err
 }
@@ -84,17 +142,14 @@ func (r *ContentRepository) GetContentsByUser(userID uuid.UUID) ([]*models.Conte
 	return contents, nil
 }
 
-func (r *ContentRepository) FindAllWithCreators() ([]models.Content, error) {
-	var contents []models.Content
+// GetContentsByUserAndStatus filtre les contenus d'un créateur par statut.
+func (r *ContentRepository) GetContentsByUserAndStatus(userID uuid.UUID, status string) ([]*models.Content, error) {
+	var contents []*models.Content
 	err := r.db.
-		Preload("Creator").
+		Where("creator_id = ? AND status = ?", userID, status).
 		Order("created_at DESC").
-		Find(&contents).
-		Error
-	if err != nil {
-		return nil, err
-	}
-	return contents, nil
+		Find(&contents).Error
+	return contents, err
 }
 
 // CreateLike ajoute un like pour un utilisateur sur un contenu
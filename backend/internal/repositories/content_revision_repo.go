@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// ContentRevisionRepository gère l'historique des modifications des contenus.
+type ContentRevisionRepository struct {
+	db *gorm.DB
+}
+
+// NewContentRevisionRepository instancie un ContentRevisionRepository.
+func NewContentRevisionRepository() *ContentRevisionRepository {
+	return &ContentRevisionRepository{db: database.DB}
+}
+
+// SaveWithRevision met à jour le contenu et ajoute la révision dans une même
+// transaction ; le numéro de révision est attribué séquentiellement.
+func (r *ContentRevisionRepository) SaveWithRevision(content *models.Content, rev *models.ContentRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&models.ContentRevision{}).
+			Where("content_id = ?", content.ID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&last).Error; err != nil {
+			return err
+		}
+		rev.ContentID = content.ID
+		rev.Revision = last + 1
+		if err := tx.Save(content).Error; err != nil {
+			return err
+		}
+		return tx.Create(rev).Error
+	})
+}
+
+// FindByContent renvoie les révisions d'un contenu, de la plus récente à la plus ancienne.
+func (r *ContentRevisionRepository) FindByContent(contentID uuid.UUID) ([]models.ContentRevision, error) {
+	var revs []models.ContentRevision
+	err := r.db.
+		Where("content_id = ?", contentID).
+		Order("revision DESC").
+		Find(&revs).Error
+	return revs, err
+}
+
+// DeleteByContent supprime l'historique d'un contenu.
+func (r *ContentRevisionRepository) DeleteByContent(contentID uuid.UUID) error {
+	return r.db.Where("content_id = ?", contentID).Delete(&models.ContentRevision{}).Error
+}
@@ -0,0 +1,243 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Granularités des séries temporelles.
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// SubscriberPeriod agrège les mouvements d'abonnés d'une période (jour,
+// semaine ISO ou mois UTC, au format AAAA-MM-JJ du premier jour).
+type SubscriberPeriod struct {
+	Period        string `json:"period"`
+	New           int64  `json:"new"`
+	Churned       int64  `json:"churned"`
+	MRRDeltaCents int64  `json:"-"`
+}
+
+// SubscriberSnapshot décrit les abonnés en cours à un instant donné.
+type SubscriberSnapshot struct {
+	Subscribers int64 `json:"subscribers"`
+	MRRCents    int64 `json:"mrr_cents"`
+}
+
+// CohortCell compte les abonnés d'une cohorte (mois du premier abonnement)
+// encore abonnés Offset mois plus tard. Les mois sont des indices
+// année*12 + mois-1.
+type CohortCell struct {
+	Cohort   int   `json:"cohort"`
+	Offset   int   `json:"offset"`
+	Retained int64 `json:"retained"`
+}
+
+// EngagedFan est un utilisateur classé par son activité sur les contenus
+// d'un créateur.
+type EngagedFan struct {
+	UserID          uuid.UUID `json:"user_id"`
+	Username        string    `json:"username"`
+	AvatarURL       string    `json:"avatar_url"`
+	Likes           int64     `json:"likes"`
+	Comments        int64     `json:"comments"`
+	Views           int64     `json:"views"`
+	Score           float64   `json:"score"`
+	IsSubscribed    bool      `json:"is_subscribed"`
+	TotalSpentCents int64     `json:"total_spent_cents"`
+}
+
+// EngagementWeights pondère likes, commentaires et vues dans les classements.
+type EngagementWeights struct {
+	Like, Comment, View float64
+}
+
+// CreatorAnalyticsRepository calcule les indicateurs d'un créateur
+// directement en SQL, sur PostgreSQL comme sur SQLite (tests).
+type CreatorAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewCreatorAnalyticsRepository() *CreatorAnalyticsRepository {
+	return &CreatorAnalyticsRepository{db: database.DB}
+}
+
+// chainedSubscriptions replace les abonnements d'un créateur dans leur suite par abonné :
+// prev_end est l'échéance du précédent, next_start le début du suivant.
+// Les abonnements suspendus restent comptés comme en cours.
+const chainedSubscriptions = `
+	SELECT s.subscriber_id, s.start_date, s.end_date, s.price,
+	  LAG(s.end_date) OVER (PARTITION BY s.subscriber_id ORDER BY s.start_date) AS prev_end,
+	  LEAD(s.start_date) OVER (PARTITION BY s.subscriber_id ORDER BY s.start_date) AS next_start
+	FROM subscription s
+	WHERE s.creator_id = ?`
+
+// SubscriberPeriods agrège par période les nouveaux abonnés, les
+// désabonnements et la variation du revenu mensuel récurrent entre from et
+// to. Un réabonnement dans le délai de grâce prolonge la relation : ce
+// n'est ni un nouvel abonné ni un départ. Seules les échéances passées
+// (avant now) comptent comme départs.
+func (r *CreatorAnalyticsRepository) SubscriberPeriods(creatorID uuid.UUID, from, to, now time.Time, interval string, grace time.Duration) ([]SubscriberPeriod, error) {
+	graceDays := grace.Hours() / 24
+	endLimit := to
+	if now.Before(endLimit) {
+		endLimit = now
+	}
+	query := `
+	WITH chain AS (` + chainedSubscriptions + `)
+	SELECT period, SUM(is_new) AS new, SUM(churned) AS churned, SUM(delta) AS mrr_delta_cents
+	FROM (
+	  SELECT ` + periodSQL(r.db, "start_date", interval) + ` AS period,
+	    CASE WHEN prev_end IS NULL OR ` + daysSQL(r.db, "start_date", "prev_end") + ` > ? THEN 1 ELSE 0 END AS is_new,
+	    0 AS churned, price AS delta
+	  FROM chain WHERE start_date >= ? AND start_date < ?
+	  UNION ALL
+	  SELECT ` + periodSQL(r.db, "end_date", interval) + `,
+	    0, CASE WHEN next_start IS NULL OR ` + daysSQL(r.db, "next_start", "end_date") + ` > ? THEN 1 ELSE 0 END,
+	    -price
+	  FROM chain WHERE end_date >= ? AND end_date < ?
+	) moves
+	GROUP BY period
+	ORDER BY period`
+
+	var periods []SubscriberPeriod
+	err := r.db.Raw(query, creatorID, graceDays, from, to, graceDays, from, endLimit).Scan(&periods).Error
+	return periods, err
+}
+
+// SnapshotAt compte les abonnés en cours à l'instant at et le revenu
+// mensuel récurrent correspondant (abonnements de 30 jours).
+func (r *CreatorAnalyticsRepository) SnapshotAt(creatorID uuid.UUID, at time.Time) (SubscriberSnapshot, error) {
+	var snap SubscriberSnapshot
+	err := r.db.Model(&models.Subscription{}).
+		Select("COUNT(DISTINCT subscriber_id) AS subscribers, COALESCE(SUM(price), 0) AS mrr_cents").
+		Where("creator_id = ? AND start_date < ? AND end_date >= ?", creatorID, at, at).
+		Scan(&snap).Error
+	return snap, err
+}
+
+// AverageLifetimeDays renvoie la durée moyenne, en jours, des relations
+// d'abonnement du créateur (suites d'abonnements séparés de moins que le
+// délai de grâce), celles en cours étant arrêtées à now, ainsi que leur nombre.
+func (r *CreatorAnalyticsRepository) AverageLifetimeDays(creatorID uuid.UUID, now time.Time, grace time.Duration) (float64, int64, error) {
+	query := `
+	WITH chain AS (` + chainedSubscriptions + ` AND s.start_date < ?),
+	flagged AS (
+	  SELECT subscriber_id, start_date, end_date,
+	    CASE WHEN prev_end IS NULL OR ` + daysSQL(r.db, "start_date", "prev_end") + ` > ? THEN 1 ELSE 0 END AS starts_streak
+	  FROM chain),
+	streaks AS (
+	  SELECT subscriber_id, start_date, end_date,
+	    SUM(starts_streak) OVER (PARTITION BY subscriber_id ORDER BY start_date ROWS UNBOUNDED PRECEDING) AS streak
+	  FROM flagged)
+	SELECT COALESCE(AVG(` + daysSQL(r.db, leastSQL(r.db, "last_end", "?"), "first_start") + `), 0) AS average, COUNT(*) AS streaks
+	FROM (SELECT MIN(start_date) AS first_start, MAX(end_date) AS last_end
+	      FROM streaks GROUP BY subscriber_id, streak) relations`
+
+	var row struct {
+		Average float64
+		Streaks int64
+	}
+	err := r.db.Raw(query, creatorID, now, grace.Hours()/24, now).Scan(&row).Error
+	return row.Average, row.Streaks, err
+}
+
+// RetentionCohorts regroupe les abonnés par mois de premier abonnement
+// (indices fromMonth à toMonth) et compte, pour chaque mois écoulé depuis
+// (jusqu'à nowMonth), ceux qui ont été abonnés au moins une partie du mois.
+func (r *CreatorAnalyticsRepository) RetentionCohorts(creatorID uuid.UUID, fromMonth, toMonth, nowMonth int) ([]CohortCell, error) {
+	query := `
+	WITH RECURSIVE offsets(n) AS (
+	  SELECT 0 UNION ALL SELECT n + 1 FROM offsets WHERE n < ?),
+	spans AS (
+	  SELECT subscriber_id, ` + monthSQL(r.db, "start_date") + ` AS first_month, ` + monthSQL(r.db, "end_date") + ` AS last_month
+	  FROM subscription WHERE creator_id = ?),
+	cohorts AS (
+	  SELECT subscriber_id, MIN(first_month) AS cohort FROM spans GROUP BY subscriber_id)
+	SELECT c.cohort, o.n AS "offset", COUNT(DISTINCT c.subscriber_id) AS retained
+	FROM cohorts c
+	CROSS JOIN offsets o
+	JOIN spans s ON s.subscriber_id = c.subscriber_id
+	  AND s.first_month <= c.cohort + o.n AND s.last_month >= c.cohort + o.n
+	WHERE c.cohort >= ? AND c.cohort <= ? AND c.cohort + o.n <= ?
+	GROUP BY c.cohort, o.n
+	ORDER BY c.cohort, o.n`
+
+	var cells []CohortCell
+	err := r.db.Raw(query, nowMonth-fromMonth, creatorID, fromMonth, toMonth, nowMonth).Scan(&cells).Error
+	return cells, err
+}
+
+// TopFans classe les utilisateurs les plus actifs sur les contenus du
+// créateur entre from et to : likes, commentaires visibles et vues
+// pondérés, avec leur dépense totale en abonnements chez lui.
+func (r *CreatorAnalyticsRepository) TopFans(creatorID uuid.UUID, from, to, now time.Time, weights EngagementWeights, limit int) ([]EngagedFan, error) {
+	query := `
+	WITH activity AS (
+	  SELECT l.user_id, 1 AS likes, 0 AS comments, 0 AS views
+	    FROM "like" l JOIN content c ON c.id = l.content_id
+	    WHERE c.creator_id = ? AND l.created_at >= ? AND l.created_at < ?
+	  UNION ALL
+	  SELECT cm.author_id, 0, 1, 0
+	    FROM comment cm JOIN content c ON c.id = cm.content_id
+	    WHERE c.creator_id = ? AND cm.status = ? AND cm.created_at >= ? AND cm.created_at < ?
+	  UNION ALL
+	  SELECT e.user_id, 0, 0, 1
+	    FROM content_event e JOIN content c ON c.id = e.content_id
+	    WHERE c.creator_id = ? AND e.type = ? AND e.user_id IS NOT NULL AND e.occurred_at >= ? AND e.occurred_at < ?),
+	totals AS (
+	  SELECT user_id, SUM(likes) AS likes, SUM(comments) AS comments, SUM(views) AS views
+	  FROM activity WHERE user_id <> ? GROUP BY user_id)
+	SELECT u.id AS user_id, u.username, COALESCE(u.avatar_url, '') AS avatar_url,
+	  t.likes, t.comments, t.views,
+	  t.likes * ? + t.comments * ? + t.views * ? AS score,
+	  EXISTS (SELECT 1 FROM subscription s
+	          WHERE s.creator_id = ? AND s.subscriber_id = u.id AND s.status = ? AND s.end_date > ?) AS is_subscribed,
+	  (SELECT COALESCE(SUM(p.amount), 0) FROM payment p JOIN subscription s ON s.id = p.subscription_id
+	    WHERE s.creator_id = ? AND s.subscriber_id = u.id AND p.status = ?) AS total_spent_cents
+	FROM totals t JOIN "user" u ON u.id = t.user_id
+	ORDER BY score DESC, u.username
+	LIMIT ?`
+
+	var fans []EngagedFan
+	err := r.db.Raw(query,
+		creatorID, from, to,
+		creatorID, models.TextStatusVisible, from, to,
+		creatorID, models.ContentEventView, from, to,
+		creatorID,
+		weights.Like, weights.Comment, weights.View,
+		creatorID, models.SubscriptionStatusActive, now,
+		creatorID, models.StatusSucceeded,
+		limit,
+	).Scan(&fans).Error
+	return fans, err
+}
+
+// RevenueCents additionne les paiements réussis des abonnements du créateur
+// entre from et to.
+func (r *CreatorAnalyticsRepository) RevenueCents(creatorID uuid.UUID, from, to time.Time) (int64, error) {
+	var total int64
+	err := r.db.Table("payment p").
+		Joins("JOIN subscription s ON s.id = p.subscription_id").
+		Where("s.creator_id = ? AND p.status = ? AND p.paid_at >= ? AND p.paid_at < ?",
+			creatorID, models.StatusSucceeded, from, to).
+		Select("COALESCE(SUM(p.amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// ValidInterval indique si interval est une granularité connue.
+func ValidInterval(interval string) bool {
+	switch interval {
+	case IntervalDay, IntervalWeek, IntervalMonth:
+		return true
+	}
+	return false
+}
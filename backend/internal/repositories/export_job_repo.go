@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// ExportJobRepository gère la persistance des exports différés.
+type ExportJobRepository struct {
+	db *gorm.DB
+}
+
+// NewExportJobRepository instancie un ExportJobRepository.
+func NewExportJobRepository() *ExportJobRepository {
+	return &ExportJobRepository{db: database.DB}
+}
+
+// Create enregistre un nouvel export.
+func (r *ExportJobRepository) Create(job *models.ExportJob) error {
+	return r.db.Create(job).Error
+}
+
+// FindByID renvoie nil,nil si l'export n'existe pas.
+func (r *ExportJobRepository) FindByID(id uuid.UUID) (*models.ExportJob, error) {
+	var job models.ExportJob
+	err := r.db.First(&job, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Update sauvegarde l'export.
+func (r *ExportJobRepository) Update(job *models.ExportJob) error {
+	return r.db.Save(job).Error
+}
+
+// Delete supprime un export.
+func (r *ExportJobRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.ExportJob{}, "id = ?", id).Error
+}
+
+// FindByOwner renvoie les exports d'un utilisateur, les plus récents d'abord.
+func (r *ExportJobRepository) FindByOwner(ownerID uuid.UUID, limit int) ([]models.ExportJob, error) {
+	var jobs []models.ExportJob
+	err := r.db.Where("owner_id = ?", ownerID).Order("created_at DESC").Limit(limit).Find(&jobs).Error
+	return jobs, err
+}
+
+// FindExpired renvoie les exports dont la date d'expiration est passée.
+func (r *ExportJobRepository) FindExpired(now time.Time) ([]models.ExportJob, error) {
+	var jobs []models.ExportJob
+	err := r.db.Where("expires_at < ?", now).Find(&jobs).Error
+	return jobs, err
+}
+
+var unfinishedExportStatuses = []string{models.ExportStatusPending, models.ExportStatusRunning}
+
+// FailUnfinished marque en échec les exports interrompus de l'instance
+// (redémarrage du serveur pendant la génération).
+func (r *ExportJobRepository) FailUnfinished(instanceID, reason string) (int64, error) {
+	res := r.db.Model(&models.ExportJob{}).
+		Where("instance_id = ? AND status IN ?", instanceID, unfinishedExportStatuses).
+		Updates(map[string]interface{}{"status": models.ExportStatusFailed, "error": reason})
+	return res.RowsAffected, res.Error
+}
+
+// Heartbeat renouvelle le bail des exports non terminés de l'instance.
+func (r *ExportJobRepository) Heartbeat(instanceID string, now time.Time) error {
+	return r.db.Model(&models.ExportJob{}).
+		Where("instance_id = ? AND status IN ?", instanceID, unfinishedExportStatuses).
+		Update("heartbeat_at", now).Error
+}
+
+// FailStale marque en échec les exports non terminés dont le bail n'a pas été
+// renouvelé depuis before : l'instance qui les générait a disparu. Les exports
+// antérieurs aux baux n'en ont pas et sont traités de même.
+func (r *ExportJobRepository) FailStale(before time.Time, reason string) (int64, error) {
+	res := r.db.Model(&models.ExportJob{}).
+		Where("(heartbeat_at < ? OR heartbeat_at IS NULL) AND status IN ?", before, unfinishedExportStatuses).
+		Updates(map[string]interface{}{"status": models.ExportStatusFailed, "error": reason})
+	return res.RowsAffected, res.Error
+}
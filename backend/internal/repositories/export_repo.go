@@ -0,0 +1,259 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// ExportFilter restreint un export ; les champs vides sont ignorés. La
+// période [From, To) porte sur la date propre à chaque jeu de données
+// (inscription, publication, signalement, paiement, début d'abonnement).
+type ExportFilter struct {
+	From      *time.Time   `json:"from,omitempty"`
+	To        *time.Time   `json:"to,omitempty"`
+	Status    string       `json:"status,omitempty"`
+	Role      string       `json:"role,omitempty"`
+	CreatorID *uuid.UUID   `json:"creator_id,omitempty"`
+	Report    ReportFilter `json:"report"`
+}
+
+// Lignes des exports, dans l'ordre des colonnes.
+
+type ExportUser struct {
+	ID                    uuid.UUID
+	Username              string
+	Email                 string
+	Role                  string
+	AgeVerificationStatus string
+	CreatedAt             time.Time
+}
+
+type ExportContent struct {
+	ID          uuid.UUID
+	Title       string
+	Creator     string
+	Status      string
+	Maturity    string
+	Price       int
+	CreatedAt   time.Time
+	PublishedAt *time.Time
+}
+
+type ExportReport struct {
+	ID               uuid.UUID
+	TargetType       string
+	TargetID         uuid.UUID
+	ReasonCode       string
+	Status           string
+	Reporter         string
+	AssigneeID       *uuid.UUID
+	ResolutionAction string
+	CreatedAt        time.Time
+	ResolvedAt       *time.Time
+}
+
+type ExportPayment struct {
+	ID             uuid.UUID
+	PaidAt         time.Time
+	Status         string
+	Amount         int64
+	SubscriptionID uuid.UUID
+	Subscriber     string
+	Creator        string
+}
+
+type ExportSubscriber struct {
+	Subscriber string
+	StartDate  time.Time
+	EndDate    time.Time
+	Status     string
+	Price      int
+	CanceledAt *time.Time
+}
+
+type ExportCreatorEarning struct {
+	CreatorID    uuid.UUID
+	Username     string
+	Email        string
+	Payments     int64
+	Subscribers  int64
+	RevenueCents int64
+}
+
+// ExportRepository parcourt les jeux de données exportables ligne par ligne,
+// sans les charger en mémoire.
+type ExportRepository struct {
+	db *gorm.DB
+}
+
+func NewExportRepository() *ExportRepository {
+	return &ExportRepository{db: database.DB}
+}
+
+func between(q *gorm.DB, col string, f ExportFilter) *gorm.DB {
+	if f.From != nil {
+		q = q.Where(col+" >= ?", *f.From)
+	}
+	if f.To != nil {
+		q = q.Where(col+" < ?", *f.To)
+	}
+	return q
+}
+
+func (r *ExportRepository) count(q *gorm.DB) (int64, error) {
+	var n int64
+	err := r.db.Table("(?) AS export", q).Count(&n).Error
+	return n, err
+}
+
+// eachRow scanne chaque ligne de q dans dest puis appelle fn ; une erreur
+// de fn interrompt le parcours.
+func (r *ExportRepository) eachRow(q *gorm.DB, dest interface{}, fn func() error) error {
+	rows, err := q.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := r.db.ScanRows(rows, dest); err != nil {
+			return err
+		}
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *ExportRepository) users(f ExportFilter) *gorm.DB {
+	q := r.db.Model(&models.User{}).
+		Select("id, username, email, role, age_verification_status, created_at")
+	if f.Role != "" {
+		q = q.Where("role = ?", f.Role)
+	}
+	return between(q, "created_at", f).Order("created_at, id")
+}
+
+func (r *ExportRepository) CountUsers(f ExportFilter) (int64, error) {
+	return r.count(r.users(f))
+}
+
+func (r *ExportRepository) EachUser(f ExportFilter, fn func(ExportUser) error) error {
+	var row ExportUser
+	return r.eachRow(r.users(f), &row, func() error { return fn(row) })
+}
+
+func (r *ExportRepository) contents(f ExportFilter) *gorm.DB {
+	q := r.db.Table("content c").
+		Select("c.id, c.title, u.username AS creator, c.status, c.maturity, c.price, c.created_at, c.published_at").
+		Joins(`JOIN "user" u ON u.id = c.creator_id`)
+	if f.Status != "" {
+		q = q.Where("c.status = ?", f.Status)
+	}
+	if f.CreatorID != nil {
+		q = q.Where("c.creator_id = ?", *f.CreatorID)
+	}
+	return between(q, "c.created_at", f).Order("c.created_at, c.id")
+}
+
+func (r *ExportRepository) CountContents(f ExportFilter) (int64, error) {
+	return r.count(r.contents(f))
+}
+
+func (r *ExportRepository) EachContent(f ExportFilter, fn func(ExportContent) error) error {
+	var row ExportContent
+	return r.eachRow(r.contents(f), &row, func() error { return fn(row) })
+}
+
+// reports applique les mêmes filtres que la liste de modération.
+func (r *ExportRepository) reports(f ExportFilter) *gorm.DB {
+	filtered := between(applyReportFilter(r.db.Model(&models.Report{}), f.Report), "created_at", f)
+	return r.db.Table("(?) AS r", filtered).
+		Select("r.id, r.target_type, r.target_id, r.reason_code, r.status, u.username AS reporter, " +
+			"r.assignee_id, r.resolution_action, r.created_at, r.resolved_at").
+		Joins(`LEFT JOIN "user" u ON u.id = r.reporter_id`).
+		Order("r.created_at, r.id")
+}
+
+func (r *ExportRepository) CountReports(f ExportFilter) (int64, error) {
+	return r.count(r.reports(f))
+}
+
+func (r *ExportRepository) EachReport(f ExportFilter, fn func(ExportReport) error) error {
+	var row ExportReport
+	return r.eachRow(r.reports(f), &row, func() error { return fn(row) })
+}
+
+func (r *ExportRepository) payments(f ExportFilter) *gorm.DB {
+	q := r.db.Table("payment p").
+		Select("p.id, p.paid_at, p.status, p.amount, p.subscription_id, fan.username AS subscriber, cr.username AS creator").
+		Joins("JOIN subscription s ON s.id = p.subscription_id").
+		Joins(`LEFT JOIN "user" fan ON fan.id = s.subscriber_id`).
+		Joins(`LEFT JOIN "user" cr ON cr.id = s.creator_id`)
+	if f.Status != "" {
+		q = q.Where("p.status = ?", f.Status)
+	}
+	if f.CreatorID != nil {
+		q = q.Where("s.creator_id = ?", *f.CreatorID)
+	}
+	return between(q, "p.paid_at", f).Order("p.paid_at, p.id")
+}
+
+func (r *ExportRepository) CountPayments(f ExportFilter) (int64, error) {
+	return r.count(r.payments(f))
+}
+
+func (r *ExportRepository) EachPayment(f ExportFilter, fn func(ExportPayment) error) error {
+	var row ExportPayment
+	return r.eachRow(r.payments(f), &row, func() error { return fn(row) })
+}
+
+// subscribers liste les abonnements d'un créateur (f.CreatorID requis).
+func (r *ExportRepository) subscribers(f ExportFilter) *gorm.DB {
+	q := r.db.Table("subscription s").
+		Select("fan.username AS subscriber, s.start_date, s.end_date, s.status, s.price, s.canceled_at").
+		Joins(`JOIN "user" fan ON fan.id = s.subscriber_id`).
+		Where("s.creator_id = ?", f.CreatorID)
+	if f.Status != "" {
+		q = q.Where("s.status = ?", f.Status)
+	}
+	return between(q, "s.start_date", f).Order("s.start_date, s.id")
+}
+
+func (r *ExportRepository) CountSubscribers(f ExportFilter) (int64, error) {
+	return r.count(r.subscribers(f))
+}
+
+func (r *ExportRepository) EachSubscriber(f ExportFilter, fn func(ExportSubscriber) error) error {
+	var row ExportSubscriber
+	return r.eachRow(r.subscribers(f), &row, func() error { return fn(row) })
+}
+
+// creatorEarnings totalise par créateur les paiements réussis de la période,
+// créateurs sans revenu compris.
+func (r *ExportRepository) creatorEarnings(f ExportFilter) *gorm.DB {
+	paid := between(r.db.Table("payment p").
+		Select("s.creator_id, COUNT(*) AS payments, COUNT(DISTINCT s.subscriber_id) AS subscribers, SUM(p.amount) AS revenue_cents").
+		Joins("JOIN subscription s ON s.id = p.subscription_id").
+		Where("p.status = ?", models.StatusSucceeded), "p.paid_at", f).
+		Group("s.creator_id")
+	return r.db.Table(`"user" u`).
+		Select("u.id AS creator_id, u.username, u.email, COALESCE(e.payments, 0) AS payments, "+
+			"COALESCE(e.subscribers, 0) AS subscribers, COALESCE(e.revenue_cents, 0) AS revenue_cents").
+		Joins("LEFT JOIN (?) e ON e.creator_id = u.id", paid).
+		Where("u.role = ?", models.RoleCreator).
+		Order("revenue_cents DESC, u.username")
+}
+
+func (r *ExportRepository) CountCreatorEarnings(f ExportFilter) (int64, error) {
+	return r.count(r.creatorEarnings(f))
+}
+
+func (r *ExportRepository) EachCreatorEarning(f ExportFilter, fn func(ExportCreatorEarning) error) error {
+	var row ExportCreatorEarning
+	return r.eachRow(r.creatorEarnings(f), &row, func() error { return fn(row) })
+}
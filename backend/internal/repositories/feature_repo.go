@@ -28,6 +28,19 @@ func (r *FeatureRepository) List(ctx context.Context) ([]models.Feature, error)
 	return features, nil
 }
 
+// Find renvoie la feature de clé donnée, ou nil,nil si elle n'existe pas.
+func (r *FeatureRepository) Find(ctx context.Context, key string) (*models.Feature, error) {
+	var feature models.Feature
+	err := r.db.WithContext(ctx).Where("key = ?", key).First(&feature).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &feature, nil
+}
+
 // Update modifie l’état (enabled) d’une feature identifiée par sa clé.
 func (r *FeatureRepository) Update(ctx context.Context, key string, enabled bool) error {
 	res := r.db.WithContext(ctx).
@@ -0,0 +1,178 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+)
+
+// FeedItem est un contenu du fil d'actualité, avec les compteurs et l'état
+// propres au lecteur.
+type FeedItem struct {
+	ID           uuid.UUID `json:"id"`
+	Title        string    `json:"title"`
+	Body         string    `json:"body"`
+	Price        int       `json:"price"`
+	FilePath     string    `json:"file_path"`
+	CreatorID    uuid.UUID `json:"creator_id"`
+	CreatorName  string    `json:"creator_name"`
+	CreatedAt    time.Time `json:"created_at"`
+	Maturity     string    `json:"maturity"`
+	IsSubscribed bool      `json:"is_subscribed"`
+	LikesCount   int64     `json:"likes_count"`
+	LikedByUser  bool      `json:"liked_by_user"`
+}
+
+// FeedCursor repère le dernier contenu d'une page : la page suivante reprend
+// strictement après lui dans l'ordre (created_at, id) décroissant.
+type FeedCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// FeedQuery décrit une page du fil chronologique.
+type FeedQuery struct {
+	ViewerID      uuid.UUID
+	IncludeMature bool
+	// FollowingOnly restreint le fil aux créateurs auxquels le lecteur est abonné.
+	FollowingOnly bool
+	After         *FeedCursor
+	Limit         int
+	Now           time.Time
+}
+
+// FeedCandidate est un contenu candidat au fil classé, avec les signaux
+// utilisés par les scoreurs.
+type FeedCandidate struct {
+	FeedItem
+	CommentsCount int64 `json:"-"`
+	// RecentLikes compte les likes reçus depuis CandidateQuery.TrendingSince.
+	RecentLikes int64 `json:"-"`
+	// SharedTags compte les tags communs avec les contenus likés par le lecteur.
+	SharedTags int64 `json:"-"`
+	// CreatorAffinity compte les likes et commentaires du lecteur sur les
+	// contenus du même créateur.
+	CreatorAffinity int64 `json:"-"`
+}
+
+// CandidateQuery décrit la collecte des candidats du fil classé : contenus
+// récents des créateurs suivis, contenus en tendance, contenus aux tags
+// proches de ceux que le lecteur a aimés, et nouveautés.
+type CandidateQuery struct {
+	ViewerID      uuid.UUID
+	IncludeMature bool
+	// Now est l'heure de référence figée par le curseur : contenus, likes,
+	// commentaires et abonnements postérieurs sont ignorés, si bien que les
+	// signaux, donc le classement, sont les mêmes d'une page à l'autre.
+	Now           time.Time
+	Since         time.Time
+	TrendingSince time.Time
+	PerSource     int
+}
+
+// feedSelect calcule en une requête les compteurs et l'état du lecteur, à
+// l'heure de référence donnée : ce qui arrive après n'est pas compté.
+const feedSelect = `
+	c.id, c.title, c.body, c.price, c.file_path, c.creator_id, u.username AS creator_name,
+	c.created_at, c.maturity,
+	EXISTS (SELECT 1 FROM subscription s
+	        WHERE s.subscriber_id = ? AND s.creator_id = c.creator_id AND s.status = ?
+	          AND s.start_date <= ? AND s.end_date > ?) AS is_subscribed,
+	(SELECT COUNT(*) FROM "like" l WHERE l.content_id = c.id AND l.created_at <= ?) AS likes_count,
+	EXISTS (SELECT 1 FROM "like" lv WHERE lv.content_id = c.id AND lv.user_id = ? AND lv.created_at <= ?) AS liked_by_user`
+
+// candidateSignals complète feedSelect avec les signaux du classement,
+// bornés eux aussi par l'heure de référence.
+const candidateSignals = `,
+	(SELECT COUNT(*) FROM comment cm WHERE cm.content_id = c.id AND cm.status = ? AND cm.created_at <= ?) AS comments_count,
+	(SELECT COUNT(*) FROM "like" lr WHERE lr.content_id = c.id AND lr.created_at > ? AND lr.created_at <= ?) AS recent_likes,
+	(SELECT COUNT(DISTINCT ct.tag_id) FROM content_tag ct
+	  WHERE ct.content_id = c.id AND ct.tag_id IN (
+	    SELECT lt.tag_id FROM content_tag lt JOIN "like" ll ON ll.content_id = lt.content_id
+	    WHERE ll.user_id = ? AND ll.created_at <= ?)) AS shared_tags,
+	(SELECT COUNT(*) FROM "like" la JOIN content ca ON ca.id = la.content_id
+	  WHERE la.user_id = ? AND la.created_at <= ? AND ca.creator_id = c.creator_id)
+	+ (SELECT COUNT(*) FROM comment cc JOIN content cb ON cb.id = cc.content_id
+	  WHERE cc.author_id = ? AND cc.created_at <= ? AND cb.creator_id = c.creator_id) AS creator_affinity`
+
+// candidateIDs réunit les sources de candidats, chacune bornée en nombre et
+// par l'heure de référence.
+const candidateIDs = `
+	SELECT id FROM (
+	  SELECT c1.id FROM content c1
+	  JOIN subscription s1 ON s1.creator_id = c1.creator_id AND s1.subscriber_id = ? AND s1.status = ?
+	    AND s1.start_date <= ? AND s1.end_date > ?
+	  WHERE c1.status = ? AND c1.created_at > ? AND c1.created_at <= ?
+	  ORDER BY c1.created_at DESC LIMIT ?) followed
+	UNION
+	SELECT id FROM (
+	  SELECT l2.content_id AS id FROM "like" l2
+	  WHERE l2.created_at > ? AND l2.created_at <= ?
+	  GROUP BY l2.content_id ORDER BY COUNT(*) DESC LIMIT ?) trending
+	UNION
+	SELECT id FROM (
+	  SELECT ct3.content_id AS id FROM content_tag ct3
+	  WHERE ct3.tag_id IN (
+	    SELECT lt3.tag_id FROM content_tag lt3 JOIN "like" l3 ON l3.content_id = lt3.content_id
+	    WHERE l3.user_id = ? AND l3.created_at <= ?)
+	  GROUP BY ct3.content_id ORDER BY COUNT(*) DESC LIMIT ?) similar
+	UNION
+	SELECT id FROM (
+	  SELECT c4.id FROM content c4
+	  WHERE c4.status = ? AND c4.created_at > ? AND c4.created_at <= ?
+	  ORDER BY c4.created_at DESC LIMIT ?) fresh`
+
+func (r *ContentRepository) feedBase(includeMature bool) *gorm.DB {
+	q := r.db.Table("content AS c").
+		Joins(`JOIN "user" u ON u.id = c.creator_id`).
+		Where("c.status = ?", models.ContentStatusApproved)
+	if !includeMature {
+		q = q.Where("c.maturity = ?", models.MaturityGeneral)
+	}
+	return q
+}
+
+// FeedPage renvoie une page du fil (contenus approuvés, du plus récent au
+// plus ancien) en une seule requête, quelle que soit la taille de la page.
+func (r *ContentRepository) FeedPage(q FeedQuery) ([]FeedItem, error) {
+	db := r.feedBase(q.IncludeMature).
+		Select(feedSelect, q.ViewerID, models.SubscriptionStatusActive, q.Now, q.Now, q.Now, q.ViewerID, q.Now)
+	if q.FollowingOnly {
+		db = db.Where(`EXISTS (SELECT 1 FROM subscription sf
+		  WHERE sf.subscriber_id = ? AND sf.creator_id = c.creator_id AND sf.status = ? AND sf.start_date <= ? AND sf.end_date > ?)`,
+			q.ViewerID, models.SubscriptionStatusActive, q.Now, q.Now)
+	}
+	if q.After != nil {
+		db = db.Where("c.created_at < ? OR (c.created_at = ? AND c.id < ?)", q.After.CreatedAt, q.After.CreatedAt, q.After.ID)
+	}
+
+	var items []FeedItem
+	err := db.Order("c.created_at DESC").Order("c.id DESC").Limit(q.Limit).Find(&items).Error
+	return items, err
+}
+
+// FeedCandidates collecte en une seule requête les candidats du fil classé
+// et leurs signaux ; le classement lui-même est fait par l'appelant.
+func (r *ContentRepository) FeedCandidates(q CandidateQuery) ([]FeedCandidate, error) {
+	approved := models.ContentStatusApproved
+	active := models.SubscriptionStatusActive
+	ids := r.db.Raw(candidateIDs,
+		q.ViewerID, active, q.Now, q.Now, approved, q.Since, q.Now, q.PerSource,
+		q.TrendingSince, q.Now, q.PerSource,
+		q.ViewerID, q.Now, q.PerSource,
+		approved, q.Since, q.Now, q.PerSource,
+	)
+
+	var list []FeedCandidate
+	err := r.feedBase(q.IncludeMature).
+		Select(feedSelect+candidateSignals,
+			q.ViewerID, active, q.Now, q.Now, q.Now, q.ViewerID, q.Now,
+			models.TextStatusVisible, q.Now, q.TrendingSince, q.Now,
+			q.ViewerID, q.Now, q.ViewerID, q.Now, q.ViewerID, q.Now).
+		Where("c.id IN (?) AND c.created_at <= ?", ids, q.Now).
+		Find(&list).Error
+	return list, err
+}
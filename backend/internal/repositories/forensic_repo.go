@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// ForensicMarkRepository gère la persistance des jetons de watermark forensique.
+type ForensicMarkRepository struct {
+	db *gorm.DB
+}
+
+// NewForensicMarkRepository instancie un ForensicMarkRepository.
+func NewForensicMarkRepository() *ForensicMarkRepository {
+	return &ForensicMarkRepository{db: database.DB}
+}
+
+// Create enregistre un nouveau jeton.
+func (r *ForensicMarkRepository) Create(mark *models.ForensicMark) error {
+	return r.db.Create(mark).Error
+}
+
+// FindByToken renvoie nil,nil si aucun jeton ne correspond.
+func (r *ForensicMarkRepository) FindByToken(token int64) (*models.ForensicMark, error) {
+	var mark models.ForensicMark
+	err := r.db.Where("token = ?", token).First(&mark).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &mark, nil
+}
+
+// FindLatest renvoie le dernier jeton émis pour un abonné, un contenu et un type, ou nil,nil.
+func (r *ForensicMarkRepository) FindLatest(contentID, subscriberID uuid.UUID, kind string) (*models.ForensicMark, error) {
+	var mark models.ForensicMark
+	err := r.db.
+		Where("content_id = ? AND subscriber_id = ? AND kind = ?", contentID, subscriberID, kind).
+		Order("issued_at DESC").
+		First(&mark).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &mark, nil
+}
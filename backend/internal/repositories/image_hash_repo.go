@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ImageHashRepository stocke les hashs perceptuels des contenus et les
+// signalements de doublons.
+type ImageHashRepository struct {
+	db *gorm.DB
+}
+
+// NewImageHashRepository instancie un ImageHashRepository.
+func NewImageHashRepository() *ImageHashRepository {
+	return &ImageHashRepository{db: database.DB}
+}
+
+// hashBand renvoie l'octet n (0 = poids fort) d'un hash.
+func hashBand(hash uint64, n int) int {
+	return int(hash >> (8 * (models.ImageHashBands - 1 - n)) & 0xFF)
+}
+
+// SaveHash enregistre le hash d'un contenu et remplace son index par octets.
+func (r *ImageHashRepository) SaveHash(contentID uuid.UUID, hash uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Content{}).
+			Where("id = ?", contentID).
+			Update("phash", int64(hash)).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("content_id = ?", contentID).Delete(&models.ImageHashBand{}).Error; err != nil {
+			return err
+		}
+		bands := make([]models.ImageHashBand, models.ImageHashBands)
+		for i := range bands {
+			bands[i] = models.ImageHashBand{ContentID: contentID, Band: i, Value: hashBand(hash, i)}
+		}
+		return tx.Create(&bands).Error
+	})
+}
+
+// FindCandidates renvoie les contenus partageant au moins un octet de hash
+// avec hash (hors exclude). La distance exacte est calculée par l'appelant.
+func (r *ImageHashRepository) FindCandidates(hash uint64, exclude uuid.UUID) ([]models.Content, error) {
+	match := r.db.Where("band = ? AND value = ?", 0, hashBand(hash, 0))
+	for i := 1; i < models.ImageHashBands; i++ {
+		match = match.Or("band = ? AND value = ?", i, hashBand(hash, i))
+	}
+	ids := r.db.Model(&models.ImageHashBand{}).Select("content_id").Where(match)
+
+	var list []models.Content
+	err := r.db.
+		Preload("Creator").
+		Where("id IN (?) AND id <> ? AND phash IS NOT NULL", ids, exclude).
+		Find(&list).Error
+	return list, err
+}
+
+// FindUnhashed renvoie des contenus sans hash perceptuel (antérieurs à la
+// détection de doublons).
+func (r *ImageHashRepository) FindUnhashed(limit, offset int) ([]models.Content, error) {
+	var list []models.Content
+	err := r.db.
+		Where("phash IS NULL AND file_path <> ''").
+		Order("created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&list).Error
+	return list, err
+}
+
+// DeleteByContent supprime l'index et les signalements liés à un contenu.
+func (r *ImageHashRepository) DeleteByContent(contentID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("content_id = ?", contentID).Delete(&models.ImageHashBand{}).Error; err != nil {
+			return err
+		}
+		return tx.
+			Where("content_id = ? OR original_content_id = ?", contentID, contentID).
+			Delete(&models.DuplicateFlag{}).Error
+	})
+}
+
+// CreateFlag enregistre un signalement de doublon.
+func (r *ImageHashRepository) CreateFlag(flag *models.DuplicateFlag) error {
+	return r.db.Create(flag).Error
+}
+
+// FlagExists indique si le couple contenu/original est déjà signalé.
+func (r *ImageHashRepository) FlagExists(contentID, originalID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.DuplicateFlag{}).
+		Where("content_id = ? AND original_content_id = ?", contentID, originalID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindFlags liste les signalements d'un statut, les plus récents d'abord,
+// avec les deux contenus et leurs créateurs.
+func (r *ImageHashRepository) FindFlags(status string, limit, offset int) ([]models.DuplicateFlag, error) {
+	var flags []models.DuplicateFlag
+	err := r.db.
+		Preload("Content.Creator").
+		Preload("OriginalContent.Creator").
+		Where("status = ?", status).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&flags).Error
+	return flags, err
+}
+
+// OpenFlagsByContent regroupe les signalements ouverts par contenu suspect.
+func (r *ImageHashRepository) OpenFlagsByContent() (map[uuid.UUID][]models.DuplicateFlag, error) {
+	var flags []models.DuplicateFlag
+	if err := r.db.Where("status = ?", models.DuplicateFlagOpen).Order("distance").Find(&flags).Error; err != nil {
+		return nil, err
+	}
+	out := make(map[uuid.UUID][]models.DuplicateFlag)
+	for _, f := range flags {
+		out[f.ContentID] = append(out[f.ContentID], f)
+	}
+	return out, nil
+}
+
+// FindFlagByID renvoie nil,nil si le signalement n'existe pas.
+func (r *ImageHashRepository) FindFlagByID(id uuid.UUID) (*models.DuplicateFlag, error) {
+	var flag models.DuplicateFlag
+	err := r.db.First(&flag, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+// UpdateFlag enregistre la décision prise sur un signalement.
+func (r *ImageHashRepository) UpdateFlag(flag *models.DuplicateFlag) error {
+	return r.db.Omit(clause.Associations).Save(flag).Error
+}
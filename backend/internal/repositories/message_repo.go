@@ -25,11 +25,21 @@ func (r *MessageRepository) Create(message *models.Message) error {
 	return r.db.Create(message).Error
 }
 
+// CreateStep prépare l'envoi d'un message dans une transaction.
+func (r *MessageRepository) CreateStep(message *models.Message) TxStep {
+	return func(tx *gorm.DB) error {
+		return tx.Create(message).Error
+	}
+}
+
+// GetConversationBetween renvoie la conversation vue par userID1 : les
+// messages retenus par la modération ne sont visibles que de leur expéditeur.
 func (r *MessageRepository) GetConversationBetween(userID1, userID2 uuid.UUID) ([]models.Message, error) {
 	var messages []models.Message
 	err := r.db.
 		Where(`(sender_id = ? AND receiver_id = ?) OR (sender_id = ? AND receiver_id = ?)`,
 			userID1, userID2, userID2, userID1).
+		Where("status = ? OR (status = ? AND sender_id = ?)", models.TextStatusVisible, models.TextStatusHeld, userID1).
 		Order("sent_at ASC").
 		Find(&messages).
 		Error
@@ -62,6 +72,7 @@ func (r *MessageRepository) GetConversationPreviews(userID uuid.UUID) ([]models.
 			MAX(sent_at)                  AS last_sent_at
 		`, uid).
 		Where("sender_id::text = ? OR receiver_id::text = ?", uid, uid).
+		Where("status = ? OR (status = ? AND sender_id::text = ?)", models.TextStatusVisible, models.TextStatusHeld, uid).
 		Group("partner_id")
 
 	err := r.db.
@@ -82,12 +93,18 @@ func (r *MessageRepository) GetConversationPreviews(userID uuid.UUID) ([]models.
 			  )
 		`, sub, uid, uid).
 		Joins(`JOIN "user" AS u ON u.id::text = l.partner_id`).
+		Where("m.status = ? OR (m.status = ? AND m.sender_id::text = ?)", models.TextStatusVisible, models.TextStatusHeld, uid).
 		Order("m.sent_at DESC").
 		Scan(&previews).Error
 
 	return previews, err
 }
 
+// UpdateStatus change la visibilité d'un message.
+func (r *MessageRepository) UpdateStatus(id uuid.UUID, status string) error {
+	return r.db.Model(&models.Message{}).Where("id = ?", id).Update("status", status).Error
+}
+
 func (r *MessageRepository) MarkAsRead(messageID uuid.UUID) error {
 	return nil
 }
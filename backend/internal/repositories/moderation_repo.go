@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// ModerationRepository gère l'historique des décisions de modération.
+type ModerationRepository struct {
+	db *gorm.DB
+}
+
+// NewModerationRepository instancie un ModerationRepository.
+func NewModerationRepository() *ModerationRepository {
+	return &ModerationRepository{db: database.DB}
+}
+
+// Apply modifie le contenu et enregistre la décision dans une même
+// transaction : l'historique reflète toujours le statut réel. Les étapes
+// also (clôture des signalements liés…) sont validées avec la décision.
+func (r *ModerationRepository) Apply(decision *models.ModerationDecision, updates map[string]interface{}, also ...TxStep) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Content{}).
+			Where("id = ?", decision.ContentID).
+			Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit("Content").Create(decision).Error; err != nil {
+			return err
+		}
+		return runSteps(tx, also)
+	})
+}
+
+// FindByContent renvoie l'historique d'un contenu, du plus ancien au plus récent.
+func (r *ModerationRepository) FindByContent(contentID uuid.UUID) ([]models.ModerationDecision, error) {
+	var list []models.ModerationDecision
+	err := r.db.
+		Where("content_id = ?", contentID).
+		Order("created_at ASC").
+		Find(&list).Error
+	return list, err
+}
+
+// FindByCreator renvoie les décisions portant sur les contenus d'un
+// créateur, les plus récentes d'abord, avec le contenu concerné.
+func (r *ModerationRepository) FindByCreator(creatorID uuid.UUID, limit, offset int) ([]models.ModerationDecision, error) {
+	var list []models.ModerationDecision
+	ids := r.db.Model(&models.Content{}).Select("id").Where("creator_id = ?", creatorID)
+	err := r.db.
+		Preload("Content").
+		Where("content_id IN (?)", ids).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&list).Error
+	return list, err
+}
+
+// QueueDepths compte les éléments en attente d'un modérateur, par file.
+type QueueDepths struct {
+	Contents         int64
+	Texts            int64
+	Reports          int64
+	Duplicates       int64
+	AgeVerifications int64
+}
+
+// CountQueues renvoie la profondeur des files de modération en une requête.
+func (r *ModerationRepository) CountQueues() (QueueDepths, error) {
+	query := `
+	SELECT
+	  (SELECT COUNT(*) FROM content WHERE status = ?) AS contents,
+	  (SELECT COUNT(*) FROM text_review WHERE status = ?) AS texts,
+	  (SELECT COUNT(*) FROM report WHERE status IN ?) AS reports,
+	  (SELECT COUNT(*) FROM duplicate_flag WHERE status = ?) AS duplicates,
+	  (SELECT COUNT(*) FROM "user" WHERE age_verification_status = ?) AS age_verifications`
+
+	var depths QueueDepths
+	err := r.db.Raw(query,
+		models.ContentStatusPending,
+		models.TextReviewPending,
+		openReportStatuses,
+		models.DuplicateFlagOpen,
+		models.AgeVerificationPending,
+	).Scan(&depths).Error
+	return depths, err
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// NotificationRepository gère les notifications des utilisateurs.
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository instancie un NotificationRepository.
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{db: database.DB}
+}
+
+// Create enregistre une notification.
+func (r *NotificationRepository) Create(n *models.Notification) error {
+	return r.db.Create(n).Error
+}
+
+// FindByUser renvoie les notifications d'un utilisateur, les plus récentes d'abord.
+func (r *NotificationRepository) FindByUser(userID uuid.UUID, unreadOnly bool, limit, offset int) ([]models.Notification, error) {
+	var list []models.Notification
+	q := r.db.Where("user_id = ?", userID)
+	if unreadOnly {
+		q = q.Where("read_at IS NULL")
+	}
+	err := q.Order("created_at DESC").Limit(limit).Offset(offset).Find(&list).Error
+	return list, err
+}
+
+// CountUnread compte les notifications non lues d'un utilisateur.
+func (r *NotificationRepository) CountUnread(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead marque une notification de l'utilisateur comme lue ; renvoie
+// false si elle n'existe pas ou ne lui appartient pas.
+func (r *NotificationRepository) MarkRead(userID, id uuid.UUID, now time.Time) (bool, error) {
+	res := r.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", now)
+	return res.RowsAffected > 0, res.Error
+}
@@ -16,11 +16,12 @@ func NewPublicContentRepository() *PublicContentRepository {
 	return &PublicContentRepository{db: database.DB}
 }
 
-// FindPreviewByCreator renvoie un aperçu (limit) des derniers contenus d’un créateur.
-func (r *PublicContentRepository) FindPreviewByCreator(creatorID uuid.UUID, limit int) ([]models.Content, error) {
+// FindPreviewByCreator renvoie un aperçu (limit) des derniers contenus publiés d’un créateur.
+func (r *PublicContentRepository) FindPreviewByCreator(creatorID uuid.UUID, limit int, includeMature bool) ([]models.Content, error) {
 	var list []models.Content
 	err := r.db.
-		Where("creator_id = ?", creatorID).
+		Scopes(MaturityScope(includeMature)).
+		Where("creator_id = ? AND status = ?", creatorID, models.ContentStatusApproved).
 		Order("created_at DESC").
 		Limit(limit).
 		Find(&list).Error
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"errors"
+
 	"github.com/google/uuid"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
@@ -17,24 +19,137 @@ func NewReportRepository() *ReportRepository {
 	return &ReportRepository{db: database.DB}
 }
 
-// Create ajoute un nouveau report en base.
+// ReportFilter restreint la liste des signalements ; les champs vides sont ignorés.
+type ReportFilter struct {
+	Status     string
+	TargetType string
+	ReasonCode string
+	AssigneeID *uuid.UUID
+}
+
+// ReportGroup agrège les signalements d'une même cible.
+type ReportGroup struct {
+	TargetType  string
+	TargetID    uuid.UUID
+	ReportCount int64
+}
+
+var openReportStatuses = []string{models.ReportStatusOpen, models.ReportStatusInReview}
+
+// Create ajoute un nouveau report en base. Un second signalement ouvert du
+// même utilisateur sur la même cible renvoie ErrDuplicateKey.
 func (r *ReportRepository) Create(report *models.Report) error {
-	return r.db.Create(report).Error
+	return translateDuplicate(r.db, r.db.Create(report).Error)
+}
+
+// FindByID renvoie nil,nil si le signalement n'existe pas.
+func (r *ReportRepository) FindByID(id uuid.UUID) (*models.Report, error) {
+	var report models.Report
+	err := r.db.First(&report, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// HasOpen indique si l'utilisateur a déjà un signalement en cours sur la cible.
+func (r *ReportRepository) HasOpen(reporterID uuid.UUID, targetType string, targetID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Report{}).
+		Where("reporter_id = ? AND target_type = ? AND target_id = ? AND status IN ?",
+			reporterID, targetType, targetID, openReportStatuses).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ReportRepository) filtered(f ReportFilter) *gorm.DB {
+	return applyReportFilter(r.db.Model(&models.Report{}), f)
+}
+
+// applyReportFilter restreint une requête sur la table report.
+func applyReportFilter(q *gorm.DB, f ReportFilter) *gorm.DB {
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	if f.TargetType != "" {
+		q = q.Where("target_type = ?", f.TargetType)
+	}
+	if f.ReasonCode != "" {
+		q = q.Where("reason_code = ?", f.ReasonCode)
+	}
+	if f.AssigneeID != nil {
+		q = q.Where("assignee_id = ?", *f.AssigneeID)
+	}
+	return q
+}
+
+// FindGroups regroupe les signalements filtrés par cible, les plus signalées
+// d'abord, et renvoie aussi le nombre total de cibles.
+func (r *ReportRepository) FindGroups(f ReportFilter, limit, offset int) ([]ReportGroup, int64, error) {
+	var total int64
+	targets := r.filtered(f).Select("target_type, target_id").Group("target_type, target_id")
+	if err := r.db.Table("(?) AS targets", targets).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var groups []ReportGroup
+	err := r.filtered(f).
+		Select("target_type, target_id, COUNT(*) AS report_count").
+		Group("target_type, target_id").
+		Order("report_count DESC, MAX(created_at) DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&groups).Error
+	return groups, total, err
 }
 
-// FindAll récupère tous les reports, triés par date décroissante.
-func (r *ReportRepository) FindAll() ([]models.Report, error) {
+// FindByTargets renvoie les signalements filtrés des cibles données, les plus
+// récents d'abord.
+func (r *ReportRepository) FindByTargets(f ReportFilter, targetType string, ids []uuid.UUID) ([]models.Report, error) {
 	var reports []models.Report
-	err := r.db.
+	if len(ids) == 0 {
+		return reports, nil
+	}
+	err := r.filtered(f).
+		Where("target_type = ? AND target_id IN ?", targetType, ids).
 		Order("created_at DESC").
-		Find(&reports).
-		Error
+		Find(&reports).Error
 	return reports, err
 }
 
-func (r *ReportRepository) DeleteByContentID(contentID uuid.UUID) error {
+// Update enregistre l'état d'un signalement.
+func (r *ReportRepository) Update(report *models.Report) error {
+	return r.db.Save(report).Error
+}
+
+// CloseStep prépare la clôture d'un signalement ; si closeTarget est vrai,
+// les autres signalements ouverts sur la même cible sont clos avec lui.
+func (r *ReportRepository) CloseStep(report *models.Report, closeTarget bool) TxStep {
+	return func(tx *gorm.DB) error {
+		if closeTarget {
+			if err := tx.Model(&models.Report{}).
+				Where("target_type = ? AND target_id = ? AND status IN ?", report.TargetType, report.TargetID, openReportStatuses).
+				Updates(map[string]interface{}{
+					"status":            report.Status,
+					"resolution_action": report.ResolutionAction,
+					"resolution_note":   report.ResolutionNote,
+					"resolved_by":       report.ResolvedBy,
+					"resolved_at":       report.ResolvedAt,
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(report).Error
+	}
+}
+
+// DeleteByTarget supprime les signalements d'une cible supprimée.
+func (r *ReportRepository) DeleteByTarget(targetType string, targetID uuid.UUID) error {
 	return r.db.
-		Where("target_content_id = ?", contentID).
+		Where("target_type = ? AND target_id = ?", targetType, targetID).
 		Delete(&models.Report{}).
 		Error
 }
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// SanctionRepository gère les sanctions appliquées aux comptes.
+type SanctionRepository struct {
+	db *gorm.DB
+}
+
+// NewSanctionRepository instancie un SanctionRepository.
+func NewSanctionRepository() *SanctionRepository {
+	return &SanctionRepository{db: database.DB}
+}
+
+// Create enregistre une sanction.
+func (r *SanctionRepository) Create(sanction *models.Sanction) error {
+	return r.db.Create(sanction).Error
+}
+
+// CreateStep prépare l'enregistrement d'une sanction dans une transaction.
+func (r *SanctionRepository) CreateStep(sanction *models.Sanction) TxStep {
+	return func(tx *gorm.DB) error {
+		return tx.Create(sanction).Error
+	}
+}
+
+// FindByID renvoie nil,nil si la sanction n'existe pas.
+func (r *SanctionRepository) FindByID(id uuid.UUID) (*models.Sanction, error) {
+	var sanction models.Sanction
+	err := r.db.First(&sanction, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sanction, nil
+}
+
+func activeSanctions(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("lifted_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", now)
+}
+
+// FindActiveByUser renvoie les sanctions en vigueur d'un utilisateur,
+// éventuellement limitées à certains types.
+func (r *SanctionRepository) FindActiveByUser(userID uuid.UUID, now time.Time, kinds ...string) ([]models.Sanction, error) {
+	var list []models.Sanction
+	q := activeSanctions(r.db, now).Where("user_id = ?", userID)
+	if len(kinds) > 0 {
+		q = q.Where("kind IN ?", kinds)
+	}
+	err := q.Order("created_at DESC").Find(&list).Error
+	return list, err
+}
+
+// FindByUser renvoie l'historique complet des sanctions d'un utilisateur.
+func (r *SanctionRepository) FindByUser(userID uuid.UUID) ([]models.Sanction, error) {
+	var list []models.Sanction
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&list).Error
+	return list, err
+}
+
+// FindActive liste les sanctions en vigueur, les plus récentes d'abord.
+func (r *SanctionRepository) FindActive(now time.Time, limit, offset int) ([]models.Sanction, error) {
+	var list []models.Sanction
+	err := activeSanctions(r.db, now).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&list).Error
+	return list, err
+}
+
+// Update enregistre la levée d'une sanction.
+func (r *SanctionRepository) Update(sanction *models.Sanction) error {
+	return r.db.Save(sanction).Error
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SearchHistoryRepository conserve les recherches récentes des utilisateurs
+// et les recherches sans résultat.
+type SearchHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchHistoryRepository() *SearchHistoryRepository {
+	return &SearchHistoryRepository{db: database.DB}
+}
+
+// Remember enregistre une recherche de l'utilisateur (ou rafraîchit sa date)
+// et ne garde que les keep plus récentes.
+func (r *SearchHistoryRepository) Remember(userID uuid.UUID, query string, at time.Time, keep int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		entry := models.RecentSearch{UserID: userID, Query: query, SearchedAt: at}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "query"}},
+			DoUpdates: clause.AssignmentColumns([]string{"searched_at"}),
+		}).Create(&entry).Error
+		if err != nil {
+			return err
+		}
+		kept := tx.Model(&models.RecentSearch{}).Select("id").
+			Where("user_id = ?", userID).Order("searched_at DESC").Limit(keep)
+		return tx.Where("user_id = ? AND id NOT IN (?)", userID, kept).Delete(&models.RecentSearch{}).Error
+	})
+}
+
+// Recent liste les recherches de l'utilisateur, les plus récentes d'abord.
+func (r *SearchHistoryRepository) Recent(userID uuid.UUID, limit int) ([]models.RecentSearch, error) {
+	var list []models.RecentSearch
+	err := r.db.Where("user_id = ?", userID).Order("searched_at DESC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+// Delete supprime une recherche de l'utilisateur ; renvoie false si elle
+// n'existe pas.
+func (r *SearchHistoryRepository) Delete(userID, id uuid.UUID) (bool, error) {
+	res := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.RecentSearch{})
+	return res.RowsAffected > 0, res.Error
+}
+
+// Clear efface l'historique de l'utilisateur.
+func (r *SearchHistoryRepository) Clear(userID uuid.UUID) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.RecentSearch{}).Error
+}
+
+// RecordMiss compte une recherche restée sans résultat.
+func (r *SearchHistoryRepository) RecordMiss(query string, at time.Time) error {
+	miss := models.SearchMiss{Query: query, Searches: 1, FirstSeenAt: at, LastSeenAt: at}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "query"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"searches":     gorm.Expr("search_miss.searches + 1"),
+			"last_seen_at": at,
+		}),
+	}).Create(&miss).Error
+}
+
+// PurgeMisses supprime les recherches sans résultat plus vues depuis before.
+func (r *SearchHistoryRepository) PurgeMisses(before time.Time) (int64, error) {
+	res := r.db.Where("last_seen_at < ?", before).Delete(&models.SearchMiss{})
+	return res.RowsAffected, res.Error
+}
+
+// TopMisses liste les recherches sans résultat vues depuis since, les plus
+// fréquentes d'abord.
+func (r *SearchHistoryRepository) TopMisses(since time.Time, limit, offset int) ([]models.SearchMiss, error) {
+	var list []models.SearchMiss
+	err := r.db.Where("last_seen_at >= ?", since).
+		Order("searches DESC").Order("last_seen_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&list).Error
+	return list, err
+}
@@ -0,0 +1,317 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Balises entourant les termes trouvés dans les extraits. Le reste de
+// l'extrait est échappé (&, <, >, "), seules ces balises sont du HTML.
+const (
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// SearchFilter décrit une recherche ; les bornes nil sont ignorées.
+type SearchFilter struct {
+	Query         string
+	ViewerID      uuid.UUID
+	IncludeMature bool
+	MinPrice      *int
+	MaxPrice      *int
+	From          *time.Time
+	To            *time.Time
+	Limit         int
+	Offset        int
+	Now           time.Time
+}
+
+// ContentHit est un contenu trouvé, avec sa pertinence et ses extraits
+// surlignés.
+type ContentHit struct {
+	ID             uuid.UUID `json:"id"`
+	Title          string    `json:"title"`
+	Body           string    `json:"body"`
+	Price          int       `json:"price"`
+	CreatorID      uuid.UUID `json:"creator_id"`
+	CreatorName    string    `json:"creator_name"`
+	Maturity       string    `json:"maturity"`
+	CreatedAt      time.Time `json:"created_at"`
+	ThumbnailURL   string    `json:"thumbnail_url" gorm:"-"`
+	TitleHighlight string    `json:"title_highlight"`
+	BodyHighlight  string    `json:"body_highlight"`
+	Rank           float64   `json:"rank"`
+	Total          int64     `json:"-"`
+}
+
+// CreatorHit est un créateur trouvé.
+type CreatorHit struct {
+	ID           uuid.UUID `json:"id"`
+	Username     string    `json:"username"`
+	AvatarURL    string    `json:"avatar_url"`
+	Bio          string    `json:"bio"`
+	BioHighlight string    `json:"bio_highlight"`
+	IsFollowed   bool      `json:"is_followed"`
+	Rank         float64   `json:"rank"`
+	Total        int64     `json:"-"`
+}
+
+// SearchRepository interroge les index de recherche. Sous PostgreSQL, la
+// recherche combine le plein texte (tsvector français et anglais) et la
+// similarité trigramme ; ailleurs (SQLite en test), elle se rabat sur LIKE.
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository() *SearchRepository {
+	return &SearchRepository{db: database.DB}
+}
+
+func (r *SearchRepository) fullText() bool {
+	return r.db.Dialector.Name() == "postgres"
+}
+
+// searchTerms est la sous-requête commune : la requête plein texte et le
+// texte brut pour la similarité.
+const searchTerms = `(SELECT websearch_to_tsquery('french', ?) || websearch_to_tsquery('english', ?) AS query, ?::text AS raw) q`
+
+const headlineOptions = `'StartSel=` + HighlightStart + `, StopSel=` + HighlightStop
+
+// escapedHTML échappe en SQL le texte d'une colonne avant ts_headline, comme
+// htmlEscaper pour le repli : un texte saisi par un utilisateur n'est jamais
+// renvoyé comme du HTML.
+func escapedHTML(expr string) string {
+	return `replace(replace(replace(replace(` + expr + `, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;')`
+}
+
+var contentSearchPG = `
+	SELECT c.id, c.title, c.body, c.price, c.creator_id, u.username AS creator_name, c.maturity, c.created_at,
+	  ts_rank_cd(c.search_vector, q.query)
+	    + 0.5 * similarity(c.title, q.raw)
+	    + 0.3 * COALESCE(tm.score, 0)
+	    + 0.2 * similarity(u.username, q.raw) AS rank,
+	  ts_headline('french', ` + escapedHTML("c.title") + `, q.query, ` + headlineOptions + `, HighlightAll=true') AS title_highlight,
+	  ts_headline('french', ` + escapedHTML("c.body") + `, q.query, ` + headlineOptions + `, MaxFragments=2, MaxWords=25, MinWords=8') AS body_highlight,
+	  COUNT(*) OVER () AS total
+	FROM content c
+	JOIN "user" u ON u.id = c.creator_id
+	CROSS JOIN ` + searchTerms + `
+	LEFT JOIN LATERAL (
+	  SELECT MAX(similarity(t.name, q.raw)) AS score
+	  FROM content_tag ct JOIN tag t ON t.id = ct.tag_id
+	  WHERE ct.content_id = c.id AND (t.name % q.raw OR t.name = lower(q.raw))) tm ON true
+	WHERE c.status = ?
+	  AND (c.search_vector @@ q.query OR c.title % q.raw OR tm.score IS NOT NULL OR u.username % q.raw)`
+
+const contentSearchFallback = `
+	SELECT c.id, c.title, c.body, c.price, c.creator_id, u.username AS creator_name, c.maturity, c.created_at,
+	  (CASE WHEN lower(c.title) LIKE ? ESCAPE '\' THEN 2 ELSE 0 END)
+	    + (CASE WHEN lower(c.body) LIKE ? ESCAPE '\' THEN 1 ELSE 0 END)
+	    + (CASE WHEN tm.n > 0 THEN 1 ELSE 0 END)
+	    + (CASE WHEN lower(u.username) LIKE ? ESCAPE '\' THEN 0.5 ELSE 0 END) AS rank,
+	  COUNT(*) OVER () AS total
+	FROM content c
+	JOIN "user" u ON u.id = c.creator_id
+	LEFT JOIN (
+	  SELECT ct.content_id, COUNT(*) AS n FROM content_tag ct JOIN tag t ON t.id = ct.tag_id
+	  WHERE lower(t.name) LIKE ? ESCAPE '\' GROUP BY ct.content_id) tm ON tm.content_id = c.id
+	WHERE c.status = ?
+	  AND (lower(c.title) LIKE ? ESCAPE '\' OR lower(c.body) LIKE ? ESCAPE '\' OR tm.n > 0 OR lower(u.username) LIKE ? ESCAPE '\')`
+
+// SearchContents renvoie une page de contenus approuvés correspondant à la
+// recherche, les plus pertinents d'abord, et le nombre total de résultats
+// (porté par chaque ligne, donc nul pour une page au-delà de la fin).
+func (r *SearchRepository) SearchContents(f SearchFilter) ([]ContentHit, int64, error) {
+	var (
+		sql  strings.Builder
+		args []interface{}
+	)
+	if r.fullText() {
+		sql.WriteString(contentSearchPG)
+		args = append(args, f.Query, f.Query, f.Query, models.ContentStatusApproved)
+	} else {
+		like := "%" + escapeLike(strings.ToLower(f.Query)) + "%"
+		sql.WriteString(contentSearchFallback)
+		args = append(args, like, like, like, like, models.ContentStatusApproved, like, like, like)
+	}
+	if !f.IncludeMature {
+		sql.WriteString(" AND c.maturity = ?")
+		args = append(args, models.MaturityGeneral)
+	}
+	if f.MinPrice != nil {
+		sql.WriteString(" AND c.price >= ?")
+		args = append(args, *f.MinPrice)
+	}
+	if f.MaxPrice != nil {
+		sql.WriteString(" AND c.price <= ?")
+		args = append(args, *f.MaxPrice)
+	}
+	if f.From != nil {
+		sql.WriteString(" AND COALESCE(c.published_at, c.created_at) >= ?")
+		args = append(args, *f.From)
+	}
+	if f.To != nil {
+		sql.WriteString(" AND COALESCE(c.published_at, c.created_at) < ?")
+		args = append(args, *f.To)
+	}
+	sql.WriteString(" ORDER BY rank DESC, c.created_at DESC, c.id LIMIT ? OFFSET ?")
+	args = append(args, f.Limit, f.Offset)
+
+	var hits []ContentHit
+	if err := r.db.Raw(sql.String(), args...).Scan(&hits).Error; err != nil {
+		return nil, 0, err
+	}
+	if !r.fullText() {
+		for i := range hits {
+			hits[i].TitleHighlight = highlight(hits[i].Title, f.Query)
+			hits[i].BodyHighlight = highlight(hits[i].Body, f.Query)
+		}
+	}
+	total := int64(0)
+	if len(hits) > 0 {
+		total = hits[0].Total
+	}
+	return hits, total, nil
+}
+
+var creatorSearchPG = `
+	SELECT u.id, u.username, COALESCE(u.avatar_url, '') AS avatar_url, COALESCE(u.bio, '') AS bio,
+	  ts_headline('french', ` + escapedHTML("COALESCE(u.bio, '')") + `, q.query, ` + headlineOptions + `, MaxFragments=1, MaxWords=25, MinWords=8') AS bio_highlight,
+	  EXISTS (SELECT 1 FROM subscription s
+	          WHERE s.creator_id = u.id AND s.subscriber_id = ? AND s.status = ? AND s.end_date > ?) AS is_followed,
+	  ts_rank_cd(u.search_vector, q.query) + similarity(u.username, q.raw) AS rank,
+	  COUNT(*) OVER () AS total
+	FROM "user" u
+	CROSS JOIN ` + searchTerms + `
+	WHERE u.role = ?
+	  AND (u.search_vector @@ q.query OR u.username % q.raw OR u.username ILIKE ? ESCAPE '\')`
+
+const creatorSearchFallback = `
+	SELECT u.id, u.username, COALESCE(u.avatar_url, '') AS avatar_url, COALESCE(u.bio, '') AS bio,
+	  EXISTS (SELECT 1 FROM subscription s
+	          WHERE s.creator_id = u.id AND s.subscriber_id = ? AND s.status = ? AND s.end_date > ?) AS is_followed,
+	  (CASE WHEN lower(u.username) LIKE ? ESCAPE '\' THEN 2 ELSE 0 END)
+	    + (CASE WHEN lower(u.bio) LIKE ? ESCAPE '\' THEN 1 ELSE 0 END) AS rank,
+	  COUNT(*) OVER () AS total
+	FROM "user" u
+	WHERE u.role = ?
+	  AND (lower(u.username) LIKE ? ESCAPE '\' OR lower(u.bio) LIKE ? ESCAPE '\')`
+
+// SearchCreators renvoie une page de créateurs dont le nom ou la bio
+// correspond à la recherche.
+func (r *SearchRepository) SearchCreators(f SearchFilter) ([]CreatorHit, int64, error) {
+	like := "%" + escapeLike(strings.ToLower(f.Query)) + "%"
+	var (
+		sql  string
+		args []interface{}
+	)
+	if r.fullText() {
+		sql = creatorSearchPG
+		args = []interface{}{f.ViewerID, models.SubscriptionStatusActive, f.Now, f.Query, f.Query, f.Query, models.RoleCreator, like}
+	} else {
+		sql = creatorSearchFallback
+		args = []interface{}{f.ViewerID, models.SubscriptionStatusActive, f.Now, like, like, models.RoleCreator, like, like}
+	}
+	sql += " ORDER BY rank DESC, u.username LIMIT ? OFFSET ?"
+	args = append(args, f.Limit, f.Offset)
+
+	var hits []CreatorHit
+	if err := r.db.Raw(sql, args...).Scan(&hits).Error; err != nil {
+		return nil, 0, err
+	}
+	total := int64(0)
+	if len(hits) > 0 {
+		total = hits[0].Total
+	}
+	if !r.fullText() {
+		for i := range hits {
+			hits[i].BioHighlight = highlight(hits[i].Bio, f.Query)
+		}
+	}
+	return hits, total, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// highlight échappe le texte et entoure les occurrences de la recherche,
+// sans tenir compte de la casse ; utilisé quand la base ne fournit pas
+// ts_headline. Les occurrences sont cherchées dans le texte brut pour ne
+// jamais couper une entité.
+func highlight(text, query string) string {
+	query = strings.TrimSpace(query)
+	if text == "" || query == "" {
+		return htmlEscaper.Replace(text)
+	}
+	re := regexp.MustCompile(`(?i)` + regexp.QuoteMeta(query))
+	var b strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringIndex(text, -1) {
+		b.WriteString(htmlEscaper.Replace(text[last:m[0]]))
+		b.WriteString(HighlightStart + htmlEscaper.Replace(text[m[0]:m[1]]) + HighlightStop)
+		last = m[1]
+	}
+	b.WriteString(htmlEscaper.Replace(text[last:]))
+	return b.String()
+}
+
+// WithContext renvoie une copie du repository liée au contexte, pour borner
+// la durée des requêtes.
+func (r *SearchRepository) WithContext(ctx context.Context) *SearchRepository {
+	return &SearchRepository{db: r.db.WithContext(ctx)}
+}
+
+// CreatorSuggestion est un créateur proposé à l'autocomplétion.
+type CreatorSuggestion struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	AvatarURL string    `json:"avatar_url"`
+}
+
+// TitleSuggestion est un titre de contenu proposé à l'autocomplétion.
+type TitleSuggestion struct {
+	ID    uuid.UUID `json:"id"`
+	Title string    `json:"title"`
+}
+
+// SuggestCreators renvoie les créateurs dont le nom commence par prefix
+// (déjà en minuscules).
+func (r *SearchRepository) SuggestCreators(prefix string, limit int) ([]CreatorSuggestion, error) {
+	var list []CreatorSuggestion
+	err := r.db.Table(`"user" u`).
+		Select("u.id, u.username, COALESCE(u.avatar_url, '') AS avatar_url").
+		Where("u.role = ? AND lower(u.username) LIKE ? ESCAPE '\\'", models.RoleCreator, escapeLike(prefix)+"%").
+		Order("u.username").
+		Limit(limit).
+		Scan(&list).Error
+	return list, err
+}
+
+// SuggestTitles renvoie les contenus approuvés dont le titre, ou l'un de ses
+// mots, commence par prefix (déjà en minuscules) ; les deux LIKE passent par
+// l'index trigramme sur lower(title).
+func (r *SearchRepository) SuggestTitles(prefix string, includeMature bool, limit int) ([]TitleSuggestion, error) {
+	like := escapeLike(prefix) + "%"
+	q := r.db.Table("content c").
+		Select("c.id, c.title").
+		Where("c.status = ?", models.ContentStatusApproved).
+		Where("lower(c.title) LIKE ? ESCAPE '\\' OR lower(c.title) LIKE ? ESCAPE '\\'", like, "% "+like)
+	if !includeMature {
+		q = q.Where("c.maturity = ?", models.MaturityGeneral)
+	}
+	var list []TitleSuggestion
+	err := q.Order("c.created_at DESC").Limit(limit).Scan(&list).Error
+	return list, err
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Expressions SQL dépendantes du moteur : PostgreSQL en production, SQLite
+// pour les tests. Les dates sont ramenées en UTC.
+
+func isPostgres(db *gorm.DB) bool {
+	return db.Dialector.Name() == "postgres"
+}
+
+// periodSQL renvoie l'expression SQL du premier jour (AAAA-MM-JJ, UTC) de la
+// période contenant col.
+func periodSQL(db *gorm.DB, col, interval string) string {
+	if isPostgres(db) {
+		switch interval {
+		case IntervalWeek:
+			return fmt.Sprintf("to_char(date_trunc('week', %s AT TIME ZONE 'UTC'), 'YYYY-MM-DD')", col)
+		case IntervalMonth:
+			return fmt.Sprintf("to_char(%s AT TIME ZONE 'UTC', 'YYYY-MM-01')", col)
+		}
+		return fmt.Sprintf("to_char(%s AT TIME ZONE 'UTC', 'YYYY-MM-DD')", col)
+	}
+	switch interval {
+	case IntervalWeek:
+		return fmt.Sprintf("date(%s, 'weekday 0', '-6 days')", col)
+	case IntervalMonth:
+		return fmt.Sprintf("strftime('%%Y-%%m-01', %s)", col)
+	}
+	return fmt.Sprintf("strftime('%%Y-%%m-%%d', %s)", col)
+}
+
+// daysSQL renvoie l'expression SQL du nombre de jours (décimal) de b à a.
+func daysSQL(db *gorm.DB, a, b string) string {
+	if isPostgres(db) {
+		return fmt.Sprintf("(EXTRACT(EPOCH FROM (%s - %s)) / 86400.0)", a, b)
+	}
+	return fmt.Sprintf("(julianday(%s) - julianday(%s))", a, b)
+}
+
+// monthSQL renvoie l'indice de mois (année*12 + mois-1, UTC) de col.
+func monthSQL(db *gorm.DB, col string) string {
+	if isPostgres(db) {
+		return fmt.Sprintf("(CAST(EXTRACT(YEAR FROM %[1]s AT TIME ZONE 'UTC') AS INTEGER) * 12 + CAST(EXTRACT(MONTH FROM %[1]s AT TIME ZONE 'UTC') AS INTEGER) - 1)", col)
+	}
+	return fmt.Sprintf("(CAST(strftime('%%Y', %[1]s) AS INTEGER) * 12 + CAST(strftime('%%m', %[1]s) AS INTEGER) - 1)", col)
+}
+
+// leastSQL renvoie l'expression SQL du plus petit de a et b.
+func leastSQL(db *gorm.DB, a, b string) string {
+	if isPostgres(db) {
+		return fmt.Sprintf("LEAST(%s, %s)", a, b)
+	}
+	return fmt.Sprintf("MIN(%s, %s)", a, b)
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
@@ -55,11 +57,54 @@ func (r *SubscriptionRepository) GetAllSubscriptions(subscriberID uuid.UUID) ([]
 	return subs, err
 }
 
-// CountByCreatorID renvoie le nombre d'abonnés pour un créateur donné.
+// CountByCreatorID renvoie le nombre d'abonnés en cours d'un créateur.
 func (r *SubscriptionRepository) CountByCreatorID(creatorID uuid.UUID) (int64, error) {
 	var count int64
 	err := r.db.Model(&models.Subscription{}).
-		Where("creator_id = ?", creatorID).
+		Where("creator_id = ? AND status = ? AND end_date > ?", creatorID, models.SubscriptionStatusActive, time.Now()).
 		Count(&count).Error
 	return count, err
 }
+
+// PauseByCreatorStep prépare la suspension des abonnements en cours d'un
+// créateur ; leur nombre est écrit dans paused.
+func (r *SubscriptionRepository) PauseByCreatorStep(creatorID uuid.UUID, now time.Time, paused *int64) TxStep {
+	return func(tx *gorm.DB) error {
+		res := tx.Model(&models.Subscription{}).
+			Where("creator_id = ? AND status = ? AND end_date > ?", creatorID, models.SubscriptionStatusActive, now).
+			Updates(map[string]interface{}{"status": models.SubscriptionStatusPaused, "paused_at": now})
+		*paused = res.RowsAffected
+		return res.Error
+	}
+}
+
+// ResumeByCreator réactive les abonnements suspendus d'un créateur en
+// repoussant leur échéance de la durée de la pause.
+func (r *SubscriptionRepository) ResumeByCreator(creatorID uuid.UUID, now time.Time) (int, error) {
+	var subs []models.Subscription
+	resumed := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("creator_id = ? AND status = ?", creatorID, models.SubscriptionStatusPaused).
+			Find(&subs).Error; err != nil {
+			return err
+		}
+		for _, sub := range subs {
+			endDate := sub.EndDate
+			if sub.PausedAt != nil {
+				endDate = endDate.Add(now.Sub(*sub.PausedAt))
+			}
+			if err := tx.Model(&models.Subscription{}).
+				Where("id = ?", sub.ID).
+				Updates(map[string]interface{}{
+					"status":    models.SubscriptionStatusActive,
+					"end_date":  endDate,
+					"paused_at": nil,
+				}).Error; err != nil {
+				return err
+			}
+			resumed++
+		}
+		return nil
+	})
+	return resumed, err
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// TagCount est un tag avec son nombre de contenus publiés.
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// TagRepository gère les tags et leur association aux contenus.
+type TagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository instancie un TagRepository.
+func NewTagRepository() *TagRepository {
+	return &TagRepository{db: database.DB}
+}
+
+// SetContentTags remplace les tags d'un contenu (les tags inconnus sont créés).
+func (r *TagRepository) SetContentTags(contentID uuid.UUID, names []string) ([]models.Tag, error) {
+	tags := make([]models.Tag, 0, len(names))
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, name := range names {
+			var tag models.Tag
+			if err := tx.Where(models.Tag{Name: name}).FirstOrCreate(&tag).Error; err != nil {
+				return err
+			}
+			tags = append(tags, tag)
+		}
+		if err := tx.Where("content_id = ?", contentID).Delete(&models.ContentTag{}).Error; err != nil {
+			return err
+		}
+		for _, tag := range tags {
+			if err := tx.Create(&models.ContentTag{ContentID: contentID, TagID: tag.ID}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return tags, err
+}
+
+// NamesByContents renvoie les noms de tags de chaque contenu.
+func (r *TagRepository) NamesByContents(contentIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	out := make(map[uuid.UUID][]string, len(contentIDs))
+	if len(contentIDs) == 0 {
+		return out, nil
+	}
+	var rows []struct {
+		ContentID uuid.UUID
+		Name      string
+	}
+	err := r.db.Table("content_tag").
+		Select("content_tag.content_id, tag.name").
+		Joins("JOIN tag ON tag.id = content_tag.tag_id").
+		Where("content_tag.content_id IN ?", contentIDs).
+		Order("tag.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[row.ContentID] = append(out[row.ContentID], row.Name)
+	}
+	return out, nil
+}
+
+// WithContext renvoie une copie du repository liée au contexte.
+func (r *TagRepository) WithContext(ctx context.Context) *TagRepository {
+	return &TagRepository{db: r.db.WithContext(ctx)}
+}
+
+// Autocomplete renvoie les tags commençant par prefix, les plus utilisés d'abord.
+func (r *TagRepository) Autocomplete(prefix string, limit int) ([]TagCount, error) {
+	var out []TagCount
+	err := r.db.Table("tag").
+		Select("tag.name, COUNT(content.id) AS count").
+		Joins("LEFT JOIN content_tag ON content_tag.tag_id = tag.id").
+		Joins("LEFT JOIN content ON content.id = content_tag.content_id AND content.status = ?", models.ContentStatusApproved).
+		Where("tag.name LIKE ?", prefix+"%").
+		Group("tag.name").
+		Order("count DESC, tag.name").
+		Limit(limit).
+		Scan(&out).Error
+	return out, err
+}
+
+// TopByCreator renvoie les tags les plus utilisés par un créateur sur ses contenus publiés.
+func (r *TagRepository) TopByCreator(creatorID uuid.UUID, limit int) ([]TagCount, error) {
+	var out []TagCount
+	err := r.db.Table("content_tag").
+		Select("tag.name, COUNT(*) AS count").
+		Joins("JOIN tag ON tag.id = content_tag.tag_id").
+		Joins("JOIN content ON content.id = content_tag.content_id").
+		Where("content.creator_id = ? AND content.status = ?", creatorID, models.ContentStatusApproved).
+		Group("tag.name").
+		Order("count DESC, tag.name").
+		Limit(limit).
+		Scan(&out).Error
+	return out, err
+}
+
+// FindPublishedByTag renvoie les contenus publiés portant un tag.
+func (r *TagRepository) FindPublishedByTag(name string, limit, offset int, includeMature bool) ([]models.Content, error) {
+	var list []models.Content
+	err := r.db.
+		Preload("Creator").
+		Scopes(MaturityScope(includeMature)).
+		Joins("JOIN content_tag ON content_tag.content_id = content.id").
+		Joins("JOIN tag ON tag.id = content_tag.tag_id").
+		Where("tag.name = ? AND content.status = ?", name, models.ContentStatusApproved).
+		Order("content.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&list).Error
+	return list, err
+}
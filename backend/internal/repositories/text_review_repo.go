@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// TextReviewRepository gère la file de modération des textes retenus.
+type TextReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewTextReviewRepository() *TextReviewRepository {
+	return &TextReviewRepository{db: database.DB}
+}
+
+func (r *TextReviewRepository) Create(review *models.TextReview) error {
+	return r.db.Create(review).Error
+}
+
+// CreateStep prépare la mise en file d'un texte dans une transaction.
+func (r *TextReviewRepository) CreateStep(review *models.TextReview) TxStep {
+	return func(tx *gorm.DB) error {
+		return tx.Create(review).Error
+	}
+}
+
+// FindByID renvoie nil,nil si l'entrée n'existe pas.
+func (r *TextReviewRepository) FindByID(id uuid.UUID) (*models.TextReview, error) {
+	var review models.TextReview
+	err := r.db.First(&review, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// Find liste la file filtrée par statut et type de cible, les plus anciennes
+// entrées d'abord.
+func (r *TextReviewRepository) Find(status, targetType string, limit, offset int) ([]models.TextReview, int64, error) {
+	q := r.db.Model(&models.TextReview{})
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	if targetType != "" {
+		q = q.Where("target_type = ?", targetType)
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []models.TextReview
+	err := q.Order("created_at ASC").Limit(limit).Offset(offset).Find(&list).Error
+	return list, total, err
+}
+
+func (r *TextReviewRepository) Update(review *models.TextReview) error {
+	return r.db.Save(review).Error
+}
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Types d'événements agrégés dans les ActivityBucket.
+const (
+	ActivityLike         = "like"
+	ActivityComment      = "comment"
+	ActivityView         = "view"
+	ActivitySubscription = "subscription"
+)
+
+// ActivityEvent est un événement brut : un like, un commentaire visible, une
+// vue d'image ou un nouvel abonnement (sans contenu).
+type ActivityEvent struct {
+	Kind      string
+	ContentID uuid.NullUUID
+	CreatorID uuid.UUID
+	At        time.Time
+}
+
+// TrendingContent est un contenu en tendance avec ses compteurs sur la fenêtre.
+type TrendingContent struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	Price       int       `json:"price"`
+	CreatorID   uuid.UUID `json:"creator_id"`
+	CreatorName string    `json:"creator_name"`
+	Maturity    string    `json:"maturity"`
+	CreatedAt   time.Time `json:"created_at"`
+	Score       float64   `json:"score"`
+	Likes       int64     `json:"likes"`
+	Comments    int64     `json:"comments"`
+	Views       int64     `json:"views"`
+}
+
+// TrendingCreator est un créateur en tendance avec ses compteurs sur la fenêtre.
+type TrendingCreator struct {
+	CreatorID     uuid.UUID `json:"creator_id"`
+	Username      string    `json:"username"`
+	Score         float64   `json:"score"`
+	Likes         int64     `json:"likes"`
+	Comments      int64     `json:"comments"`
+	Views         int64     `json:"views"`
+	Subscriptions int64     `json:"subscriptions"`
+}
+
+// TrendingRepository gère les agrégats horaires d'activité et les scores de
+// tendance.
+type TrendingRepository struct {
+	db *gorm.DB
+}
+
+func NewTrendingRepository() *TrendingRepository {
+	return &TrendingRepository{db: database.DB}
+}
+
+const activityEvents = `
+	SELECT 'like' AS kind, l.content_id, c.creator_id, l.created_at AS at
+	  FROM "like" l JOIN content c ON c.id = l.content_id WHERE l.created_at >= ?
+	UNION ALL
+	SELECT 'comment', cm.content_id, c.creator_id, cm.created_at
+	  FROM comment cm JOIN content c ON c.id = cm.content_id WHERE cm.created_at >= ? AND cm.status = ?
+	UNION ALL
+	SELECT 'view', e.content_id, c.creator_id, e.occurred_at
+	  FROM content_event e JOIN content c ON c.id = e.content_id WHERE e.occurred_at >= ? AND e.type = ?
+	UNION ALL
+	SELECT 'subscription', NULL, s.creator_id, s.start_date
+	  FROM subscription s WHERE s.start_date >= ?`
+
+// EachActivityEvent parcourt les événements survenus depuis since sans les
+// charger tous en mémoire.
+func (r *TrendingRepository) EachActivityEvent(since time.Time, fn func(ActivityEvent)) error {
+	rows, err := r.db.Raw(activityEvents,
+		since, since, models.TextStatusVisible, since, models.ContentEventView, since).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ev ActivityEvent
+		if err := rows.Scan(&ev.Kind, &ev.ContentID, &ev.CreatorID, &ev.At); err != nil {
+			return err
+		}
+		fn(ev)
+	}
+	return rows.Err()
+}
+
+// LatestBucketHour renvoie l'heure du dernier agrégat, nil s'il n'y en a aucun.
+func (r *TrendingRepository) LatestBucketHour() (*time.Time, error) {
+	var buckets []models.ActivityBucket
+	if err := r.db.Order("hour DESC").Limit(1).Find(&buckets).Error; err != nil {
+		return nil, err
+	}
+	if len(buckets) == 0 {
+		return nil, nil
+	}
+	return &buckets[0].Hour, nil
+}
+
+// ReplaceBuckets remplace les agrégats à partir de l'heure from et supprime
+// ceux antérieurs à purgeBefore.
+func (r *TrendingRepository) ReplaceBuckets(from, purgeBefore time.Time, buckets []models.ActivityBucket) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("hour >= ? OR hour < ?", from, purgeBefore).Delete(&models.ActivityBucket{}).Error; err != nil {
+			return err
+		}
+		if len(buckets) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(buckets, 500).Error
+	})
+}
+
+// EachBucketSince parcourt les agrégats à partir de l'heure since.
+func (r *TrendingRepository) EachBucketSince(since time.Time, fn func(models.ActivityBucket)) error {
+	rows, err := r.db.Model(&models.ActivityBucket{}).Where("hour >= ?", since).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var b models.ActivityBucket
+		if err := r.db.ScanRows(rows, &b); err != nil {
+			return err
+		}
+		fn(b)
+	}
+	return rows.Err()
+}
+
+// ReplaceScores remplace les scores d'une fenêtre.
+func (r *TrendingRepository) ReplaceScores(window string, scores []models.TrendingScore) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("time_window = ?", window).Delete(&models.TrendingScore{}).Error; err != nil {
+			return err
+		}
+		if len(scores) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(scores, 500).Error
+	})
+}
+
+// TopContents liste les contenus approuvés les mieux classés sur la fenêtre.
+func (r *TrendingRepository) TopContents(window string, includeMature bool, limit, offset int) ([]TrendingContent, error) {
+	q := r.db.Table("trending_score AS t").
+		Select(`c.id, c.title, c.body, c.price, c.creator_id, u.username AS creator_name, c.maturity, c.created_at,
+		        t.score, t.likes, t.comments, t.views`).
+		Joins("JOIN content c ON c.id = t.target_id").
+		Joins(`JOIN "user" u ON u.id = c.creator_id`).
+		Where("t.time_window = ? AND t.target_type = ? AND c.status = ?", window, models.TrendingTargetContent, models.ContentStatusApproved)
+	if !includeMature {
+		q = q.Where("c.maturity = ?", models.MaturityGeneral)
+	}
+	var list []TrendingContent
+	err := q.Order("t.score DESC").Order("c.id").Limit(limit).Offset(offset).Find(&list).Error
+	return list, err
+}
+
+// TopCreators liste les créateurs les mieux classés sur la fenêtre.
+func (r *TrendingRepository) TopCreators(window string, limit, offset int) ([]TrendingCreator, error) {
+	var list []TrendingCreator
+	err := r.db.Table("trending_score AS t").
+		Select("u.id AS creator_id, u.username, t.score, t.likes, t.comments, t.views, t.subscriptions").
+		Joins(`JOIN "user" u ON u.id = t.target_id`).
+		Where("t.time_window = ? AND t.target_type = ?", window, models.TrendingTargetCreator).
+		Order("t.score DESC").Order("u.id").
+		Limit(limit).Offset(offset).
+		Find(&list).Error
+	return list, err
+}
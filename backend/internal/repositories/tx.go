@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"gorm.io/gorm"
+)
+
+// ErrDuplicateKey signale une violation d'index unique, quel que soit le SGBD.
+var ErrDuplicateKey = errors.New("enregistrement déjà existant")
+
+// TxStep est une écriture préparée par un dépôt et exécutée dans la
+// transaction d'un autre : une action et ses effets sont validés ensemble.
+type TxStep func(tx *gorm.DB) error
+
+// InTransaction exécute les étapes dans une même transaction.
+func InTransaction(steps ...TxStep) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		return runSteps(tx, steps)
+	})
+}
+
+func runSteps(tx *gorm.DB, steps []TxStep) error {
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+		if err := step(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// translateDuplicate remplace une violation d'index unique par ErrDuplicateKey.
+func translateDuplicate(db *gorm.DB, err error) error {
+	if err == nil {
+		return nil
+	}
+	if t, ok := db.Dialector.(gorm.ErrorTranslator); ok && errors.Is(t.Translate(err), gorm.ErrDuplicatedKey) {
+		return ErrDuplicateKey
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicateKey
+	}
+	return err
+}
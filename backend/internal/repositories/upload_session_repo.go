@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// UploadSessionRepository gère la persistance des sessions d'upload reprenables.
+type UploadSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewUploadSessionRepository instancie un UploadSessionRepository.
+func NewUploadSessionRepository() *UploadSessionRepository {
+	return &UploadSessionRepository{db: database.DB}
+}
+
+// Create enregistre une nouvelle session.
+func (r *UploadSessionRepository) Create(session *models.UploadSession) error {
+	return r.db.Create(session).Error
+}
+
+// FindByID renvoie nil,nil si la session n'existe pas.
+func (r *UploadSessionRepository) FindByID(id uuid.UUID) (*models.UploadSession, error) {
+	var session models.UploadSession
+	err := r.db.First(&session, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Update sauvegarde la session.
+func (r *UploadSessionRepository) Update(session *models.UploadSession) error {
+	return r.db.Save(session).Error
+}
+
+// Advance fait passer l'offset de from à to et repousse l'expiration, à
+// condition que la session soit encore en cours d'envoi à l'offset from.
+// Renvoie false si un autre envoi (éventuellement sur une autre réplica) l'a
+// devancé.
+func (r *UploadSessionRepository) Advance(id uuid.UUID, from, to int64, expiresAt time.Time) (bool, error) {
+	res := r.db.Model(&models.UploadSession{}).
+		Where("id = ? AND \"offset\" = ? AND status = ?", id, from, models.UploadSessionStatusUploading).
+		Updates(map[string]interface{}{"offset": to, "expires_at": expiresAt})
+	return res.RowsAffected == 1, res.Error
+}
+
+// Transition change le statut de la session s'il vaut encore from.
+func (r *UploadSessionRepository) Transition(id uuid.UUID, from, to string) (bool, error) {
+	res := r.db.Model(&models.UploadSession{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	return res.RowsAffected == 1, res.Error
+}
+
+// Delete supprime une session.
+func (r *UploadSessionRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.UploadSession{}, "id = ?", id).Error
+}
+
+// FindExpired renvoie les sessions dont la date d'expiration est passée.
+func (r *UploadSessionRepository) FindExpired(now time.Time) ([]models.UploadSession, error) {
+	var sessions []models.UploadSession
+	err := r.db.
+		Where("expires_at < ?", now).
+		Find(&sessions).Error
+	return sessions, err
+}
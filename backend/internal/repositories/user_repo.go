@@ -71,6 +71,22 @@ func (r *UserRepository) UpdateRole(userID uuid.UUID, newRole models.Role) error
 	return nil
 }
 
+// Update enregistre toutes les colonnes d’un utilisateur.
+func (r *UserRepository) Update(user *models.User) error {
+	return r.db.Save(user).Error
+}
+
+// FindByAgeVerificationStatus liste les utilisateurs d’un statut de
+// vérification d’âge, les plus anciennes demandes d’abord.
+func (r *UserRepository) FindByAgeVerificationStatus(status string) ([]models.User, error) {
+	var users []models.User
+	err := r.db.
+		Where("age_verification_status = ?", status).
+		Order("created_at ASC").
+		Find(&users).Error
+	return users, err
+}
+
 // FindAll récupère tous les utilisateurs.
 func (r *UserRepository) FindAll() ([]models.User, error) {
 	var users []models.User
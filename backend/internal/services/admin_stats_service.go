@@ -2,313 +2,259 @@ package services
 
 import (
 	"fmt"
-	"sort"
+	"log"
 	"time"
 
-	"github.com/google/uuid"
-	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
 )
 
-type AdminStatsService struct{}
+const (
+	// dailyStatsBackfill borne le premier cumul quotidien de la plateforme.
+	dailyStatsBackfill = 365
+	// dailyStatsHour : heure UTC du cumul nocturne.
+	dailyStatsHour = 2
+)
 
-func NewAdminStatsService() *AdminStatsService {
-	return &AdminStatsService{}
+// AdminStatsService fournit les statistiques du tableau de bord admin :
+// revenus tirés des paiements réussis, classements par engagement et
+// cumuls quotidiens matérialisés chaque nuit.
+type AdminStatsService struct {
+	repo *repositories.AdminStatsRepository
+	now  func() time.Time
 }
 
-// GetBasicStats - Version simple sans JOIN complexes
-func (s *AdminStatsService) GetBasicStats(days int) (*models.AdminStatsSimple, error) {
-	var stats models.AdminStatsSimple
-	var subscriptionCount int64
-
-	endDate := time.Now()
-	startDate := endDate.AddDate(0, 0, -days)
-	stats.Period = fmt.Sprintf("%d days", days)
-	stats.StartDate = startDate
-	stats.EndDate = endDate
-
-	database.DB.Table("user").
-		Where("created_at >= ? AND created_at <= ?", startDate, endDate).
-		Count(&stats.TotalUsers)
-
-	database.DB.Table("user").
-		Where("role = ? AND created_at >= ? AND created_at <= ?", "creator", startDate, endDate).
-		Count(&stats.TotalCreators)
-
-	database.DB.Table("content").
-		Where("created_at >= ? AND created_at <= ?", startDate, endDate).
-		Count(&stats.TotalContents)
-
-	database.DB.Table("subscription").
-		Where("created_at >= ? AND created_at <= ?", startDate, endDate).
-		Count(&subscriptionCount)
-	stats.TotalRevenue = subscriptionCount * 3000
-
-	database.DB.Table("subscription").
-		Where("start_date <= ? AND end_date >= ?", endDate, startDate).
-		Count(&stats.TotalSubscribers)
+func NewAdminStatsService(repo *repositories.AdminStatsRepository) *AdminStatsService {
+	return &AdminStatsService{repo: repo, now: time.Now}
+}
 
-	database.DB.Table("content").
-		Where("created_at >= ? AND created_at <= ? AND status = ?", startDate, endDate, "pending").
-		Count(&stats.PendingContents)
+// WithClock remplace l'horloge des calculs, pour des tests déterministes.
+func (s *AdminStatsService) WithClock(now func() time.Time) *AdminStatsService {
+	s.now = now
+	return s
+}
 
-	database.DB.Table("content").
-		Where("created_at >= ? AND created_at <= ? AND status = ?", startDate, endDate, "approved").
-		Count(&stats.ApprovedContents)
+func (s *AdminStatsService) window(days int) (time.Time, time.Time) {
+	end := s.now()
+	return end.AddDate(0, 0, -days), end
+}
 
-	database.DB.Table("content").
-		Where("created_at >= ? AND created_at <= ? AND status = ?", startDate, endDate, "rejected").
-		Count(&stats.RejectedContents)
+// GetBasicStats renvoie les compteurs des days derniers jours.
+func (s *AdminStatsService) GetBasicStats(days int) (*models.AdminStatsSimple, error) {
+	startDate, endDate := s.window(days)
+	totals, err := s.repo.Totals(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
 
+	stats := &models.AdminStatsSimple{
+		TotalUsers:       totals.Users,
+		TotalCreators:    totals.Creators,
+		TotalContents:    totals.Contents,
+		TotalRevenue:     totals.RevenueCents,
+		TotalSubscribers: totals.Subscribers,
+		PendingContents:  totals.Pending,
+		ApprovedContents: totals.Approved,
+		RejectedContents: totals.Rejected,
+		Period:           fmt.Sprintf("%d days", days),
+		StartDate:        startDate,
+		EndDate:          endDate,
+	}
 	if stats.TotalUsers > 0 {
 		stats.AvgRevenuePerUser = float64(stats.TotalRevenue) / float64(stats.TotalUsers)
+		stats.ConversionRate = float64(stats.TotalSubscribers) / float64(stats.TotalUsers) * 100
 	}
 	if stats.TotalCreators > 0 {
 		stats.AvgContentPerCreator = float64(stats.TotalContents) / float64(stats.TotalCreators)
 	}
-	if stats.TotalUsers > 0 {
-		stats.ConversionRate = float64(stats.TotalSubscribers) / float64(stats.TotalUsers) * 100
-	}
-
-	return &stats, nil
+	return stats, nil
 }
 
+// GetTopCreators classe les créateurs par revenus encaissés sur la période.
 func (s *AdminStatsService) GetTopCreators(limit int, days int) ([]models.SimpleCreatorRank, error) {
-	var creators []models.SimpleCreatorRank
-
-	startDate := time.Now().AddDate(0, 0, -days)
-	endDate := time.Now()
-
-	userQuery := `
-		SELECT 
-			id::text as creator_id,
-			username,
-			email,
-			created_at as joined_at
-		FROM "user" 
-		WHERE role = 'creator'
-		ORDER BY created_at ASC
-	`
-
-	rows, err := database.DB.Raw(userQuery).Rows()
+	startDate, endDate := s.window(days)
+	creators, err := s.repo.TopCreators(startDate, endDate, limit)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var allCreators []models.SimpleCreatorRank
-	for rows.Next() {
-		var creator models.SimpleCreatorRank
-		var creatorIDStr string
-
-		if err := rows.Scan(&creatorIDStr, &creator.Username,
-			&creator.Email, &creator.JoinedAt); err != nil {
-			return nil, err
-		}
-
-		if parsedID, err := uuid.Parse(creatorIDStr); err == nil {
-			creator.CreatorID = parsedID
-		}
-
-		allCreators = append(allCreators, creator)
-	}
-
-	for i, creator := range allCreators {
-		database.DB.Table("content").
-			Where("creator_id = ? AND created_at >= ? AND created_at <= ?",
-				creator.CreatorID, startDate, endDate).
-			Count(&allCreators[i].ContentCount)
-
-		var subscriptionCount int64
-		database.DB.Table("subscription").
-			Where("creator_id = ? AND created_at >= ? AND created_at <= ?",
-				creator.CreatorID, startDate, endDate).
-			Count(&subscriptionCount)
-
-		allCreators[i].TotalRevenue = subscriptionCount * 3000
-
-		allCreators[i].Subscribers = subscriptionCount
-
-		fmt.Printf("DEBUG Creator %s (ID: %s): ContentCount=%d, SubscriptionCount=%d, Revenue=%d\n",
-			creator.Username, creator.CreatorID, allCreators[i].ContentCount, subscriptionCount, allCreators[i].TotalRevenue)
+	if creators == nil {
+		creators = []models.SimpleCreatorRank{}
 	}
-
-	sort.Slice(allCreators, func(i, j int) bool {
-		if allCreators[i].TotalRevenue != allCreators[j].TotalRevenue {
-			return allCreators[i].TotalRevenue > allCreators[j].TotalRevenue
-		}
-		return allCreators[i].ContentCount > allCreators[j].ContentCount
-	})
-
-	if limit > len(allCreators) {
-		limit = len(allCreators)
-	}
-
-	for i := 0; i < len(allCreators) && i < limit; i++ {
-		allCreators[i].Rank = i + 1
-		creators = append(creators, allCreators[i])
-	}
-
-	fmt.Printf("DEBUG: Returning %d creators out of %d total\n", len(creators), len(allCreators))
-
 	return creators, nil
 }
 
+// GetTopContents classe les contenus publiés sur la période par engagement.
 func (s *AdminStatsService) GetTopContents(limit int, days int) ([]models.SimpleContentRank, error) {
-	var contents []models.SimpleContentRank
-
-	startDate := time.Now().AddDate(0, 0, -days)
-	endDate := time.Now()
+	return s.rankContents(limit, days, false)
+}
 
-	query := `
-		SELECT 
-			ROW_NUMBER() OVER (ORDER BY c.price DESC, c.created_at DESC) as rank,
-			c.id::text as content_id,
-			c.title,
-			u.username as creator_name,
-			c.price,
-			c.status,
-			c.created_at
-		FROM content c
-		JOIN "user" u ON c.creator_id = u.id
-		WHERE c.created_at >= $1 AND c.created_at <= $2
-		ORDER BY c.price DESC, c.created_at DESC
-		LIMIT $3
-	`
+// GetFlopContents renvoie les contenus publiés sur la période les moins engageants.
+func (s *AdminStatsService) GetFlopContents(limit int, days int) ([]models.SimpleContentRank, error) {
+	return s.rankContents(limit, days, true)
+}
 
-	rows, err := database.DB.Raw(query, startDate, endDate, limit).Rows()
+func (s *AdminStatsService) rankContents(limit, days int, ascending bool) ([]models.SimpleContentRank, error) {
+	startDate, endDate := s.window(days)
+	weights := repositories.EngagementWeights{Like: trendingLikeWeight, Comment: trendingCommentWeight, View: trendingViewWeight}
+	contents, err := s.repo.RankContents(startDate, endDate, weights, limit, ascending)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var content models.SimpleContentRank
-		var contentIDStr string
-		if err := rows.Scan(&content.Rank, &contentIDStr, &content.Title,
-			&content.CreatorName, &content.Price, &content.Status, &content.CreatedAt); err != nil {
-			return nil, err
-		}
-		if parsedID, err := uuid.Parse(contentIDStr); err == nil {
-			content.ContentID = parsedID
-		}
-		contents = append(contents, content)
+	if contents == nil {
+		contents = []models.SimpleContentRank{}
 	}
-
 	return contents, nil
 }
 
-func (s *AdminStatsService) GetFlopContents(limit int, days int) ([]models.SimpleContentRank, error) {
-	var contents []models.SimpleContentRank
-
-	startDate := time.Now().AddDate(0, 0, -days)
-	endDate := time.Now()
+// GetDailyStats renvoie l'activité des days derniers jours UTC, aujourd'hui
+// compris : les jours passés viennent du cumul nocturne, le jour en cours
+// est calculé à la volée.
+func (s *AdminStatsService) GetDailyStats(days int) ([]models.PlatformDailyStat, error) {
+	today := utcDay(s.now())
+	return s.GetDailyStatsBetween(today.AddDate(0, 0, 1-days), today.AddDate(0, 0, 1))
+}
 
-	query := `
-		SELECT 
-			ROW_NUMBER() OVER (ORDER BY c.price ASC, c.created_at ASC) as rank,
-			c.id::text as content_id,
-			c.title,
-			u.username as creator_name,
-			c.price,
-			c.status,
-			c.created_at
-		FROM content c
-		JOIN "user" u ON c.creator_id = u.id
-		WHERE c.created_at >= $1 AND c.created_at <= $2
-		ORDER BY c.price ASC, c.created_at ASC
-		LIMIT $3
-	`
+// GetDailyStatsBetween renvoie les cumuls quotidiens des jours UTC de from
+// (inclus) à to (exclu, un jour entamé compte), jours sans activité compris.
+// Les jours futurs sont ignorés ; le jour en cours est calculé en direct.
+func (s *AdminStatsService) GetDailyStatsBetween(from, to time.Time) ([]models.PlatformDailyStat, error) {
+	now := s.now()
+	today := utcDay(now)
+	start, end := utcDay(from), utcDay(to)
+	if end.Before(to) {
+		end = end.AddDate(0, 0, 1)
+	}
+	if tomorrow := today.AddDate(0, 0, 1); end.After(tomorrow) {
+		end = tomorrow
+	}
+	if !start.Before(end) {
+		return []models.PlatformDailyStat{}, nil
+	}
 
-	rows, err := database.DB.Raw(query, startDate, endDate, limit).Rows()
+	storedEnd := end
+	if storedEnd.After(today) {
+		storedEnd = today
+	}
+	stored, err := s.repo.DailyStats(start, storedEnd)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var content models.SimpleContentRank
-		var contentIDStr string
-		if err := rows.Scan(&content.Rank, &contentIDStr, &content.Title,
-			&content.CreatorName, &content.Price, &content.Status, &content.CreatedAt); err != nil {
+	var live []models.PlatformDailyStat
+	if end.After(today) {
+		if live, err = s.repo.DailyActivity(today, now); err != nil {
 			return nil, err
 		}
-		if parsedID, err := uuid.Parse(contentIDStr); err == nil {
-			content.ContentID = parsedID
-		}
-		contents = append(contents, content)
+	}
+	byDay := make(map[time.Time]models.PlatformDailyStat, len(stored)+len(live))
+	for _, st := range append(stored, live...) {
+		byDay[utcDay(st.Day)] = st
 	}
 
-	return contents, nil
+	out := make([]models.PlatformDailyStat, 0, int(end.Sub(start).Hours()/24))
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		st := byDay[day]
+		st.Day = day
+		out = append(out, st)
+	}
+	return out, nil
 }
 
+// GetRevenueByDay renvoie les paiements réussis par jour (centimes) sur les
+// days derniers jours.
 func (s *AdminStatsService) GetRevenueByDay(days int) ([]models.RevenueByPeriod, error) {
-	var revenue []models.RevenueByPeriod
-
-	startDate := time.Now().AddDate(0, 0, -days)
-	endDate := time.Now()
-
-	query := `
-		SELECT 
-			DATE(created_at) as date,
-			COUNT(*) * 3000 as amount
-		FROM subscription 
-		WHERE created_at >= $1 AND created_at <= $2
-		GROUP BY DATE(created_at)
-		ORDER BY date ASC
-	`
-
-	rows, err := database.DB.Raw(query, startDate, endDate).Rows()
+	stats, err := s.GetDailyStats(days)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	revenue := make([]models.RevenueByPeriod, len(stats))
+	for i, st := range stats {
+		revenue[i] = models.RevenueByPeriod{Date: st.Day, Amount: st.RevenueCents}
+	}
+	return revenue, nil
+}
 
-	for rows.Next() {
-		var rev models.RevenueByPeriod
-		if err := rows.Scan(&rev.Date, &rev.Amount); err != nil {
-			return nil, err
-		}
-		revenue = append(revenue, rev)
+// RollupDaily matérialise l'activité des jours terminés depuis le dernier
+// cumul (recalculé, il a pu être fait avant des corrections) ou, au premier
+// passage, depuis un an.
+func (s *AdminStatsService) RollupDaily() error {
+	now := s.now()
+	today := utcDay(now)
+	from := today.AddDate(0, 0, -dailyStatsBackfill)
+	latest, err := s.repo.LatestDailyStat()
+	if err != nil {
+		return err
+	}
+	if latest != nil {
+		from = utcDay(*latest)
+	}
+	if !from.Before(today) {
+		return nil
 	}
 
-	return revenue, nil
+	stats, err := s.repo.DailyActivity(from, today)
+	if err != nil {
+		return err
+	}
+	for i := range stats {
+		stats[i].ComputedAt = now
+	}
+	if err := s.repo.ReplaceDailyStats(from, today, stats); err != nil {
+		return err
+	}
+	log.Printf("📊 Statistiques quotidiennes de la plateforme cumulées du %s au %s",
+		from.Format(time.DateOnly), today.AddDate(0, 0, -1).Format(time.DateOnly))
+	return nil
 }
 
-func (s *AdminStatsService) GetDashboard(days int) (*models.AdminDashboard, error) {
-	dashboard := &models.AdminDashboard{}
+// StartNightlyRollup rattrape les cumuls manquants puis les recalcule
+// chaque nuit.
+func (s *AdminStatsService) StartNightlyRollup() {
+	go func() {
+		for {
+			if err := s.RollupDaily(); err != nil {
+				logger.LogError(err, "platform_daily_stats_failed", nil)
+			}
+			now := time.Now().UTC()
+			next := utcDay(now).Add(dailyStatsHour * time.Hour)
+			if !next.After(now) {
+				next = next.AddDate(0, 0, 1)
+			}
+			time.Sleep(next.Sub(now))
+		}
+	}()
+}
 
+// GetDashboard rassemble les statistiques du tableau de bord ; la première
+// erreur interrompt le calcul.
+func (s *AdminStatsService) GetDashboard(days int) (*models.AdminDashboard, error) {
 	stats, err := s.GetBasicStats(days)
 	if err != nil {
 		return nil, err
 	}
-	dashboard.Stats = *stats
-
 	topCreators, err := s.GetTopCreators(5, days)
 	if err != nil {
-		topCreators = []models.SimpleCreatorRank{}
+		return nil, err
 	}
-	dashboard.TopCreators = topCreators
-
 	topContents, err := s.GetTopContents(5, days)
 	if err != nil {
-		topContents = []models.SimpleContentRank{}
+		return nil, err
 	}
-	dashboard.TopContents = topContents
-
 	flopContents, err := s.GetFlopContents(5, days)
 	if err != nil {
-		flopContents = []models.SimpleContentRank{}
+		return nil, err
 	}
-	dashboard.FlopContents = flopContents
-
 	recentRevenue, err := s.GetRevenueByDay(7)
 	if err != nil {
-		recentRevenue = []models.RevenueByPeriod{}
+		return nil, err
 	}
-	dashboard.RecentRevenue = recentRevenue
-
-	return dashboard, nil
+	return &models.AdminDashboard{
+		Stats:         *stats,
+		TopCreators:   topCreators,
+		TopContents:   topContents,
+		FlopContents:  flopContents,
+		RecentRevenue: recentRevenue,
+	}, nil
 }
 
 func (s *AdminStatsService) GetSimpleDashboard(days int) (*models.AdminDashboard, error) {
@@ -0,0 +1,144 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+var adminStatsNow = time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+
+type adminStatsFixture struct {
+	*testStore
+	svc *services.AdminStatsService
+}
+
+// setupAdminStats : alice (inscrite le 1er juin) encaisse deux abonnements,
+// bruno (ancien) un abonnement dont le paiement a échoué ; son contenu le
+// plus cher n'a aucun engagement.
+func setupAdminStats(t *testing.T) *adminStatsFixture {
+	f := &adminStatsFixture{
+		testStore: newTestStore(t, &models.User{}, &models.Content{}, &models.Subscription{},
+			&models.Payment{}, &models.Like{}, &models.Comment{}, &models.ContentEvent{}, &models.PlatformDailyStat{}),
+		svc: services.NewAdminStatsService(repositories.NewAdminStatsRepository()).
+			WithClock(func() time.Time { return adminStatsNow })}
+	june := func(d, h int) time.Time { return time.Date(2026, 6, d, h, 0, 0, 0, time.UTC) }
+	old := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	alice := f.user("alice", models.RoleCreator, joined(june(1, 8)))
+	bruno := f.user("bruno", models.RoleCreator, joined(old))
+	fan1 := f.user("fan1", models.RoleSubscriber, joined(june(10, 8)))
+	fan2 := f.user("fan2", models.RoleSubscriber, joined(june(14, 8)))
+	fan3 := f.user("fan3", models.RoleSubscriber, joined(old))
+
+	f.paid(fan1, alice, june(10, 9), models.StatusSucceeded)
+	f.paid(fan2, alice, june(15, 9), models.StatusSucceeded)
+	f.paid(fan3, bruno, june(12, 9), models.StatusFailed)
+
+	hit := f.content(alice, "hit", models.ContentStatusApproved, june(5, 10), priced(5))
+	seen := f.content(alice, "seen", models.ContentStatusApproved, june(6, 10), priced(5))
+	f.content(alice, "waiting", models.ContentStatusPending, june(7, 10), priced(5))
+	f.content(bruno, "pricey", models.ContentStatusApproved, june(8, 10), priced(100))
+	f.content(bruno, "archive", models.ContentStatusApproved, old, priced(100))
+
+	for _, fan := range []*models.User{fan1, fan2} {
+		f.like(hit, fan, june(11, 10))
+	}
+	f.create(&models.Comment{ContentID: hit.ID, AuthorID: fan1.ID, Text: "superbe",
+		Status: models.TextStatusVisible, CreatedAt: june(11, 11)})
+	for range 3 {
+		f.create(&models.ContentEvent{ContentID: seen.ID, UserID: &fan3.ID,
+			Type: models.ContentEventView, OccurredAt: june(12, 10)})
+	}
+	return f
+}
+
+func TestAdminStats_BasicStatsUsePayments(t *testing.T) {
+	f := setupAdminStats(t)
+
+	stats, err := f.svc.GetBasicStats(30)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, stats.TotalUsers)
+	assert.EqualValues(t, 1, stats.TotalCreators)
+	assert.EqualValues(t, 4, stats.TotalContents)
+	assert.EqualValues(t, 1, stats.PendingContents)
+	assert.EqualValues(t, 3, stats.ApprovedContents)
+	// Le paiement échoué de fan3 ne compte pas.
+	assert.EqualValues(t, 6000, stats.TotalRevenue)
+	assert.EqualValues(t, 3, stats.TotalSubscribers)
+}
+
+func TestAdminStats_Rankings(t *testing.T) {
+	f := setupAdminStats(t)
+
+	creators, err := f.svc.GetTopCreators(10, 30)
+	require.NoError(t, err)
+	require.Len(t, creators, 2)
+	assert.Equal(t, "alice", creators[0].Username)
+	assert.Equal(t, 1, creators[0].Rank)
+	assert.EqualValues(t, 6000, creators[0].TotalRevenue)
+	assert.EqualValues(t, 2, creators[0].Subscribers)
+	assert.EqualValues(t, 3, creators[0].ContentCount)
+	assert.EqualValues(t, 0, creators[1].TotalRevenue)
+	assert.EqualValues(t, 1, creators[1].Subscribers)
+
+	top, err := f.svc.GetTopContents(10, 30)
+	require.NoError(t, err)
+	require.Len(t, top, 3)
+	assert.Equal(t, []string{"hit", "seen", "pricey"}, []string{top[0].Title, top[1].Title, top[2].Title})
+	assert.EqualValues(t, 2, top[0].Likes)
+	assert.EqualValues(t, 1, top[0].Comments)
+	assert.InDelta(t, 4.0, top[0].Score, 1e-9)
+	assert.EqualValues(t, 3, top[1].Views)
+
+	flop, err := f.svc.GetFlopContents(1, 30)
+	require.NoError(t, err)
+	require.Len(t, flop, 1)
+	assert.Equal(t, "pricey", flop[0].Title)
+}
+
+func TestAdminStats_DailyRollup(t *testing.T) {
+	f := setupAdminStats(t)
+	require.NoError(t, f.svc.RollupDaily())
+
+	var stored []models.PlatformDailyStat
+	require.NoError(t, f.db.Order("day").Find(&stored).Error)
+	require.NotEmpty(t, stored)
+	assert.True(t, stored[len(stored)-1].Day.Before(time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)),
+		"le jour en cours n'est pas matérialisé")
+
+	days, err := f.svc.GetDailyStats(7)
+	require.NoError(t, err)
+	require.Len(t, days, 7)
+	byDay := map[int]models.PlatformDailyStat{}
+	for _, d := range days {
+		byDay[d.Day.Day()] = d
+	}
+	assert.EqualValues(t, 1, byDay[10].NewUsers)
+	assert.EqualValues(t, 3000, byDay[10].RevenueCents)
+	assert.EqualValues(t, 2, byDay[11].Likes)
+	assert.EqualValues(t, 1, byDay[11].Comments)
+	assert.EqualValues(t, 1, byDay[12].NewSubscriptions)
+	assert.EqualValues(t, 0, byDay[12].RevenueCents)
+	assert.EqualValues(t, 3, byDay[12].Views)
+	// Aujourd'hui est calculé à la volée.
+	assert.EqualValues(t, 3000, byDay[15].RevenueCents)
+
+	revenue, err := f.svc.GetRevenueByDay(7)
+	require.NoError(t, err)
+	require.Len(t, revenue, 7)
+	assert.EqualValues(t, 3000, revenue[1].Amount)
+	assert.EqualValues(t, 3000, revenue[6].Amount)
+
+	// Un second passage ne recalcule que depuis le dernier jour cumulé.
+	require.NoError(t, f.svc.RollupDaily())
+	var again int64
+	require.NoError(t, f.db.Model(&models.PlatformDailyStat{}).Count(&again).Error)
+	assert.EqualValues(t, len(stored), again)
+}
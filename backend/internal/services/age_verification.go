@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var (
+	ErrInvalidBirthDate         = errors.New("date de naissance invalide")
+	ErrAgeAlreadyVerified       = errors.New("âge déjà vérifié")
+	ErrBirthDateLocked          = errors.New("date de naissance déjà déclarée, seule la modération peut la réinitialiser")
+	ErrNoPendingAgeVerification = errors.New("aucune vérification d'âge en attente")
+	ErrUserNotFound             = errors.New("utilisateur non trouvé")
+	ErrMatureRestricted         = errors.New("contenu réservé aux adultes dont l'âge est vérifié")
+	ErrInvalidMaturity          = errors.New("classification invalide (general, mature ou explicit)")
+	ErrMaturityLocked           = errors.New("classification imposée par la modération")
+)
+
+// maxDeclaredAge borne les dates de naissance plausibles.
+const maxDeclaredAge = 120
+
+// AgeVerificationService gère la vérification d'âge et décide si un lecteur
+// peut recevoir des contenus adultes.
+type AgeVerificationService struct {
+	users *repositories.UserRepository
+	now   func() time.Time
+}
+
+func NewAgeVerificationService(users *repositories.UserRepository) *AgeVerificationService {
+	return &AgeVerificationService{users: users, now: time.Now}
+}
+
+// AllowsMature indique si le lecteur peut recevoir des contenus adultes. Un
+// visiteur anonyme ou un service non configuré n'y a jamais accès.
+func (s *AgeVerificationService) AllowsMature(viewerID uuid.UUID) bool {
+	if s == nil || viewerID == uuid.Nil {
+		return false
+	}
+	user, err := s.users.FindByID(viewerID)
+	if err != nil || user == nil {
+		return false
+	}
+	return user.CanViewMature(s.now())
+}
+
+// SubmitBirthDate enregistre la date de naissance déclarée. Un utilisateur
+// majeur passe en attente de vérification par la modération ; un mineur reste
+// non vérifié (son état est déduit de la date). La date déclarée ne peut plus
+// changer ensuite, sauf réinitialisation par un admin.
+func (s *AgeVerificationService) SubmitBirthDate(userID uuid.UUID, birthDate time.Time) (*models.User, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.AgeVerificationStatus == models.AgeVerificationVerified {
+		return nil, ErrAgeAlreadyVerified
+	}
+	if user.BirthDate != nil {
+		return nil, ErrBirthDateLocked
+	}
+
+	now := s.now()
+	birthDate = time.Date(birthDate.Year(), birthDate.Month(), birthDate.Day(), 0, 0, 0, 0, time.UTC)
+	if birthDate.After(now) || birthDate.Before(now.AddDate(-maxDeclaredAge, 0, 0)) {
+		return nil, ErrInvalidBirthDate
+	}
+
+	user.BirthDate = &birthDate
+	if user.Age(now) >= models.AdultAge {
+		user.AgeVerificationStatus = models.AgeVerificationPending
+	} else {
+		user.AgeVerificationStatus = models.AgeVerificationUnverified
+	}
+	if err := s.users.Update(user); err != nil {
+		return nil, err
+	}
+	log.Printf("🔞 Vérification d'âge demandée par %s : %s", userID, user.AgeVerificationState(now))
+	return user, nil
+}
+
+// ReviewAgeVerification valide ou refuse une demande en attente (admin).
+func (s *AgeVerificationService) ReviewAgeVerification(userID uuid.UUID, approved bool) (*models.User, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.AgeVerificationStatus != models.AgeVerificationPending {
+		return nil, ErrNoPendingAgeVerification
+	}
+
+	now := s.now()
+	if approved && user.Age(now) >= models.AdultAge {
+		user.AgeVerificationStatus = models.AgeVerificationVerified
+		user.AgeVerifiedAt = &now
+	} else {
+		user.AgeVerificationStatus = models.AgeVerificationRejected
+		user.AgeVerifiedAt = nil
+	}
+	if err := s.users.Update(user); err != nil {
+		return nil, err
+	}
+	log.Printf("🔞 Vérification d'âge de %s : %s", userID, user.AgeVerificationStatus)
+	return user, nil
+}
+
+// ResetBirthDate efface la date de naissance déclarée et la vérification
+// (admin), pour corriger une erreur de saisie : l'utilisateur peut alors en
+// déclarer une nouvelle.
+func (s *AgeVerificationService) ResetBirthDate(userID uuid.UUID) (*models.User, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	user.BirthDate = nil
+	user.AgeVerificationStatus = models.AgeVerificationUnverified
+	user.AgeVerifiedAt = nil
+	if err := s.users.Update(user); err != nil {
+		return nil, err
+	}
+	log.Printf("🔞 Date de naissance de %s réinitialisée", userID)
+	return user, nil
+}
+
+// PendingAgeVerifications liste les demandes à examiner.
+func (s *AgeVerificationService) PendingAgeVerifications() ([]models.User, error) {
+	return s.users.FindByAgeVerificationStatus(models.AgeVerificationPending)
+}
+
+// SetHideMatureContent enregistre le choix de l'utilisateur de ne plus
+// recevoir de contenus adultes, même vérifié.
+func (s *AgeVerificationService) SetHideMatureContent(userID uuid.UUID, hide bool) (*models.User, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	user.HideMatureContent = hide
+	if err := s.users.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// State renvoie l'état effectif de vérification d'un utilisateur.
+func (s *AgeVerificationService) State(user *models.User) string {
+	return user.AgeVerificationState(s.now())
+}
+
+func (s *AgeVerificationService) findUser(userID uuid.UUID) (*models.User, error) {
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
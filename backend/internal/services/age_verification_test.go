@@ -0,0 +1,114 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+func TestAgeVerification_MatureContentGating(t *testing.T) {
+	contents, repo, db := setupContentLifecycle(t)
+	ages := services.NewAgeVerificationService(repositories.NewUserRepository())
+
+	adult := &models.User{Username: "adulte", Email: "a@test", HashedPassword: "x", Role: models.RoleSubscriber}
+	minor := &models.User{Username: "mineur", Email: "m@test", HashedPassword: "x", Role: models.RoleSubscriber}
+	require.NoError(t, db.Create(adult).Error)
+	require.NoError(t, db.Create(minor).Error)
+
+	creatorID := uuid.New()
+	general := &models.Content{CreatorID: creatorID, Title: "Paysage", Body: "b", Price: 3, FilePath: "g.jpg",
+		Status: models.ContentStatusApproved, Maturity: models.MaturityGeneral}
+	mature := &models.Content{CreatorID: creatorID, Title: "Nu", Body: "b", Price: 3, FilePath: "m.jpg",
+		Status: models.ContentStatusApproved, Maturity: models.MaturityMature}
+	require.NoError(t, db.Create(general).Error)
+	require.NoError(t, db.Create(mature).Error)
+
+	// Mineur : état déduit de la date, jamais d'accès.
+	minorUser, err := ages.SubmitBirthDate(minor.ID, time.Now().AddDate(-15, 0, 0))
+	require.NoError(t, err)
+	assert.Equal(t, models.AgeVerificationMinor, ages.State(minorUser))
+	_, err = ages.ReviewAgeVerification(minor.ID, true)
+	assert.ErrorIs(t, err, services.ErrNoPendingAgeVerification)
+	assert.False(t, ages.AllowsMature(minor.ID))
+
+	// Majeur : en attente tant que la modération n'a pas validé.
+	adultUser, err := ages.SubmitBirthDate(adult.ID, time.Date(1990, 5, 12, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, models.AgeVerificationPending, ages.State(adultUser))
+	assert.False(t, ages.AllowsMature(adult.ID))
+
+	_, err = ages.ReviewAgeVerification(adult.ID, true)
+	require.NoError(t, err)
+	assert.True(t, ages.AllowsMature(adult.ID))
+	_, err = ages.SubmitBirthDate(adult.ID, time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, services.ErrAgeAlreadyVerified)
+
+	list, err := contents.GetAllContents(ages.AllowsMature(minor.ID))
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, general.ID, list[0].ID)
+
+	list, err = contents.GetAllContents(ages.AllowsMature(adult.ID))
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	// Désactivation volontaire.
+	_, err = ages.SetHideMatureContent(adult.ID, true)
+	require.NoError(t, err)
+	assert.False(t, ages.AllowsMature(adult.ID))
+	assert.False(t, ages.AllowsMature(uuid.Nil))
+
+	// Classification imposée par la modération : le créateur ne peut plus la changer.
+	_, err = contents.OverrideMaturity(general.ID, uuid.New(), models.MaturityExplicit)
+	require.NoError(t, err)
+	locked, err := repo.FindByID(general.ID)
+	require.NoError(t, err)
+	assert.ErrorIs(t, contents.SetMaturity(locked, models.MaturityGeneral), services.ErrMaturityLocked)
+}
+
+func TestSetMaturity_DowngradeSendsBackToModeration(t *testing.T) {
+	contents, _, db := setupContentLifecycle(t)
+	content := &models.Content{CreatorID: uuid.New(), Title: "t", Body: "b", Price: 3, FilePath: "x.jpg",
+		Status: models.ContentStatusApproved, Maturity: models.MaturityMature}
+	require.NoError(t, db.Create(content).Error)
+
+	assert.ErrorIs(t, contents.SetMaturity(content, "adult"), services.ErrInvalidMaturity)
+
+	require.NoError(t, contents.SetMaturity(content, models.MaturityExplicit))
+	assert.Equal(t, models.ContentStatusApproved, content.Status)
+
+	require.NoError(t, contents.SetMaturity(content, models.MaturityGeneral))
+	assert.Equal(t, models.ContentStatusPending, content.Status)
+}
+
+func TestAgeVerification_BirthDateCannotBeRedeclared(t *testing.T) {
+	_, _, db := setupContentLifecycle(t)
+	ages := services.NewAgeVerificationService(repositories.NewUserRepository())
+	minor := &models.User{Username: "mineur", Email: "m@test", HashedPassword: "x", Role: models.RoleSubscriber}
+	require.NoError(t, db.Create(minor).Error)
+
+	_, err := ages.SubmitBirthDate(minor.ID, time.Now().AddDate(-15, 0, 0))
+	require.NoError(t, err)
+
+	// Un mineur ne peut pas se redéclarer majeur pour repasser en attente.
+	_, err = ages.SubmitBirthDate(minor.ID, time.Date(1990, 5, 12, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, services.ErrBirthDateLocked)
+	pending, err := ages.PendingAgeVerifications()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// Après réinitialisation par un admin, une nouvelle déclaration est possible.
+	reset, err := ages.ResetBirthDate(minor.ID)
+	require.NoError(t, err)
+	assert.Nil(t, reset.BirthDate)
+	user, err := ages.SubmitBirthDate(minor.ID, time.Date(1990, 5, 12, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, models.AgeVerificationPending, user.AgeVerificationStatus)
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+// auditGenesisHash précède la première entrée du journal.
+const auditGenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// auditVerifyBatch est la taille des lots relus lors de la vérification.
+const auditVerifyBatch = 500
+
+// AuditRecord décrit une action d'administration à journaliser. Before et
+// After sont des instantanés sérialisés en JSON (nil si sans objet).
+type AuditRecord struct {
+	ActorID    uuid.UUID
+	ActorRole  string
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     interface{}
+	After      interface{}
+	IP         string
+	RequestID  string
+}
+
+// AuditVerification est le résultat du contrôle d'intégrité du journal.
+type AuditVerification struct {
+	Valid    bool   `json:"valid"`
+	Checked  int    `json:"checked"`
+	BrokenAt *int64 `json:"broken_at,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// AuditService tient le journal d'audit chaîné des actions d'administration.
+type AuditService struct {
+	repo *repositories.AuditRepository
+	now  func() time.Time
+}
+
+func NewAuditService(repo *repositories.AuditRepository) *AuditService {
+	return &AuditService{repo: repo, now: time.Now}
+}
+
+// Record ajoute une entrée au journal. Sans service configuré, l'action est
+// seulement écrite dans les logs applicatifs.
+func (s *AuditService) Record(rec AuditRecord) (*models.AuditEntry, error) {
+	logger.LogAdmin(rec.Action, rec.ActorID.String(), rec.TargetType, rec.TargetID, map[string]interface{}{
+		"ip":         rec.IP,
+		"request_id": rec.RequestID,
+	})
+	if s == nil {
+		return nil, nil
+	}
+
+	before, err := auditSnapshot(rec.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := auditSnapshot(rec.After)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chaque entrée dépend de la précédente : le dépôt sérialise les ajouts
+	// par un verrou en base, valable pour toutes les instances.
+	entry, err := s.repo.AppendNext(func(last *models.AuditEntry) *models.AuditEntry {
+		entry := &models.AuditEntry{
+			ID:         uuid.New(),
+			Seq:        1,
+			ActorID:    rec.ActorID,
+			ActorRole:  rec.ActorRole,
+			Action:     rec.Action,
+			TargetType: rec.TargetType,
+			TargetID:   rec.TargetID,
+			Before:     before,
+			After:      after,
+			IP:         rec.IP,
+			RequestID:  rec.RequestID,
+			// Précision de PostgreSQL : le hash doit survivre à l'aller-retour en base.
+			CreatedAt: s.now().UTC().Truncate(time.Microsecond),
+			PrevHash:  auditGenesisHash,
+		}
+		if last != nil {
+			entry.Seq = last.Seq + 1
+			entry.PrevHash = last.Hash
+		}
+		entry.Hash = auditHash(entry)
+		return entry
+	})
+	if err != nil {
+		return nil, fmt.Errorf("journal d'audit: %w", err)
+	}
+	return entry, nil
+}
+
+// List renvoie une page du journal filtré, les entrées récentes d'abord.
+func (s *AuditService) List(filter repositories.AuditFilter, limit, offset int) ([]models.AuditEntry, int64, error) {
+	limit, offset = Pagination(limit, offset)
+	return s.repo.Find(filter, limit, offset)
+}
+
+// Verify relit toute la chaîne et signale la première entrée altérée,
+// supprimée ou insérée après coup.
+func (s *AuditService) Verify() (*AuditVerification, error) {
+	result := &AuditVerification{Valid: true}
+	prevHash, prevSeq := auditGenesisHash, int64(0)
+	for {
+		batch, err := s.repo.FindAfter(prevSeq, auditVerifyBatch)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			return result, nil
+		}
+		for i := range batch {
+			e := &batch[i]
+			switch {
+			case e.Seq != prevSeq+1:
+				result.fail(e.Seq, fmt.Sprintf("entrées manquantes entre %d et %d", prevSeq, e.Seq))
+			case e.PrevHash != prevHash:
+				result.fail(e.Seq, "chaînage rompu avec l'entrée précédente")
+			case auditHash(e) != e.Hash:
+				result.fail(e.Seq, "contenu de l'entrée modifié")
+			}
+			if !result.Valid {
+				return result, nil
+			}
+			result.Checked++
+			prevHash, prevSeq = e.Hash, e.Seq
+		}
+	}
+}
+
+func (v *AuditVerification) fail(seq int64, reason string) {
+	v.Valid = false
+	v.BrokenAt = &seq
+	v.Reason = reason
+}
+
+// auditHash calcule le hash d'une entrée à partir de tous ses champs et du
+// hash de la précédente.
+func auditHash(e *models.AuditEntry) string {
+	fields := []string{
+		strconv.FormatInt(e.Seq, 10),
+		e.PrevHash,
+		e.ID.String(),
+		e.ActorID.String(),
+		e.ActorRole,
+		e.Action,
+		e.TargetType,
+		e.TargetID,
+		e.Before,
+		e.After,
+		e.IP,
+		e.RequestID,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+func auditSnapshot(v interface{}) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("instantané d'audit: %w", err)
+	}
+	return string(data), nil
+}
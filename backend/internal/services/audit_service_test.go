@@ -0,0 +1,70 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+func TestAuditLog_ChainsEntriesAndDetectsTampering(t *testing.T) {
+	_, _, db := setupContentLifecycle(t)
+	require.NoError(t, database.MigrateSQLite(db, &models.AuditEntry{}))
+	audit := services.NewAuditService(repositories.NewAuditRepository())
+
+	adminID, userID := uuid.New(), uuid.New()
+	first, err := audit.Record(services.AuditRecord{
+		ActorID: adminID, ActorRole: string(models.RoleAdmin),
+		Action: models.AuditUserRoleChanged, TargetType: "user", TargetID: userID.String(),
+		Before: map[string]string{"role": "subscriber"}, After: map[string]string{"role": "creator"},
+		IP: "10.0.0.1", RequestID: "req-1",
+	})
+	require.NoError(t, err)
+	second, err := audit.Record(services.AuditRecord{
+		ActorID: adminID, Action: models.AuditFeatureToggled, TargetType: "feature", TargetID: "chat",
+		Before: map[string]bool{"enabled": true}, After: map[string]bool{"enabled": false},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), first.Seq)
+	assert.Equal(t, int64(2), second.Seq)
+	assert.Equal(t, first.Hash, second.PrevHash)
+
+	entries, total, err := audit.List(repositories.AuditFilter{TargetType: "user", TargetID: userID.String()}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, entries, 1)
+	assert.JSONEq(t, `{"role":"creator"}`, entries[0].After)
+	assert.Equal(t, "req-1", entries[0].RequestID)
+
+	result, err := audit.Verify()
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, 2, result.Checked)
+
+	// Réécrire un instantané sans recalculer le hash casse la chaîne.
+	require.NoError(t, db.Model(&models.AuditEntry{}).Where("seq = ?", 1).
+		Update("after", `{"role":"subscriber"}`).Error)
+	result, err = audit.Verify()
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	require.NotNil(t, result.BrokenAt)
+	assert.Equal(t, int64(1), *result.BrokenAt)
+
+	// Une entrée supprimée laisse un trou dans la séquence.
+	require.NoError(t, db.Model(&models.AuditEntry{}).Where("seq = ?", 1).
+		Update("after", first.After).Error)
+	_, err = audit.Record(services.AuditRecord{ActorID: adminID, Action: models.AuditCategoryDeleted, TargetType: "category", TargetID: "x"})
+	require.NoError(t, err)
+	require.NoError(t, db.Where("seq = ?", 2).Delete(&models.AuditEntry{}).Error)
+	result, err = audit.Verify()
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, int64(3), *result.BrokenAt)
+	assert.Equal(t, 1, result.Checked)
+}
@@ -12,9 +12,12 @@ import (
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
 )
 
+var ErrInvalidCredentials = errors.New("identifiants invalides")
+
 type AuthService struct {
-	userRepo *repositories.UserRepository
-	jwtKey   []byte
+	userRepo  *repositories.UserRepository
+	jwtKey    []byte
+	sanctions *SanctionService
 }
 
 func NewAuthService(repo *repositories.UserRepository) *AuthService {
@@ -24,6 +27,11 @@ func NewAuthService(repo *repositories.UserRepository) *AuthService {
 	}
 }
 
+// SetSanctions active le refus de connexion des comptes bannis ou suspendus.
+func (s *AuthService) SetSanctions(sanctions *SanctionService) {
+	s.sanctions = sanctions
+}
+
 // Register crée un nouvel utilisateur avec un mot de passe hashé
 func (s *AuthService) Register(username, email, password string, role models.Role) (*models.User, error) {
 	existing, err := s.userRepo.FindByEmail(email)
@@ -61,11 +69,14 @@ func (s *AuthService) Login(email, password string) (string, error) {
 		return "", err
 	}
 	if user == nil {
-		return "", errors.New("identifiants invalides")
+		return "", ErrInvalidCredentials
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.HashedPassword), []byte(password)); err != nil {
-		return "", errors.New("identifiants invalides")
+		return "", ErrInvalidCredentials
+	}
+	if err := s.sanctions.CheckAccess(user.ID); err != nil {
+		return "", err
 	}
 
 	expirationTime := time.Now().Add(72 * time.Hour)
@@ -14,24 +14,38 @@ type CommentWithMeta struct {
 }
 
 type CommentService struct {
-	repo     *repositories.CommentRepository
-	likeRepo *repositories.CommentLikeRepository
-	userRepo *repositories.UserRepository
+	repo      *repositories.CommentRepository
+	likeRepo  *repositories.CommentLikeRepository
+	userRepo  *repositories.UserRepository
+	sanctions *SanctionService
+	text      *TextModerationService
+	events    *ContentEventService
 }
 
 func NewCommentService(
 	repo *repositories.CommentRepository,
 	likeRepo *repositories.CommentLikeRepository,
 	userRepo *repositories.UserRepository,
+	sanctions *SanctionService,
 ) *CommentService {
-	return &CommentService{repo: repo, likeRepo: likeRepo, userRepo: userRepo}
+	return &CommentService{repo: repo, likeRepo: likeRepo, userRepo: userRepo, sanctions: sanctions}
+}
+
+// SetTextModeration branche la modération automatique des commentaires.
+func (s *CommentService) SetTextModeration(text *TextModerationService) {
+	s.text = text
+}
+
+// SetEventRecorder branche le comptage des commentaires par contenu.
+func (s *CommentService) SetEventRecorder(events *ContentEventService) {
+	s.events = events
 }
 
 // FetchComments récupère tous les commentaires associés à un contenu,
 // avec métadonnées (likes + likedByMe).
 // userID est l'utilisateur courant (extrait du JWT) pour le flag LikedByMe.
 func (s *CommentService) FetchComments(contentID, userID uuid.UUID) ([]CommentWithMeta, error) {
-	raw, err := s.repo.FindAllByContent(contentID)
+	raw, err := s.repo.FindAllByContent(contentID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -60,22 +74,38 @@ func (s *CommentService) FetchComments(contentID, userID uuid.UUID) ([]CommentWi
 	return out, nil
 }
 
-// PostComment crée un commentaire (optionnellement en réponse à un parent)
+// PostComment crée un commentaire (optionnellement en réponse à un parent).
+// Un commentaire retenu par la modération reste visible de son seul auteur.
 func (s *CommentService) PostComment(
 	contentID uuid.UUID,
 	authorID uuid.UUID,
 	text string,
 	parentID *uuid.UUID,
 ) (*models.Comment, error) {
+	if err := s.sanctions.Ensure(authorID, models.SanctionRestrictCommenting); err != nil {
+		return nil, err
+	}
+	text, rating, err := s.text.Screen(models.TextTargetComment, authorID, text)
+	if err != nil {
+		return nil, err
+	}
 	comment := &models.Comment{
+		ID:        uuid.New(),
 		ContentID: contentID,
 		AuthorID:  authorID,
 		Text:      text,
 		ParentID:  parentID,
+		Status:    rating.Visibility(),
 	}
-	if err := s.repo.Create(comment); err != nil {
+	if err := repositories.InTransaction(
+		s.repo.CreateStep(comment),
+		s.text.HoldStep(models.TextTargetComment, comment.ID, authorID, text, rating),
+	); err != nil {
 		return nil, err
 	}
+	if comment.Status == models.TextStatusVisible {
+		s.events.Record(models.ContentEventComment, contentID, authorID)
+	}
 	return comment, nil
 }
 
@@ -107,6 +137,11 @@ func (s *CommentService) ListAllComments(page, pageSize int) ([]models.Comment,
 	return s.repo.ListAll(offset, pageSize)
 }
 
+// GetCommentByID renvoie un commentaire, ou nil s'il n'existe pas.
+func (s *CommentService) GetCommentByID(id uuid.UUID) (*models.Comment, error) {
+	return s.repo.FindByID(id)
+}
+
 // DeleteCommentByID supprime un commentaire quel que soit son auteur.
 func (s *CommentService) DeleteCommentByID(id uuid.UUID) error {
 	return s.repo.DeleteByID(id)
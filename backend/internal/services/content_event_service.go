@@ -0,0 +1,393 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var (
+	ErrAnalyticsForbidden = errors.New("ces statistiques ne vous appartiennent pas")
+	ErrInvalidDateRange   = errors.New("période invalide")
+)
+
+const (
+	// viewDedupWindow : une vue (ou impression) par utilisateur et par
+	// contenu est comptée au plus une fois par fenêtre.
+	viewDedupWindow = 30 * time.Minute
+	// conversionWindow : un abonnement est attribué au dernier contenu du
+	// créateur vu dans cette période.
+	conversionWindow = 7 * 24 * time.Hour
+	// eventRetention : les événements bruts plus anciens sont purgés, seuls
+	// les cumuls quotidiens restent.
+	eventRetention = 90 * 24 * time.Hour
+
+	eventQueueSize = 10000
+	eventBatchSize = 200
+
+	defaultAnalyticsDays = 30
+	maxAnalyticsDays     = 366
+)
+
+// ContentEventService enregistre les événements sur les contenus (vues,
+// impressions, téléchargements, likes, commentaires, abonnements attribués)
+// et les cumule par jour. Une fois démarré, l'enregistrement passe par une
+// file écrite par lots en arrière-plan ; sinon il est immédiat.
+type ContentEventService struct {
+	repo     *repositories.ContentEventRepository
+	contents *repositories.ContentRepository
+	queue    chan models.ContentEvent
+	// flushes demande l'écriture immédiate de la file ; stop l'arrête après
+	// une dernière écriture, signalée par la fermeture de stopped.
+	flushes  chan chan struct{}
+	stop     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+	now      func() time.Time
+	mu       sync.Mutex
+}
+
+func NewContentEventService(repo *repositories.ContentEventRepository, contents *repositories.ContentRepository) *ContentEventService {
+	return &ContentEventService{repo: repo, contents: contents, now: time.Now}
+}
+
+// WithClock remplace l'horloge des événements, pour des tests déterministes.
+func (s *ContentEventService) WithClock(now func() time.Time) *ContentEventService {
+	s.now = now
+	return s
+}
+
+// Start lance l'écriture par lots des événements et le cumul périodique.
+func (s *ContentEventService) Start(flushEvery, rollupEvery time.Duration) {
+	s.queue = make(chan models.ContentEvent, eventQueueSize)
+	s.flushes = make(chan chan struct{})
+	s.stop = make(chan struct{})
+	s.stopped = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(flushEvery)
+		defer ticker.Stop()
+		batch := make([]models.ContentEvent, 0, eventBatchSize)
+		for {
+			select {
+			case ev := <-s.queue:
+				if batch = append(batch, ev); len(batch) < eventBatchSize {
+					continue
+				}
+			case <-ticker.C:
+			case done := <-s.flushes:
+				s.write(s.drain(batch))
+				batch = batch[:0]
+				close(done)
+				continue
+			case <-s.stop:
+				s.write(s.drain(batch))
+				close(s.stopped)
+				return
+			}
+			s.write(batch)
+			batch = batch[:0]
+		}
+	}()
+	go func() {
+		ticker := time.NewTicker(rollupEvery)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			if err := s.Rollup(now); err != nil {
+				logger.LogError(err, "content_events_rollup_failed", nil)
+			}
+		}
+	}()
+}
+
+// Flush écrit sans attendre les événements en file.
+func (s *ContentEventService) Flush() {
+	if s == nil || s.queue == nil {
+		return
+	}
+	done := make(chan struct{})
+	select {
+	case s.flushes <- done:
+		<-done
+	case <-s.stopped:
+	}
+}
+
+// Stop écrit les événements encore en file puis arrête l'écriture par lots ;
+// à appeler à l'arrêt du serveur, une fois les requêtes terminées.
+func (s *ContentEventService) Stop() {
+	if s == nil || s.queue == nil {
+		return
+	}
+	s.stopOnce.Do(func() { close(s.stop) })
+	<-s.stopped
+}
+
+// drain ajoute au lot tout ce qui attend dans la file.
+func (s *ContentEventService) drain(batch []models.ContentEvent) []models.ContentEvent {
+	for {
+		select {
+		case ev := <-s.queue:
+			batch = append(batch, ev)
+		default:
+			return batch
+		}
+	}
+}
+
+func (s *ContentEventService) write(events []models.ContentEvent) {
+	if err := s.repo.Insert(events); err != nil {
+		log.Printf("⚠️ %d événements de contenu perdus : %v", len(events), err)
+	}
+}
+
+// Record enregistre un événement d'un utilisateur sur un contenu. Sans
+// service configuré, l'appel est sans effet.
+func (s *ContentEventService) Record(kind string, contentID, userID uuid.UUID) {
+	if s == nil {
+		return
+	}
+	s.enqueue(s.event(kind, contentID, userID, s.now()))
+}
+
+// RecordImpressions enregistre l'affichage de contenus dans un fil.
+func (s *ContentEventService) RecordImpressions(contentIDs []uuid.UUID, userID uuid.UUID) {
+	if s == nil {
+		return
+	}
+	now := s.now()
+	for _, id := range contentIDs {
+		s.enqueue(s.event(models.ContentEventImpression, id, userID, now))
+	}
+}
+
+// RecordConversion attribue un nouvel abonnement au dernier contenu du
+// créateur vu par l'abonné dans la fenêtre de conversion. La file est d'abord
+// écrite : la vue qui a précédé l'abonnement peut y attendre encore.
+func (s *ContentEventService) RecordConversion(subscriberID, creatorID uuid.UUID) {
+	if s == nil {
+		return
+	}
+	s.Flush()
+	now := s.now()
+	contentID, err := s.repo.LastViewedContent(subscriberID, creatorID, now.Add(-conversionWindow))
+	if err != nil {
+		log.Printf("⚠️ Attribution de l'abonnement de %s à %s : %v", subscriberID, creatorID, err)
+		return
+	}
+	if contentID != nil {
+		s.enqueue(s.event(models.ContentEventSubscription, *contentID, subscriberID, now))
+	}
+}
+
+func (s *ContentEventService) event(kind string, contentID, userID uuid.UUID, at time.Time) models.ContentEvent {
+	ev := models.ContentEvent{ContentID: contentID, Type: kind, OccurredAt: at.UTC()}
+	if userID == uuid.Nil {
+		return ev
+	}
+	ev.UserID = &userID
+	var key string
+	switch kind {
+	case models.ContentEventView, models.ContentEventImpression:
+		key = fmt.Sprintf("%s:%s:%s:%d", kind, contentID, userID, at.Unix()/int64(viewDedupWindow/time.Second))
+	case models.ContentEventLike:
+		key = fmt.Sprintf("%s:%s:%s", kind, contentID, userID)
+	}
+	if key != "" {
+		ev.DedupKey = &key
+	}
+	return ev
+}
+
+func (s *ContentEventService) enqueue(ev models.ContentEvent) {
+	if s.queue == nil {
+		s.write([]models.ContentEvent{ev})
+		return
+	}
+	select {
+	case s.queue <- ev:
+	default:
+		log.Printf("⚠️ File des événements pleine, événement %s ignoré", ev.Type)
+	}
+}
+
+type dailyKey struct {
+	contentID uuid.UUID
+	day       time.Time
+}
+
+// Rollup recalcule les cumuls quotidiens depuis le dernier jour cumulé
+// (inclus, car il pouvait être incomplet) puis purge les vieux événements.
+func (s *ContentEventService) Rollup(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	from, err := s.repo.LatestStatDay()
+	if err != nil {
+		return err
+	}
+	if from == nil {
+		if from, err = s.repo.OldestEventTime(); err != nil || from == nil {
+			return err
+		}
+	}
+	start := utcDay(*from)
+
+	stats := map[dailyKey]*models.ContentDailyStat{}
+	viewers := map[dailyKey]map[uuid.UUID]struct{}{}
+	err = s.repo.EachEventSince(start, func(ev models.ContentEvent) {
+		key := dailyKey{contentID: ev.ContentID, day: utcDay(ev.OccurredAt)}
+		st := stats[key]
+		if st == nil {
+			st = &models.ContentDailyStat{ContentID: key.contentID, Day: key.day}
+			stats[key] = st
+		}
+		switch ev.Type {
+		case models.ContentEventView:
+			st.Views++
+			if ev.UserID != nil {
+				if viewers[key] == nil {
+					viewers[key] = map[uuid.UUID]struct{}{}
+				}
+				viewers[key][*ev.UserID] = struct{}{}
+			}
+		case models.ContentEventImpression:
+			st.Impressions++
+		case models.ContentEventDownload:
+			st.Downloads++
+		case models.ContentEventLike:
+			st.Likes++
+		case models.ContentEventComment:
+			st.Comments++
+		case models.ContentEventSubscription:
+			st.Subscriptions++
+		}
+	})
+	if err != nil {
+		return err
+	}
+	list := make([]models.ContentDailyStat, 0, len(stats))
+	for key, st := range stats {
+		st.UniqueViewers = int64(len(viewers[key]))
+		list = append(list, *st)
+	}
+	if err := s.repo.ReplaceDailyStats(start, list); err != nil {
+		return err
+	}
+	if err := s.repo.PurgeEvents(now.Add(-eventRetention)); err != nil {
+		return err
+	}
+	log.Printf("📊 Statistiques des contenus cumulées depuis le %s", start.Format(time.DateOnly))
+	return nil
+}
+
+func utcDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// ContentAnalyticsTotals cumule la période ; les taux sont rapportés aux vues.
+type ContentAnalyticsTotals struct {
+	Views          int64   `json:"views"`
+	Impressions    int64   `json:"impressions"`
+	Downloads      int64   `json:"downloads"`
+	Likes          int64   `json:"likes"`
+	Comments       int64   `json:"comments"`
+	Subscriptions  int64   `json:"subscriptions"`
+	ConversionRate float64 `json:"conversion_rate"`
+	EngagementRate float64 `json:"engagement_rate"`
+}
+
+// ContentAnalyticsPoint est un jour de la série.
+type ContentAnalyticsPoint struct {
+	models.ContentDailyStat
+	ConversionRate float64 `json:"conversion_rate"`
+	EngagementRate float64 `json:"engagement_rate"`
+}
+
+// ContentAnalytics sont les statistiques d'un contenu sur une période.
+type ContentAnalytics struct {
+	ContentID uuid.UUID               `json:"content_id"`
+	From      time.Time               `json:"from"`
+	To        time.Time               `json:"to"`
+	Totals    ContentAnalyticsTotals  `json:"totals"`
+	Series    []ContentAnalyticsPoint `json:"series"`
+}
+
+// ContentAnalytics renvoie les statistiques quotidiennes d'un contenu de son
+// créateur, jours sans activité compris, entre les jours from et to inclus
+// (par défaut les 30 derniers jours).
+func (s *ContentEventService) ContentAnalytics(contentID, requesterID uuid.UUID, from, to *time.Time) (*ContentAnalytics, error) {
+	content, err := s.contents.FindByID(contentID)
+	if err != nil {
+		return nil, ErrContentNotFound
+	}
+	if content.CreatorID != requesterID {
+		return nil, ErrAnalyticsForbidden
+	}
+	start, end, err := analyticsRange(from, to, s.now(), defaultAnalyticsDays)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.DailyStats(contentID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	byDay := make(map[time.Time]models.ContentDailyStat, len(stats))
+	for _, st := range stats {
+		byDay[utcDay(st.Day)] = st
+	}
+
+	out := &ContentAnalytics{ContentID: contentID, From: start, To: end.AddDate(0, 0, -1), Series: []ContentAnalyticsPoint{}}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		st := byDay[day]
+		st.ContentID, st.Day = contentID, day
+		out.Series = append(out.Series, ContentAnalyticsPoint{
+			ContentDailyStat: st,
+			ConversionRate:   ratio(st.Subscriptions, st.Views),
+			EngagementRate:   ratio(st.Likes+st.Comments, st.Views),
+		})
+		t := &out.Totals
+		t.Views += st.Views
+		t.Impressions += st.Impressions
+		t.Downloads += st.Downloads
+		t.Likes += st.Likes
+		t.Comments += st.Comments
+		t.Subscriptions += st.Subscriptions
+	}
+	out.Totals.ConversionRate = ratio(out.Totals.Subscriptions, out.Totals.Views)
+	out.Totals.EngagementRate = ratio(out.Totals.Likes+out.Totals.Comments, out.Totals.Views)
+	return out, nil
+}
+
+// analyticsRange ramène une période aux jours UTC : [début, lendemain de la
+// fin). Sans début, elle couvre les defaultDays jours jusqu'à la fin.
+func analyticsRange(from, to *time.Time, now time.Time, defaultDays int) (time.Time, time.Time, error) {
+	end := utcDay(now).AddDate(0, 0, 1)
+	if to != nil {
+		end = utcDay(*to).AddDate(0, 0, 1)
+	}
+	start := end.AddDate(0, 0, -defaultDays)
+	if from != nil {
+		start = utcDay(*from)
+	}
+	if !start.Before(end) || end.Sub(start) > maxAnalyticsDays*24*time.Hour {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	return start, end, nil
+}
+
+func ratio(n, d int64) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
@@ -0,0 +1,116 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+type eventsFixture struct {
+	svc     *services.ContentEventService
+	now     time.Time
+	creator *models.User
+	fans    []*models.User
+	content *models.Content
+}
+
+func setupContentEvents(t *testing.T) *eventsFixture {
+	// La file est écrite depuis une autre goroutine, donc une autre connexion.
+	store := newTestStore(t, &models.User{}, &models.Content{}, &models.ContentEvent{}, &models.ContentDailyStat{})
+
+	f := &eventsFixture{now: time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)}
+	f.svc = services.NewContentEventService(repositories.NewContentEventRepository(), repositories.NewContentRepository()).
+		WithClock(func() time.Time { return f.now })
+	f.creator = store.user("artist", models.RoleCreator)
+	for _, name := range []string{"ana", "bob"} {
+		f.fans = append(f.fans, store.user(name, models.RoleSubscriber))
+	}
+	f.content = store.content(f.creator, "t", "approved", time.Time{})
+	return f
+}
+
+func TestContentEvents_DedupAndRollup(t *testing.T) {
+	f := setupContentEvents(t)
+	ana, bob := f.fans[0], f.fans[1]
+
+	// Trois vues d'ana dans la même demi-heure n'en font qu'une.
+	f.svc.Record(models.ContentEventView, f.content.ID, ana.ID)
+	f.now = f.now.Add(10 * time.Minute)
+	f.svc.Record(models.ContentEventView, f.content.ID, ana.ID)
+	f.svc.Record(models.ContentEventView, f.content.ID, bob.ID)
+	f.svc.Record(models.ContentEventLike, f.content.ID, bob.ID)
+	f.svc.Record(models.ContentEventLike, f.content.ID, bob.ID)
+	f.svc.RecordConversion(bob.ID, f.creator.ID)
+	f.now = f.now.Add(time.Hour)
+	f.svc.Record(models.ContentEventView, f.content.ID, ana.ID)
+	f.svc.Record(models.ContentEventComment, f.content.ID, ana.ID)
+	require.NoError(t, f.svc.Rollup(f.now))
+
+	// Le lendemain, le cumul reprend sans recompter la veille.
+	f.now = f.now.Add(24 * time.Hour)
+	f.svc.Record(models.ContentEventDownload, f.content.ID, ana.ID)
+	require.NoError(t, f.svc.Rollup(f.now))
+
+	from := f.now.AddDate(0, 0, -2)
+	got, err := f.svc.ContentAnalytics(f.content.ID, f.creator.ID, &from, &f.now)
+	require.NoError(t, err)
+	require.Len(t, got.Series, 3)
+
+	assert.Zero(t, got.Series[0].Views)
+	day := got.Series[1]
+	assert.EqualValues(t, 3, day.Views)
+	assert.EqualValues(t, 2, day.UniqueViewers)
+	assert.EqualValues(t, 1, day.Likes)
+	assert.EqualValues(t, 1, day.Comments)
+	assert.EqualValues(t, 1, day.Subscriptions)
+	assert.InDelta(t, 1.0/3, day.ConversionRate, 1e-9)
+	assert.InDelta(t, 2.0/3, day.EngagementRate, 1e-9)
+	assert.EqualValues(t, 1, got.Series[2].Downloads)
+	assert.EqualValues(t, 3, got.Totals.Views)
+	assert.EqualValues(t, 1, got.Totals.Downloads)
+}
+
+func TestContentEvents_AnalyticsAccess(t *testing.T) {
+	f := setupContentEvents(t)
+
+	_, err := f.svc.ContentAnalytics(f.content.ID, f.fans[0].ID, nil, nil)
+	assert.ErrorIs(t, err, services.ErrAnalyticsForbidden)
+
+	got, err := f.svc.ContentAnalytics(f.content.ID, f.creator.ID, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, got.Series, 30)
+
+	from := f.now.AddDate(-2, 0, 0)
+	_, err = f.svc.ContentAnalytics(f.content.ID, f.creator.ID, &from, nil)
+	assert.ErrorIs(t, err, services.ErrInvalidDateRange)
+}
+
+func TestContentEvents_QueueFlushedForConversionAndOnStop(t *testing.T) {
+	f := setupContentEvents(t)
+	ana := f.fans[0]
+	// Écriture par lots si lente qu'elle ne se déclenche pas pendant le test.
+	f.svc.Start(time.Hour, time.Hour)
+	count := func(kind string) int64 {
+		var n int64
+		require.NoError(t, database.DB.Model(&models.ContentEvent{}).Where("type = ?", kind).Count(&n).Error)
+		return n
+	}
+
+	f.svc.Record(models.ContentEventView, f.content.ID, ana.ID)
+	assert.Equal(t, int64(0), count(models.ContentEventView), "la vue attend dans la file")
+	f.svc.RecordConversion(ana.ID, f.creator.ID)
+	assert.Equal(t, int64(1), count(models.ContentEventView), "la file est écrite avant l'attribution")
+
+	f.svc.Record(models.ContentEventDownload, f.content.ID, ana.ID)
+	f.svc.Stop()
+	assert.Equal(t, int64(1), count(models.ContentEventSubscription), "l'abonnement est attribué à la vue en file")
+	assert.Equal(t, int64(1), count(models.ContentEventDownload), "l'arrêt écrit les événements restants")
+	f.svc.Stop()
+}
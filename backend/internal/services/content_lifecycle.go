@@ -0,0 +1,183 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var (
+	ErrInvalidTransition = errors.New("changement de statut impossible")
+	ErrInvalidPublishAt  = errors.New("la date de publication doit être dans le futur")
+	ErrInvalidStatus     = errors.New("statut de contenu inconnu")
+	// ErrContentUnavailable : contenu non publié demandé par un autre que son
+	// créateur ou un admin, traité comme introuvable.
+	ErrContentUnavailable = errors.New("contenu introuvable")
+)
+
+// ContentPublication décrit comment un nouveau contenu entre dans le cycle
+// de vie : brouillon, ou soumis à la modération avec une date de publication
+// éventuelle, et sa classification (tout public par défaut).
+type ContentPublication struct {
+	Draft     bool
+	PublishAt *time.Time
+	Maturity  string
+}
+
+// Validate vérifie que la date de publication demandée est future et que la
+// classification est connue.
+func (p ContentPublication) Validate(now time.Time) error {
+	if p.PublishAt != nil && !p.PublishAt.After(now) {
+		return ErrInvalidPublishAt
+	}
+	if p.Maturity != "" && !models.IsValidMaturity(p.Maturity) {
+		return ErrInvalidMaturity
+	}
+	return nil
+}
+
+func (p ContentPublication) maturity() string {
+	if p.Maturity == "" {
+		return models.MaturityGeneral
+	}
+	return p.Maturity
+}
+
+func (p ContentPublication) initialStatus() string {
+	if p.Draft {
+		return models.ContentStatusDraft
+	}
+	return models.ContentStatusPending
+}
+
+// SubmitContent envoie un brouillon en modération.
+func (s *ContentService) SubmitContent(content *models.Content) error {
+	if content.Status != models.ContentStatusDraft {
+		return fmt.Errorf("%w: seul un brouillon peut être soumis", ErrInvalidTransition)
+	}
+	if err := s.CanPost(content.CreatorID); err != nil {
+		return err
+	}
+	if content.PublishAt != nil && !content.PublishAt.After(time.Now()) {
+		content.PublishAt = nil
+	}
+	content.Status = models.ContentStatusPending
+	return s.repo.Update(content)
+}
+
+// ScheduleContent fixe (ou retire si publishAt est nil) la date de
+// publication. Un contenu déjà approuvé et reprogrammé repasse en
+// "scheduled" jusqu'à la nouvelle date.
+func (s *ContentService) ScheduleContent(content *models.Content, publishAt *time.Time) error {
+	now := time.Now()
+	if err := (ContentPublication{PublishAt: publishAt}).Validate(now); err != nil {
+		return err
+	}
+
+	switch content.Status {
+	case models.ContentStatusDraft, models.ContentStatusPending:
+	case models.ContentStatusApproved, models.ContentStatusScheduled:
+		if publishAt == nil {
+			if content.Status == models.ContentStatusScheduled {
+				content.Status = models.ContentStatusApproved
+				content.PublishedAt = &now
+			}
+		} else {
+			content.Status = models.ContentStatusScheduled
+			content.PublishedAt = nil
+		}
+	default:
+		return fmt.Errorf("%w: contenu %s", ErrInvalidTransition, content.Status)
+	}
+
+	content.PublishAt = publishAt
+	if err := s.repo.Update(content); err != nil {
+		return err
+	}
+	s.imageCache.InvalidateContent(content.ID)
+	return nil
+}
+
+// ArchiveContent retire un contenu publié ou programmé sans le supprimer.
+func (s *ContentService) ArchiveContent(content *models.Content) error {
+	if content.Status != models.ContentStatusApproved && content.Status != models.ContentStatusScheduled {
+		return fmt.Errorf("%w: seul un contenu approuvé peut être archivé", ErrInvalidTransition)
+	}
+	now := time.Now()
+	content.Status = models.ContentStatusArchived
+	content.ArchivedAt = &now
+	return s.repo.Update(content)
+}
+
+// UnarchiveContent republie un contenu archivé.
+func (s *ContentService) UnarchiveContent(content *models.Content) error {
+	if content.Status != models.ContentStatusArchived {
+		return fmt.Errorf("%w: contenu non archivé", ErrInvalidTransition)
+	}
+	now := time.Now()
+	content.Status = models.ContentStatusApproved
+	content.ArchivedAt = nil
+	content.PublishAt = nil
+	if content.PublishedAt == nil {
+		content.PublishedAt = &now
+	}
+	return s.repo.Update(content)
+}
+
+// GetCreatorContents renvoie les contenus d'un créateur, tous statuts
+// confondus ou filtrés sur un statut.
+func (s *ContentService) GetCreatorContents(creatorID uuid.UUID, status string) ([]*models.Content, error) {
+	if status == "" {
+		return s.repo.GetContentsByUser(creatorID)
+	}
+	if !models.IsValidContentStatus(status) {
+		return nil, ErrInvalidStatus
+	}
+	return s.repo.GetContentsByUserAndStatus(creatorID, status)
+}
+
+// CanView indique si l'utilisateur peut accéder au contenu : un contenu non
+// publié (brouillon, en attente, refusé, programmé, archivé) n'est visible
+// que de son créateur et des admins.
+func (s *ContentService) CanView(content *models.Content, userID uuid.UUID) bool {
+	if content.Status == models.ContentStatusApproved || content.CreatorID == userID {
+		return true
+	}
+	user, err := repositories.NewUserRepository().FindByID(userID)
+	return err == nil && user != nil && user.Role == models.RoleAdmin
+}
+
+// PublishDueContents publie les contenus approuvés dont la date est atteinte.
+func (s *ContentService) PublishDueContents(now time.Time) (int64, error) {
+	n, err := s.repo.PublishDue(now)
+	if err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		log.Printf("📅 %d contenu(s) programmé(s) publié(s)", n)
+		logger.LogBusinessEvent("scheduled_contents_published", map[string]interface{}{
+			"count": n,
+		})
+	}
+	return n, nil
+}
+
+// StartPublishScheduler lance la publication périodique des contenus programmés.
+func (s *ContentService) StartPublishScheduler(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			if _, err := s.PublishDueContents(now); err != nil {
+				logger.LogError(err, "publish_scheduler_failed", nil)
+			}
+		}
+	}()
+}
@@ -0,0 +1,104 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+func setupContentLifecycle(t *testing.T) (*services.ContentService, *repositories.ContentRepository, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, database.MigrateSQLite(db, &models.User{}, &models.Content{}))
+	repositories.SetTestDB(db)
+
+	repo := repositories.NewContentRepository()
+	return services.NewContentService(repo, repositories.NewContentRevisionRepository(), t.TempDir(), nil, nil, nil), repo, db
+}
+
+func TestContentLifecycle_DraftScheduledPublished(t *testing.T) {
+	svc, repo, db := setupContentLifecycle(t)
+	publishAt := time.Now().Add(2 * time.Hour)
+
+	content := &models.Content{
+		CreatorID: uuid.New(), Title: "Brouillon", Body: "b", Price: 3, FilePath: "x.jpg",
+		Status: models.ContentStatusDraft,
+	}
+	require.NoError(t, db.Create(content).Error)
+
+	require.NoError(t, svc.ScheduleContent(content, &publishAt))
+	require.NoError(t, svc.SubmitContent(content))
+	assert.Equal(t, models.ContentStatusPending, content.Status)
+
+	require.NoError(t, repo.Approve(content.ID, time.Now()))
+	content, err := repo.FindByID(content.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ContentStatusScheduled, content.Status)
+
+	published, err := svc.GetAllContents(false)
+	require.NoError(t, err)
+	assert.Empty(t, published)
+
+	n, err := svc.PublishDueContents(publishAt.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	published, err = svc.GetAllContents(false)
+	require.NoError(t, err)
+	require.Len(t, published, 1)
+	assert.NotNil(t, published[0].PublishedAt)
+
+	content = &published[0]
+	require.NoError(t, svc.ArchiveContent(content))
+	published, err = svc.GetAllContents(false)
+	require.NoError(t, err)
+	assert.Empty(t, published)
+
+	err = svc.SubmitContent(content)
+	assert.True(t, errors.Is(err, services.ErrInvalidTransition))
+}
+
+func TestContentLifecycle_RejectsPastPublishAt(t *testing.T) {
+	svc, _, db := setupContentLifecycle(t)
+	content := &models.Content{
+		CreatorID: uuid.New(), Title: "T", Body: "b", Price: 3, FilePath: "x.jpg",
+		Status: models.ContentStatusPending,
+	}
+	require.NoError(t, db.Create(content).Error)
+
+	past := time.Now().Add(-time.Hour)
+	assert.True(t, errors.Is(svc.ScheduleContent(content, &past), services.ErrInvalidPublishAt))
+}
+
+func TestContentLifecycle_UnpublishedOnlyForCreatorAndAdmin(t *testing.T) {
+	svc, _, db := setupContentLifecycle(t)
+	creator := &models.User{Username: "artist", Email: "artist@test", HashedPassword: "x", Role: models.RoleCreator}
+	fan := &models.User{Username: "fan", Email: "fan@test", HashedPassword: "x", Role: models.RoleSubscriber}
+	admin := &models.User{Username: "admin", Email: "admin@test", HashedPassword: "x", Role: models.RoleAdmin}
+	for _, u := range []*models.User{creator, fan, admin} {
+		require.NoError(t, db.Create(u).Error)
+	}
+
+	for _, status := range []string{models.ContentStatusDraft, models.ContentStatusPending, models.ContentStatusRejected,
+		models.ContentStatusScheduled, models.ContentStatusArchived} {
+		content := &models.Content{CreatorID: creator.ID, Status: status}
+		assert.False(t, svc.CanView(content, fan.ID), status)
+		assert.True(t, svc.CanView(content, creator.ID), status)
+		assert.True(t, svc.CanView(content, admin.ID), status)
+	}
+	assert.True(t, svc.CanView(&models.Content{CreatorID: creator.ID, Status: models.ContentStatusApproved}, fan.ID))
+
+	_, err := svc.GetCreatorContents(creator.ID, "published'; --")
+	assert.True(t, errors.Is(err, services.ErrInvalidStatus))
+}
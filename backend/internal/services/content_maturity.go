@@ -0,0 +1,52 @@
+package services
+
+import (
+	"log"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+)
+
+// SetMaturity applique la classification choisie par le créateur. Elle est
+// refusée si la modération l'a imposée. Déclasser en "general" un contenu
+// publié ou programmé le renvoie en modération.
+func (s *ContentService) SetMaturity(content *models.Content, maturity string) error {
+	if !models.IsValidMaturity(maturity) {
+		return ErrInvalidMaturity
+	}
+	if content.MaturityModeratedBy != nil {
+		return ErrMaturityLocked
+	}
+	if content.Maturity == maturity {
+		return nil
+	}
+
+	wasMature := content.IsMature()
+	content.Maturity = maturity
+	if wasMature && !content.IsMature() &&
+		(content.Status == models.ContentStatusApproved || content.Status == models.ContentStatusScheduled) {
+		content.Status = models.ContentStatusPending
+		log.Printf("🔞 Contenu %s déclassé en tout public : retour en modération", content.ID)
+	}
+	return s.repo.Update(content)
+}
+
+// OverrideMaturity impose une classification (modération) ; le créateur ne
+// peut plus la modifier ensuite.
+func (s *ContentService) OverrideMaturity(contentID, moderatorID uuid.UUID, maturity string) (*models.Content, error) {
+	if !models.IsValidMaturity(maturity) {
+		return nil, ErrInvalidMaturity
+	}
+	content, err := s.repo.FindByID(contentID)
+	if err != nil {
+		return nil, ErrContentNotFound
+	}
+	content.Maturity = maturity
+	content.MaturityModeratedBy = &moderatorID
+	if err := s.repo.Update(content); err != nil {
+		return nil, err
+	}
+	log.Printf("🔞 Classification du contenu %s imposée à %q par %s", contentID, maturity, moderatorID)
+	return content, nil
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+)
+
+var (
+	ErrInvalidContentEdit = errors.New("titre, description et prix positif requis")
+	ErrContentNotEditable = errors.New("un contenu archivé ou refusé ne peut pas être modifié")
+)
+
+// Au-delà de cette proportion de texte modifié, une modification de la
+// description est considérée comme substantielle.
+const substantialBodyChange = 0.2
+
+// ContentEdit porte les nouvelles valeurs des champs modifiables.
+type ContentEdit struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Price int    `json:"price"`
+}
+
+// EditContent applique une modification, l'enregistre dans l'historique et
+// renvoie un contenu approuvé en modération si la modification est
+// substantielle (titre, description largement réécrite).
+func (s *ContentService) EditContent(content *models.Content, editorID uuid.UUID, edit ContentEdit) error {
+	edit.Title = strings.TrimSpace(edit.Title)
+	edit.Body = strings.TrimSpace(edit.Body)
+	if edit.Title == "" || edit.Body == "" || edit.Price <= 0 {
+		return ErrInvalidContentEdit
+	}
+
+	changes := models.FieldChanges{}
+	substantial := false
+	if edit.Title != content.Title {
+		changes["title"] = models.FieldChange{Old: content.Title, New: edit.Title}
+		substantial = substantial || !strings.EqualFold(edit.Title, content.Title)
+	}
+	if edit.Body != content.Body {
+		changes["body"] = models.FieldChange{Old: content.Body, New: edit.Body}
+		substantial = substantial || changedRatio(content.Body, edit.Body) > substantialBodyChange
+	}
+	if edit.Price != content.Price {
+		changes["price"] = models.FieldChange{Old: content.Price, New: edit.Price}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	rating := allowText()
+	if edit.Title != content.Title || edit.Body != content.Body {
+		var err error
+		if rating, err = s.screenText(content.CreatorID, edit.Title, edit.Body); err != nil {
+			return err
+		}
+		// Un texte retenu repasse toujours par la modération.
+		substantial = substantial || rating.Verdict == models.TextVerdictHold
+	}
+
+	content.Title = edit.Title
+	content.Body = edit.Body
+	content.Price = edit.Price
+	if err := s.saveRevision(content, editorID, changes, "", substantial); err != nil {
+		return err
+	}
+	return s.text.Hold(models.TextTargetContent, content.ID, content.CreatorID, edit.Title+"\n"+edit.Body, rating)
+}
+
+// ReplaceContentFile remplace l'image d'un contenu. L'ancien fichier est
+// conservé et référencé par la révision ; un remplacement est toujours
+// substantiel et soumis aux mêmes restrictions qu'une publication.
+func (s *ContentService) ReplaceContentFile(content *models.Content, editorID uuid.UUID, fileHeader *multipart.FileHeader) error {
+	if content.Status == models.ContentStatusArchived || content.Status == models.ContentStatusRejected {
+		return ErrContentNotEditable
+	}
+	if err := s.CanPost(editorID); err != nil {
+		return err
+	}
+	validated, err := s.validateUpload(fileHeader)
+	if err != nil {
+		return err
+	}
+
+	userDir := filepath.Dir(content.FilePath)
+	if err := os.MkdirAll(filepath.Join(s.uploadPath, userDir), 0o755); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+	newPath := filepath.Join(userDir, uuid.NewString()+validated.Ext)
+	if err := os.WriteFile(filepath.Join(s.uploadPath, newPath), validated.Data, 0o644); err != nil {
+		return err
+	}
+
+	previous := content.FilePath
+	changes := models.FieldChanges{
+		"file_path": {Old: previous, New: newPath},
+		"sha256":    {Old: content.SHA256, New: validated.SHA256},
+	}
+	if validated.ContentType != content.MediaType {
+		changes["media_type"] = models.FieldChange{Old: content.MediaType, New: validated.ContentType}
+	}
+	content.FilePath = newPath
+	content.SHA256 = validated.SHA256
+	content.Width = validated.Width
+	content.Height = validated.Height
+	content.MediaType = validated.ContentType
+
+	if err := s.saveRevision(content, editorID, changes, previous, true); err != nil {
+		os.Remove(filepath.Join(s.uploadPath, newPath))
+		return err
+	}
+	s.imageCache.InvalidateContent(content.ID)
+	if _, err := s.duplicates.Index(content, validated.PHash); err != nil {
+		log.Printf("⚠️ Détection de doublons pour %s: %v", content.ID, err)
+	}
+	return nil
+}
+
+// GetRevisions renvoie l'historique d'un contenu.
+func (s *ContentService) GetRevisions(contentID uuid.UUID) ([]models.ContentRevision, error) {
+	return s.revisions.FindByContent(contentID)
+}
+
+func (s *ContentService) saveRevision(
+	content *models.Content,
+	editorID uuid.UUID,
+	changes models.FieldChanges,
+	previousFile string,
+	substantial bool,
+) error {
+	rev := &models.ContentRevision{
+		EditorID:         editorID,
+		Changes:          changes,
+		PreviousFilePath: previousFile,
+		StatusBefore:     content.Status,
+	}
+	if substantial && (content.Status == models.ContentStatusApproved || content.Status == models.ContentStatusScheduled) {
+		content.Status = models.ContentStatusPending
+	}
+	rev.StatusAfter = content.Status
+
+	if err := s.revisions.SaveWithRevision(content, rev); err != nil {
+		return err
+	}
+
+	if rev.StatusBefore != rev.StatusAfter {
+		log.Printf("✏️ Contenu %s modifié substantiellement, retour en modération", content.ID)
+	}
+	logger.LogContent("content_edited", editorID.String(), content.ID.String(), map[string]interface{}{
+		"revision":      rev.Revision,
+		"fields":        changedFields(changes),
+		"status_before": rev.StatusBefore,
+		"status_after":  rev.StatusAfter,
+	})
+	return nil
+}
+
+// changedRatio estime la proportion de texte modifiée entre deux versions
+// (hors préfixe et suffixe communs).
+func changedRatio(before, after string) float64 {
+	a, b := []rune(before), []rune(after)
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	if longest == 0 {
+		return 0
+	}
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	return float64(longest-prefix-suffix) / float64(longest)
+}
+
+func changedFields(changes models.FieldChanges) []string {
+	fields := make([]string, 0, len(changes))
+	for f := range changes {
+		fields = append(fields, f)
+	}
+	return fields
+}
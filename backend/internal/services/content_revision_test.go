@@ -0,0 +1,103 @@
+package services_test
+
+import (
+	"bytes"
+	"image"
+	imgpng "image/png"
+	"mime/multipart"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+func TestEditContent_RecordsRevisionsAndRemoderates(t *testing.T) {
+	svc, _, db := setupContentLifecycle(t)
+	require.NoError(t, database.MigrateSQLite(db, &models.ContentRevision{}))
+
+	creatorID := uuid.New()
+	content := &models.Content{
+		CreatorID: creatorID, Title: "Paysage", Body: "Une aquarelle de montagne au lever du soleil.",
+		Price: 5, FilePath: "x.jpg", Status: models.ContentStatusApproved,
+	}
+	require.NoError(t, db.Create(content).Error)
+
+	// Changement de prix et petite correction : le contenu reste publié.
+	require.NoError(t, svc.EditContent(content, creatorID, services.ContentEdit{
+		Title: "Paysage", Body: "Une aquarelle de montagne au lever du soleil !", Price: 7,
+	}))
+	assert.Equal(t, models.ContentStatusApproved, content.Status)
+
+	// Nouveau titre : retour en modération.
+	require.NoError(t, svc.EditContent(content, creatorID, services.ContentEdit{
+		Title: "Autre chose", Body: content.Body, Price: 7,
+	}))
+	assert.Equal(t, models.ContentStatusPending, content.Status)
+
+	revs, err := svc.GetRevisions(content.ID)
+	require.NoError(t, err)
+	require.Len(t, revs, 2)
+	assert.Equal(t, 2, revs[0].Revision)
+	assert.Equal(t, "Paysage", revs[0].Changes["title"].Old)
+	assert.Equal(t, models.ContentStatusApproved, revs[0].StatusBefore)
+	assert.Equal(t, models.ContentStatusPending, revs[0].StatusAfter)
+	assert.Contains(t, revs[1].Changes, "price")
+	assert.NotContains(t, revs[1].Changes, "title")
+}
+
+// fileHeader construit le champ multipart "file" d'un formulaire.
+func fileHeader(t *testing.T, name string, data []byte) *multipart.FileHeader {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile("file", name)
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	form, err := multipart.NewReader(&buf, w.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	t.Cleanup(func() { form.RemoveAll() })
+	return form.File["file"][0]
+}
+
+func TestReplaceContentFile_UpdatesMediaTypeAndEnforcesRestrictions(t *testing.T) {
+	_, _, db := setupContentLifecycle(t)
+	require.NoError(t, database.MigrateSQLite(db, &models.ContentRevision{}, &models.Sanction{}, &models.Subscription{}))
+	sanctions := services.NewSanctionService(repositories.NewSanctionRepository(), repositories.NewUserRepository(),
+		repositories.NewSubscriptionRepository())
+	svc := services.NewContentService(repositories.NewContentRepository(), repositories.NewContentRevisionRepository(),
+		t.TempDir(), nil, nil, sanctions)
+
+	admin := &models.User{Username: "admin", Email: "admin@test", HashedPassword: "x", Role: models.RoleAdmin}
+	creator := &models.User{Username: "artiste", Email: "c@test", HashedPassword: "x", Role: models.RoleCreator}
+	require.NoError(t, db.Create(admin).Error)
+	require.NoError(t, db.Create(creator).Error)
+	content := &models.Content{CreatorID: creator.ID, Title: "Paysage", Body: "b", Price: 5,
+		FilePath: "artiste/x.jpg", MediaType: "image/jpeg", Status: models.ContentStatusApproved}
+	require.NoError(t, db.Create(content).Error)
+
+	var png bytes.Buffer
+	require.NoError(t, imgpng.Encode(&png, image.NewRGBA(image.Rect(0, 0, 40, 30))))
+	require.NoError(t, svc.ReplaceContentFile(content, creator.ID, fileHeader(t, "x.png", png.Bytes())))
+	assert.Equal(t, "image/png", content.MediaType)
+	assert.Equal(t, models.ContentStatusPending, content.Status)
+	revs, err := svc.GetRevisions(content.ID)
+	require.NoError(t, err)
+	require.Len(t, revs, 1)
+	assert.Equal(t, "image/jpeg", revs[0].Changes["media_type"].Old)
+
+	_, err = sanctions.Issue(creator.ID, admin.ID, models.SanctionRestrictPosting, "plagiat", nil)
+	require.NoError(t, err)
+	err = svc.ReplaceContentFile(content, creator.ID, fileHeader(t, "y.jpg", encodeTestJPEG(t, 40, 30)))
+	assert.ErrorIs(t, err, services.ErrPostingRestricted)
+
+	content.Status = models.ContentStatusArchived
+	err = svc.ReplaceContentFile(content, creator.ID, fileHeader(t, "y.jpg", encodeTestJPEG(t, 40, 30)))
+	assert.ErrorIs(t, err, services.ErrContentNotEditable)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
@@ -11,10 +12,12 @@ import (
 	"io"
 	"log"
 	"mime/multipart"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -22,17 +25,66 @@ import (
 	"golang.org/x/image/font/basicfont"
 	"golang.org/x/image/math/fixed"
 
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/config"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
 	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
 )
 
 type ContentService struct {
 	repo       *repositories.ContentRepository
+	revisions  *repositories.ContentRevisionRepository
 	uploadPath string
+	imageCache *ImageCache
+	forensic   *ForensicService
+	duplicates *DuplicateService
+	sanctions  *SanctionService
+	text       *TextModerationService
+	ranker     *FeedRanker
+	events     *ContentEventService
 }
 
-func NewContentService(repo *repositories.ContentRepository, uploadPath string) *ContentService {
-	return &ContentService{repo: repo, uploadPath: uploadPath}
+func NewContentService(
+	repo *repositories.ContentRepository,
+	revisions *repositories.ContentRevisionRepository,
+	uploadPath string,
+	forensic *ForensicService,
+	duplicates *DuplicateService,
+	sanctions *SanctionService,
+) *ContentService {
+	cacheSize := config.C.ImageCacheMaxBytes
+	if cacheSize <= 0 {
+		cacheSize = 64 << 20
+	}
+	return &ContentService{
+		repo:       repo,
+		revisions:  revisions,
+		uploadPath: uploadPath,
+		imageCache: NewImageCache(cacheSize),
+		forensic:   forensic,
+		duplicates: duplicates,
+		sanctions:  sanctions,
+	}
+}
+
+// ErrNoImagePreview : les PSD et vidéos n'ont pas d'image rendue, seulement
+// le téléchargement.
+var ErrNoImagePreview = errors.New("aperçu indisponible pour ce format")
+
+// CanPost vérifie qu'aucune sanction n'interdit au créateur de publier.
+func (s *ContentService) CanPost(creatorID uuid.UUID) error {
+	return s.sanctions.Ensure(creatorID, models.SanctionRestrictPosting)
+}
+
+// SetTextModeration branche la modération automatique des titres et
+// descriptions.
+func (s *ContentService) SetTextModeration(text *TextModerationService) {
+	s.text = text
+}
+
+// screenText évalue le titre et la description d'un contenu.
+func (s *ContentService) screenText(creatorID uuid.UUID, title, body string) (TextRating, error) {
+	_, rating, err := s.text.Screen(models.TextTargetContent, creatorID, title+"\n"+body)
+	return rating, err
 }
 
 func (s *ContentService) CreateContent(
@@ -41,6 +93,9 @@ func (s *ContentService) CreateContent(
 	price int,
 	fileHeader *multipart.FileHeader,
 	role string,
+	preview models.PreviewSettings,
+	logoHeader *multipart.FileHeader,
+	publication ContentPublication,
 ) (*models.Content, error) {
 
 	if role != "creator" && role != "admin" {
@@ -49,62 +104,254 @@ func (s *ContentService) CreateContent(
 	if title == "" || body == "" || price <= 0 || fileHeader == nil {
 		return nil, fmt.Errorf("champs requis manquants ou invalides")
 	}
+	if err := NormalizePreview(&preview); err != nil {
+		return nil, err
+	}
+	if err := publication.Validate(time.Now()); err != nil {
+		return nil, err
+	}
 
-	userDir := filepath.Join(s.uploadPath, username)
-	if err := os.MkdirAll(userDir, 0o755); err != nil {
-		return nil, fmt.Errorf("mkdir: %w", err)
+	validated, err := s.validateUpload(fileHeader)
+	if err != nil {
+		contentUploads.WithLabelValues(uploadStatusInvalid).Inc()
+		return nil, err
 	}
 
-	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
-	allowed := map[string]bool{".jpg": true, ".jpeg": true, ".png": true}
-	if !allowed[ext] {
-		return nil, fmt.Errorf("format de fichier non autorisé")
+	if logoHeader != nil {
+		logoPath, err := s.saveWatermarkLogo(username, logoHeader)
+		if err != nil {
+			return nil, err
+		}
+		preview.WatermarkLogoPath = logoPath
 	}
 
-	filename := uuid.NewString() + ext
-	dstPath := filepath.Join(userDir, filename)
+	content, err := s.CreateContentFromImage(creatorID, username, title, body, price, validated, preview, publication)
+	if err != nil && logoHeader != nil {
+		// Contenu refusé (sanction, modération…) : le logo ne sert à rien.
+		if rmErr := os.Remove(filepath.Join(s.uploadPath, preview.WatermarkLogoPath)); rmErr != nil {
+			log.Printf("⚠️ Suppression du logo %s: %v", preview.WatermarkLogoPath, rmErr)
+		}
+	}
+	return content, err
+}
 
-	src, err := fileHeader.Open()
-	if err != nil {
-		return nil, err
+// CreateContentFromImage enregistre une image déjà validée (upload classique
+// ou reprise d'upload finalisée) et crée le contenu associé, en brouillon ou
+// en attente de modération. Les réglages d'aperçu doivent être normalisés.
+func (s *ContentService) CreateContentFromImage(
+	creatorID uuid.UUID,
+	username, title, body string,
+	price int,
+	validated *ValidatedImage,
+	preview models.PreviewSettings,
+	publication ContentPublication,
+) (*models.Content, error) {
+	content := &models.Content{
+		SHA256:    validated.SHA256,
+		Width:     validated.Width,
+		Height:    validated.Height,
+		MediaType: validated.ContentType,
 	}
-	defer src.Close()
+	store := func(dst string) error { return os.WriteFile(dst, validated.Data, 0o644) }
+	phash := validated.PHash
+	return s.createContent(creatorID, username, title, body, price, preview, publication, content, validated.Ext, store, &phash)
+}
+
+// CreateContentFromMedia crée un contenu à partir d'un PSD ou d'une vidéo
+// finalisé par un upload reprenable. Le fichier est déplacé sans passer par
+// la mémoire ; ces contenus n'ont ni aperçu ni hash perceptuel.
+func (s *ContentService) CreateContentFromMedia(
+	creatorID uuid.UUID,
+	username, title, body string,
+	price int,
+	media MediaFile,
+	preview models.PreviewSettings,
+	publication ContentPublication,
+) (*models.Content, error) {
+	content := &models.Content{
+		SHA256:    media.SHA256,
+		Width:     media.Width,
+		Height:    media.Height,
+		MediaType: media.ContentType,
+	}
+	store := func(dst string) error { return moveFile(media.Path, dst) }
+	return s.createContent(creatorID, username, title, body, price, preview, publication, content, media.Ext, store, nil)
+}
 
-	dst, err := os.Create(dstPath)
+// createContent range le fichier dans le dossier du créateur puis enregistre
+// le contenu ; phash est nil pour les formats sans image décodable.
+func (s *ContentService) createContent(
+	creatorID uuid.UUID,
+	username, title, body string,
+	price int,
+	preview models.PreviewSettings,
+	publication ContentPublication,
+	content *models.Content,
+	ext string,
+	store func(dst string) error,
+	phash *uint64,
+) (*models.Content, error) {
+	if err := s.CanPost(creatorID); err != nil {
+		contentUploads.WithLabelValues(uploadStatusRefused).Inc()
+		return nil, err
+	}
+	rating, err := s.screenText(creatorID, title, body)
 	if err != nil {
+		contentUploads.WithLabelValues(uploadStatusRefused).Inc()
 		return nil, err
 	}
-	defer dst.Close()
 
-	if _, err := io.Copy(dst, src); err != nil {
-		return nil, err
+	userDir := filepath.Join(s.uploadPath, username)
+	if err := os.MkdirAll(userDir, 0o755); err != nil {
+		return nil, fmt.Errorf("mkdir: %w", err)
 	}
 
-	relativePath := filepath.Join(username, filename)
-	content := &models.Content{
-		CreatorID: creatorID,
-		Title:     title,
-		Body:      body,
-		Price:     price,
-		FilePath:  relativePath,
-		Status:    "pending",
+	filename := uuid.NewString() + ext
+	if err := store(filepath.Join(userDir, filename)); err != nil {
+		return nil, err
 	}
 
+	content.CreatorID = creatorID
+	content.Title = title
+	content.Body = body
+	content.Price = price
+	content.FilePath = filepath.Join(username, filename)
+	content.Status = publication.initialStatus()
+	content.PublishAt = publication.PublishAt
+	content.Maturity = publication.maturity()
+	content.IsBlurred = preview.Mode == models.PreviewModeBlur
+	content.Preview = preview
+
 	if err := s.repo.Create(content); err != nil {
+		os.Remove(filepath.Join(userDir, filename))
 		return nil, err
 	}
+	contentUploads.WithLabelValues(content.Status).Inc()
+	if phash != nil {
+		if _, err := s.duplicates.Index(content, *phash); err != nil {
+			log.Printf("⚠️ Détection de doublons pour %s: %v", content.ID, err)
+		}
+	}
+	if err := s.text.Hold(models.TextTargetContent, content.ID, creatorID, title+"\n"+body, rating); err != nil {
+		log.Printf("⚠️ Mise en file de modération de %s: %v", content.ID, err)
+	}
 
 	return content, nil
 }
 
-func (s *ContentService) GetAllContents() ([]models.Content, error) {
-	return s.repo.FindAll()
+// moveFile déplace un fichier, par copie en flux si la destination est sur
+// un autre volume.
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return os.Chmod(dst, 0o644)
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	return os.Remove(src)
+}
+
+// UpdatePreview change le mode d'aperçu d'un contenu. Le logo existant est
+// conservé sauf si un nouveau logo est fourni ou si removeLogo est vrai.
+func (s *ContentService) UpdatePreview(
+	content *models.Content,
+	preview models.PreviewSettings,
+	logoHeader *multipart.FileHeader,
+	removeLogo bool,
+) error {
+	if err := NormalizePreview(&preview); err != nil {
+		return err
+	}
+
+	oldLogo := content.Preview.WatermarkLogoPath
+	preview.WatermarkLogoPath = oldLogo
+	if logoHeader != nil {
+		logoPath, err := s.saveWatermarkLogo(filepath.Dir(content.FilePath), logoHeader)
+		if err != nil {
+			return err
+		}
+		preview.WatermarkLogoPath = logoPath
+	} else if removeLogo {
+		preview.WatermarkLogoPath = ""
+	}
+
+	content.Preview = preview
+	content.IsBlurred = preview.Mode == models.PreviewModeBlur
+	if err := s.repo.Update(content); err != nil {
+		return err
+	}
+
+	if oldLogo != "" && oldLogo != preview.WatermarkLogoPath {
+		if err := os.Remove(filepath.Join(s.uploadPath, oldLogo)); err != nil && !os.IsNotExist(err) {
+			log.Printf("⚠️ Suppression ancien logo impossible: %v", err)
+		}
+	}
+	s.imageCache.InvalidateContent(content.ID)
+	return nil
+}
+
+// saveWatermarkLogo enregistre le logo de watermark d'un créateur après
+// validation (PNG ou JPEG, limites d'upload, métadonnées supprimées).
+func (s *ContentService) saveWatermarkLogo(userDir string, logoHeader *multipart.FileHeader) (string, error) {
+	validated, err := s.validateUpload(logoHeader)
+	if err != nil {
+		return "", fmt.Errorf("logo: %w", err)
+	}
+
+	logoDir := filepath.Join(s.uploadPath, userDir, "logos")
+	if err := os.MkdirAll(logoDir, 0o755); err != nil {
+		return "", fmt.Errorf("mkdir: %w", err)
+	}
+	filename := uuid.NewString() + validated.Ext
+	if err := os.WriteFile(filepath.Join(logoDir, filename), validated.Data, 0o644); err != nil {
+		return "", err
+	}
+	return filepath.Join(userDir, "logos", filename), nil
+}
+
+// validateUpload passe un fichier envoyé dans la chaîne de validation d'image.
+func (s *ContentService) validateUpload(fileHeader *multipart.FileHeader) (*ValidatedImage, error) {
+	limits := DefaultUploadLimits()
+	if fileHeader.Size > limits.MaxBytes {
+		return nil, fmt.Errorf("%w (max %d Mo)", ErrUploadTooLarge, limits.MaxBytes>>20)
+	}
+	src, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+	return ValidateImageUpload(src, limits)
+}
+
+// GetAllContents renvoie les contenus publiés, sans les contenus adultes si
+// includeMature est faux.
+func (s *ContentService) GetAllContents(includeMature bool) ([]models.Content, error) {
+	return s.repo.FindPublished(includeMature)
 }
 
+// ServeProtectedImage sert l'image d'un contenu : version marquée pour les
+// abonnés, aperçu sinon. Un contenu adulte n'est servi, même en aperçu, qu'à
+// son créateur ou si allowMature est vrai.
 func (s *ContentService) ServeProtectedImage(
 	c *gin.Context,
 	contentID uuid.UUID,
 	userID uuid.UUID,
+	allowMature bool,
 ) error {
 	log.Printf("🖼️ ServeProtectedImage - contentID: %s | userID: %s", contentID.String(), userID.String())
 
@@ -114,6 +361,18 @@ func (s *ContentService) ServeProtectedImage(
 		return fmt.Errorf("contenu non trouvé")
 	}
 	log.Printf("📄 Contenu trouvé: %s (creatorID: %s)", content.Title, content.CreatorID.String())
+	if !s.CanView(content, userID) {
+		log.Printf("🚫 Contenu %s (%s) refusé à %s", contentID, content.Status, userID)
+		return ErrContentUnavailable
+	}
+
+	if content.IsMature() && !allowMature && content.CreatorID != userID {
+		log.Printf("🔞 Contenu adulte refusé à %s", userID)
+		return ErrMatureRestricted
+	}
+	if !hasImagePreview(content.FilePath) {
+		return ErrNoImagePreview
+	}
 
 	subscribed, err := s.repo.IsUserSubscribedToCreator(userID, content.CreatorID)
 	if err != nil {
@@ -123,11 +382,157 @@ func (s *ContentService) ServeProtectedImage(
 	log.Printf("🔐 Abonné ? %v", subscribed)
 
 	imagePath := filepath.Join(s.uploadPath, content.FilePath)
-	file, err := os.Open(imagePath)
+	info, err := os.Stat(imagePath)
 	if err != nil {
 		log.Printf("❌ Image introuvable: %v", err)
 		return fmt.Errorf("image non trouvée")
 	}
+	modTime := info.ModTime().UTC().Truncate(time.Second)
+
+	variant := content.Preview.Mode
+	if variant == "" {
+		variant = ImageVariantWatermark
+	}
+	var mark *models.ForensicMark
+	if subscribed {
+		variant = ImageVariantForensic
+		mark, err = s.forensic.IssueMark(contentID, userID, models.ForensicMarkKindView)
+		if err != nil {
+			log.Printf("❌ Erreur émission jeton forensique: %v", err)
+			return fmt.Errorf("erreur marquage image")
+		}
+	}
+	key := ImageCacheKey{ContentID: contentID, Variant: variant, Version: WatermarkVersion}
+	if mark != nil {
+		key.Mark = uint32(mark.Token)
+	} else {
+		key.Options = previewFingerprint(content.Preview)
+	}
+	etag := fmt.Sprintf(`"%s-%x"`, key.String(), modTime.Unix())
+
+	// Une réponse 304 reste une vue ; celles du créateur ne comptent pas.
+	if content.CreatorID != userID {
+		s.events.Record(models.ContentEventView, contentID, userID)
+	}
+
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", modTime.Format(http.TimeFormat))
+	c.Header("Cache-Control", "private, max-age=3600, must-revalidate")
+	c.Header("Vary", "Authorization")
+
+	if notModified(c.Request, etag, modTime) {
+		c.Status(http.StatusNotModified)
+		return nil
+	}
+
+	rendered, ok := s.imageCache.Get(key, modTime)
+	if !ok {
+		rendered, err = s.renderImage(content, variant, mark)
+		if err != nil {
+			return err
+		}
+		rendered.ModTime = modTime
+		s.imageCache.Put(key, rendered)
+	}
+
+	c.Header("Content-Disposition", "inline; filename="+filepath.Base(imagePath))
+	c.Data(http.StatusOK, rendered.ContentType, rendered.Data)
+	return nil
+}
+
+// renderImage décode l'image source et l'encode dans la variante demandée.
+func (s *ContentService) renderImage(content *models.Content, variant string, mark *models.ForensicMark) (CachedImage, error) {
+	start := time.Now()
+	defer func() {
+		imageRenderDuration.WithLabelValues(variant).Observe(time.Since(start).Seconds())
+	}()
+
+	img, ext, err := decodeImageFile(filepath.Join(s.uploadPath, content.FilePath))
+	if err != nil {
+		return CachedImage{}, err
+	}
+
+	switch variant {
+	case ImageVariantForensic:
+		log.Println("✅ Image originale envoyée (watermark forensique invisible)")
+		img, err = s.applyForensicMark(img, mark)
+		if err != nil {
+			return CachedImage{}, err
+		}
+	case ImageVariantClean:
+		log.Println("✅ Image originale envoyée (pas de watermark)")
+	default:
+		log.Printf("🔒 Aperçu %s", variant)
+		preview := content.Preview
+		if preview.Mode == "" {
+			preview.Mode = models.PreviewModeWatermark
+		}
+		var logo image.Image
+		if preview.WatermarkLogoPath != "" {
+			if logo, _, err = decodeImageFile(filepath.Join(s.uploadPath, preview.WatermarkLogoPath)); err != nil {
+				log.Printf("⚠️ Logo de watermark illisible, ignoré: %v", err)
+				logo = nil
+			}
+		}
+		img = applyPreview(img, preview, logo)
+	}
+
+	return encodeImage(img, ext)
+}
+
+// applyForensicMark embarque le jeton ; les images trop petites pour porter
+// la marque sont servies telles quelles.
+func (s *ContentService) applyForensicMark(img image.Image, mark *models.ForensicMark) (image.Image, error) {
+	marked, err := s.forensic.Apply(img, mark)
+	if errors.Is(err, ErrForensicImageTooSmall) {
+		return img, nil
+	}
+	if err != nil {
+		log.Printf("❌ Erreur watermark forensique: %v", err)
+		return nil, fmt.Errorf("erreur marquage image")
+	}
+	return marked, nil
+}
+
+// RenderForensicDownload renvoie le fichier à télécharger marqué d'un jeton
+// propre à ce téléchargement, ou ok=false si le format ne se prête pas au marquage.
+func (s *ContentService) RenderForensicDownload(contentID, userID uuid.UUID) (CachedImage, bool, error) {
+	content, err := s.repo.FindByID(contentID)
+	if err != nil {
+		return CachedImage{}, false, fmt.Errorf("contenu introuvable: %v", err)
+	}
+	if !hasImagePreview(content.FilePath) {
+		return CachedImage{}, false, nil
+	}
+
+	mark, err := s.forensic.IssueMark(contentID, userID, models.ForensicMarkKindDownload)
+	if err != nil {
+		return CachedImage{}, false, err
+	}
+	rendered, err := s.renderImage(content, ImageVariantForensic, mark)
+	if err != nil {
+		return CachedImage{}, false, err
+	}
+	return rendered, true, nil
+}
+
+// hasImagePreview indique si le fichier passe par la chaîne image : les PSD
+// et vidéos sont servis tels quels, au téléchargement uniquement.
+func hasImagePreview(filePath string) bool {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".jpg", ".jpeg", ".png":
+		return true
+	}
+	return false
+}
+
+// decodeImageFile ouvre et décode une image JPEG ou PNG selon son extension.
+func decodeImageFile(imagePath string) (image.Image, string, error) {
+	file, err := os.Open(imagePath)
+	if err != nil {
+		log.Printf("❌ Image introuvable: %v", err)
+		return nil, "", fmt.Errorf("image non trouvée")
+	}
 	defer file.Close()
 
 	ext := strings.ToLower(path.Ext(imagePath))
@@ -139,42 +544,51 @@ func (s *ContentService) ServeProtectedImage(
 		img, err = png.Decode(file)
 	default:
 		log.Printf("❌ Format d'image non supporté: %s", ext)
-		return fmt.Errorf("format d'image non supporté")
+		return nil, "", fmt.Errorf("format d'image non supporté")
 	}
 	if err != nil {
 		log.Printf("❌ Erreur décodage image: %v", err)
-		return fmt.Errorf("erreur décodage image: %v", err)
+		return nil, "", fmt.Errorf("erreur décodage image: %v", err)
 	}
+	return img, ext, nil
+}
 
+// encodeImage encode l'image dans le format d'origine.
+func encodeImage(img image.Image, ext string) (CachedImage, error) {
 	var buf bytes.Buffer
-	if subscribed {
-		log.Println("✅ Image originale envoyée (pas de watermark)")
-		if ext == ".png" {
-			err = png.Encode(&buf, img)
-			c.Header("Content-Type", "image/png")
-		} else {
-			err = jpeg.Encode(&buf, img, nil)
-			c.Header("Content-Type", "image/jpeg")
-		}
+	var err error
+	contentType := "image/jpeg"
+	if ext == ".png" {
+		err = png.Encode(&buf, img)
+		contentType = "image/png"
 	} else {
-		log.Println("🔒 Image avec watermark")
-		watermarked := addWatermark(img, "Abonne-toi pour voir l'image !")
-		if ext == ".png" {
-			err = png.Encode(&buf, watermarked)
-			c.Header("Content-Type", "image/png")
-		} else {
-			err = jpeg.Encode(&buf, watermarked, nil)
-			c.Header("Content-Type", "image/jpeg")
-		}
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 92})
 	}
 	if err != nil {
 		log.Printf("❌ Erreur encoding image: %v", err)
-		return fmt.Errorf("erreur encoding image: %v", err)
+		return CachedImage{}, fmt.Errorf("erreur encoding image: %v", err)
 	}
+	return CachedImage{Data: buf.Bytes(), ContentType: contentType}, nil
+}
 
-	c.Header("Content-Disposition", "inline; filename="+filepath.Base(imagePath))
-	c.Data(200, c.GetHeader("Content-Type"), buf.Bytes())
-	return nil
+// notModified applique les règles de requête conditionnelle (If-None-Match
+// prioritaire sur If-Modified-Since).
+func notModified(r *http.Request, etag string, modTime time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		if t, err := http.ParseTime(ims); err == nil {
+			return !modTime.After(t)
+		}
+	}
+	return false
 }
 
 // Watermark visible (répété sur toute la largeur)
@@ -212,7 +626,29 @@ func (s *ContentService) UpdateContent(content *models.Content) error {
 }
 
 func (s *ContentService) DeleteContent(id uuid.UUID) error {
-	return s.repo.Delete(id, s.uploadPath)
+	revs, err := s.revisions.FindByContent(id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(id, s.uploadPath); err != nil {
+		return err
+	}
+	for _, rev := range revs {
+		if rev.PreviousFilePath == "" {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.uploadPath, rev.PreviousFilePath)); err != nil && !os.IsNotExist(err) {
+			log.Printf("⚠️ Suppression ancienne version %s: %v", rev.PreviousFilePath, err)
+		}
+	}
+	if err := s.revisions.DeleteByContent(id); err != nil {
+		log.Printf("⚠️ Suppression historique du contenu %s: %v", id, err)
+	}
+	if err := s.duplicates.Forget(id); err != nil {
+		log.Printf("⚠️ Suppression du hash du contenu %s: %v", id, err)
+	}
+	s.imageCache.InvalidateContent(id)
+	return nil
 }
 
 func (s *ContentService) GetContentsByUserID(userID uuid.UUID) ([]*models.Content, error) {
@@ -222,38 +658,13 @@ func (s *ContentService) GetContentsByUserID(userID uuid.UUID) ([]*models.Conten
 
 }
 
-func (s *ContentService) GetFeedContents(userID uuid.UUID) ([]map[string]interface{}, error) {
-	contents, err := s.repo.FindAllWithCreators()
-	if err != nil {
-		return nil, err
-	}
-
-	var feed []map[string]interface{}
-	for _, c := range contents {
-		isSub, _ := s.repo.IsUserSubscribedToCreator(userID, c.CreatorID)
-		count, _ := s.repo.CountContentLikes(c.ID)
-		liked, _ := s.repo.IsContentLikedBy(userID, c.ID)
-
-		feed = append(feed, map[string]interface{}{
-			"id":            c.ID,
-			"title":         c.Title,
-			"body":          c.Body,
-			"price":         c.Price,
-			"file_path":     c.FilePath,
-			"creator_id":    c.CreatorID,
-			"creator_name":  c.Creator.Username,
-			"created_at":    c.CreatedAt,
-			"is_subscribed": isSub,
-			"likes_count":   count,
-			"liked_by_user": liked,
-		})
-	}
-	return feed, nil
-}
-
 // LikeContent enregistre un like pour un user sur un content
 func (s *ContentService) LikeContent(userID, contentID uuid.UUID) error {
-	return s.repo.CreateLike(userID, contentID)
+	if err := s.repo.CreateLike(userID, contentID); err != nil {
+		return err
+	}
+	s.events.Record(models.ContentEventLike, contentID, userID)
+	return nil
 }
 
 // UnlikeContent supprime un like existant
@@ -261,6 +672,17 @@ func (s *ContentService) UnlikeContent(userID, contentID uuid.UUID) error {
 	return s.repo.DeleteLike(userID, contentID)
 }
 
+// SetEventRecorder branche l'enregistrement des vues, likes, impressions et
+// téléchargements pour les statistiques des créateurs.
+func (s *ContentService) SetEventRecorder(events *ContentEventService) {
+	s.events = events
+}
+
+// RecordDownload compte un téléchargement du contenu.
+func (s *ContentService) RecordDownload(contentID, userID uuid.UUID) {
+	s.events.Record(models.ContentEventDownload, contentID, userID)
+}
+
 func (s *ContentService) CanDownload(userID, CreatorID uuid.UUID) bool {
 	isSub, _ := s.repo.IsUserSubscribedToCreator(userID, CreatorID)
 	println("CanDownload - isSub:", isSub)
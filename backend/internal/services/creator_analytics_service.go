@@ -0,0 +1,268 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var ErrInvalidInterval = errors.New("granularité invalide (day, week ou month)")
+
+const (
+	// renewalGrace : un réabonnement dans ce délai après l'échéance prolonge
+	// la relation au lieu de compter un départ puis un nouvel abonné.
+	renewalGrace = 3 * 24 * time.Hour
+
+	defaultCohortDays = 365
+	defaultTopFans    = 10
+)
+
+// CreatorAnalyticsService calcule les indicateurs d'activité d'un créateur :
+// abonnés, départs, revenu mensuel récurrent, cohortes et fans.
+type CreatorAnalyticsService struct {
+	repo *repositories.CreatorAnalyticsRepository
+	now  func() time.Time
+}
+
+func NewCreatorAnalyticsService(repo *repositories.CreatorAnalyticsRepository) *CreatorAnalyticsService {
+	return &CreatorAnalyticsService{repo: repo, now: time.Now}
+}
+
+// WithClock remplace l'horloge des calculs, pour des tests déterministes.
+func (s *CreatorAnalyticsService) WithClock(now func() time.Time) *CreatorAnalyticsService {
+	s.now = now
+	return s
+}
+
+// CreatorOverview résume une période ; Subscribers et MRRCents sont les
+// valeurs actuelles, le taux de départ est rapporté aux abonnés du début.
+type CreatorOverview struct {
+	From                time.Time `json:"from"`
+	To                  time.Time `json:"to"`
+	Subscribers         int64     `json:"subscribers"`
+	MRRCents            int64     `json:"mrr_cents"`
+	SubscribersAtStart  int64     `json:"subscribers_at_start"`
+	NewSubscribers      int64     `json:"new_subscribers"`
+	ChurnedSubscribers  int64     `json:"churned_subscribers"`
+	ChurnRate           float64   `json:"churn_rate"`
+	RevenueCents        int64     `json:"revenue_cents"`
+	AverageLifetimeDays float64   `json:"average_lifetime_days"`
+}
+
+// Overview renvoie les chiffres clés du créateur entre les jours from et to
+// inclus (30 derniers jours par défaut).
+func (s *CreatorAnalyticsService) Overview(creatorID uuid.UUID, from, to *time.Time) (*CreatorOverview, error) {
+	now := s.now()
+	start, end, err := analyticsRange(from, to, now, defaultAnalyticsDays)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.repo.SnapshotAt(creatorID, now)
+	if err != nil {
+		return nil, err
+	}
+	initial, err := s.repo.SnapshotAt(creatorID, start)
+	if err != nil {
+		return nil, err
+	}
+	periods, err := s.repo.SubscriberPeriods(creatorID, start, end, now, repositories.IntervalMonth, renewalGrace)
+	if err != nil {
+		return nil, err
+	}
+	revenue, err := s.repo.RevenueCents(creatorID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	lifetime, _, err := s.repo.AverageLifetimeDays(creatorID, now, renewalGrace)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &CreatorOverview{
+		From:                start,
+		To:                  end.AddDate(0, 0, -1),
+		Subscribers:         current.Subscribers,
+		MRRCents:            current.MRRCents,
+		SubscribersAtStart:  initial.Subscribers,
+		RevenueCents:        revenue,
+		AverageLifetimeDays: lifetime,
+	}
+	for _, p := range periods {
+		out.NewSubscribers += p.New
+		out.ChurnedSubscribers += p.Churned
+	}
+	out.ChurnRate = ratio(out.ChurnedSubscribers, out.SubscribersAtStart)
+	return out, nil
+}
+
+// SubscriberPoint est une période de la série des abonnés ; Subscribers et
+// MRRCents sont les valeurs en fin de période.
+type SubscriberPoint struct {
+	Period      string `json:"period"`
+	New         int64  `json:"new"`
+	Churned     int64  `json:"churned"`
+	Net         int64  `json:"net"`
+	Subscribers int64  `json:"subscribers"`
+	MRRCents    int64  `json:"mrr_cents"`
+}
+
+// SubscriberSeries est l'évolution des abonnés et du revenu mensuel
+// récurrent sur une période.
+type SubscriberSeries struct {
+	From     time.Time         `json:"from"`
+	To       time.Time         `json:"to"`
+	Interval string            `json:"interval"`
+	Points   []SubscriberPoint `json:"points"`
+}
+
+// Subscribers renvoie, par jour, semaine ou mois, les nouveaux abonnés, les
+// départs et le revenu mensuel récurrent entre les jours from et to inclus.
+func (s *CreatorAnalyticsService) Subscribers(creatorID uuid.UUID, from, to *time.Time, interval string) (*SubscriberSeries, error) {
+	if interval == "" {
+		interval = repositories.IntervalDay
+	}
+	if !repositories.ValidInterval(interval) {
+		return nil, ErrInvalidInterval
+	}
+	now := s.now()
+	start, end, err := analyticsRange(from, to, now, defaultAnalyticsDays)
+	if err != nil {
+		return nil, err
+	}
+	initial, err := s.repo.SnapshotAt(creatorID, start)
+	if err != nil {
+		return nil, err
+	}
+	periods, err := s.repo.SubscriberPeriods(creatorID, start, end, now, interval, renewalGrace)
+	if err != nil {
+		return nil, err
+	}
+	byPeriod := make(map[string]repositories.SubscriberPeriod, len(periods))
+	for _, p := range periods {
+		byPeriod[p.Period] = p
+	}
+
+	out := &SubscriberSeries{From: start, To: end.AddDate(0, 0, -1), Interval: interval, Points: []SubscriberPoint{}}
+	subscribers, mrr := initial.Subscribers, initial.MRRCents
+	for day := periodStart(start, interval); day.Before(end); day = nextPeriod(day, interval) {
+		key := day.Format(time.DateOnly)
+		p := byPeriod[key]
+		subscribers += p.New - p.Churned
+		mrr += p.MRRDeltaCents
+		out.Points = append(out.Points, SubscriberPoint{
+			Period:      key,
+			New:         p.New,
+			Churned:     p.Churned,
+			Net:         p.New - p.Churned,
+			Subscribers: subscribers,
+			MRRCents:    mrr,
+		})
+	}
+	return out, nil
+}
+
+// periodStart renvoie le premier jour de la période contenant day (semaines
+// du lundi au dimanche).
+func periodStart(day time.Time, interval string) time.Time {
+	switch interval {
+	case repositories.IntervalWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case repositories.IntervalMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+func nextPeriod(day time.Time, interval string) time.Time {
+	switch interval {
+	case repositories.IntervalWeek:
+		return day.AddDate(0, 0, 7)
+	case repositories.IntervalMonth:
+		return day.AddDate(0, 1, 0)
+	}
+	return day.AddDate(0, 0, 1)
+}
+
+// RetentionCohort suit les abonnés arrivés un même mois : Retained[k] est le
+// nombre encore abonnés k mois plus tard, Rates[k] la part de la cohorte.
+type RetentionCohort struct {
+	Cohort   string    `json:"cohort"`
+	Size     int64     `json:"size"`
+	Retained []int64   `json:"retained"`
+	Rates    []float64 `json:"rates"`
+}
+
+// Cohorts renvoie les cohortes mensuelles de rétention des abonnés arrivés
+// entre les jours from et to inclus (12 derniers mois par défaut).
+func (s *CreatorAnalyticsService) Cohorts(creatorID uuid.UUID, from, to *time.Time) ([]RetentionCohort, error) {
+	now := s.now()
+	start, end, err := analyticsRange(from, to, now, defaultCohortDays)
+	if err != nil {
+		return nil, err
+	}
+	fromMonth, toMonth, nowMonth := monthIndex(start), monthIndex(end.AddDate(0, 0, -1)), monthIndex(now)
+	if toMonth > nowMonth {
+		toMonth = nowMonth
+	}
+	cells, err := s.repo.RetentionCohorts(creatorID, fromMonth, toMonth, nowMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	cohorts := []RetentionCohort{}
+	byMonth := map[int]int{}
+	for _, cell := range cells {
+		i, ok := byMonth[cell.Cohort]
+		if !ok {
+			i = len(cohorts)
+			byMonth[cell.Cohort] = i
+			width := nowMonth - cell.Cohort + 1
+			cohorts = append(cohorts, RetentionCohort{
+				Cohort:   fmt.Sprintf("%04d-%02d", cell.Cohort/12, cell.Cohort%12+1),
+				Retained: make([]int64, width),
+				Rates:    make([]float64, width),
+			})
+		}
+		cohorts[i].Retained[cell.Offset] = cell.Retained
+	}
+	for i := range cohorts {
+		c := &cohorts[i]
+		c.Size = c.Retained[0]
+		for k, n := range c.Retained {
+			c.Rates[k] = ratio(n, c.Size)
+		}
+	}
+	return cohorts, nil
+}
+
+func monthIndex(t time.Time) int {
+	t = t.UTC()
+	return t.Year()*12 + int(t.Month()) - 1
+}
+
+// TopFans classe les utilisateurs les plus engagés sur les contenus du
+// créateur entre les jours from et to inclus, avec les poids des tendances.
+func (s *CreatorAnalyticsService) TopFans(creatorID uuid.UUID, from, to *time.Time, limit int) ([]repositories.EngagedFan, error) {
+	now := s.now()
+	start, end, err := analyticsRange(from, to, now, defaultAnalyticsDays)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultTopFans
+	}
+	limit, _ = Pagination(limit, 0)
+	weights := repositories.EngagementWeights{Like: trendingLikeWeight, Comment: trendingCommentWeight, View: trendingViewWeight}
+	fans, err := s.repo.TopFans(creatorID, start, end, now, weights, limit)
+	if err != nil {
+		return nil, err
+	}
+	if fans == nil {
+		fans = []repositories.EngagedFan{}
+	}
+	return fans, nil
+}
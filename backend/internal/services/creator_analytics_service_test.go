@@ -0,0 +1,151 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+var analyticsNow = time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+
+type analyticsFixture struct {
+	*testStore
+	svc     *services.CreatorAnalyticsService
+	creator *models.User
+	fans    map[string]*models.User
+}
+
+// setupCreatorAnalytics crée l'historique d'un créateur :
+//   - ana : mars, avril puis mai (réabonnements dans le délai de grâce), partie fin mai ;
+//   - bob : avril → 10 mai ;
+//   - cleo : 20 mai → 19 juin, en cours ;
+//   - dan : 5 juin, résilié le 10 ;
+//   - eve : 12 juin, en cours.
+func setupCreatorAnalytics(t *testing.T) *analyticsFixture {
+	f := &analyticsFixture{fans: map[string]*models.User{},
+		testStore: newTestStore(t, &models.User{}, &models.Content{}, &models.Subscription{},
+			&models.Payment{}, &models.Like{}, &models.Comment{}, &models.ContentEvent{}),
+		svc: services.NewCreatorAnalyticsService(repositories.NewCreatorAnalyticsRepository()).
+			WithClock(func() time.Time { return analyticsNow })}
+	f.creator = f.user("artist", models.RoleCreator)
+	for _, name := range []string{"ana", "bob", "cleo", "dan", "eve"} {
+		f.fans[name] = f.user(name, models.RoleSubscriber)
+	}
+
+	f.subscribe("ana", day(3, 1), day(3, 31), models.SubscriptionStatusExpired)
+	f.subscribe("ana", day(4, 1), day(5, 1), models.SubscriptionStatusExpired)
+	f.subscribe("ana", day(5, 2), day(5, 31).Add(12*time.Hour), models.SubscriptionStatusExpired)
+	f.subscribe("bob", day(4, 10), day(5, 10), models.SubscriptionStatusExpired)
+	f.subscribe("cleo", day(5, 20), day(6, 19), models.SubscriptionStatusActive)
+	f.subscribe("dan", day(6, 5), day(6, 10), models.SubscriptionStatusCanceled)
+	f.subscribe("eve", day(6, 12), day(7, 12), models.SubscriptionStatusActive)
+	return f
+}
+
+func (f *analyticsFixture) subscribe(fan string, start, end time.Time, status string) {
+	f.payment(f.subscription(f.fans[fan], f.creator, start, end, status), start, models.StatusSucceeded)
+}
+
+func TestCreatorAnalytics_Overview(t *testing.T) {
+	f := setupCreatorAnalytics(t)
+
+	from, to := day(6, 1), day(6, 15)
+	got, err := f.svc.Overview(f.creator.ID, &from, &to)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 2, got.Subscribers)
+	assert.EqualValues(t, 6000, got.MRRCents)
+	assert.EqualValues(t, 1, got.SubscribersAtStart)
+	assert.EqualValues(t, 2, got.NewSubscribers)
+	assert.EqualValues(t, 1, got.ChurnedSubscribers)
+	assert.InDelta(t, 1.0, got.ChurnRate, 1e-9)
+	assert.EqualValues(t, 6000, got.RevenueCents)
+	// ana 91,5 j, bob 30 j, cleo 26,5 j (en cours), dan 5 j, eve 3,5 j (en cours).
+	assert.InDelta(t, 31.3, got.AverageLifetimeDays, 1e-6)
+}
+
+func TestCreatorAnalytics_SubscriberSeries(t *testing.T) {
+	f := setupCreatorAnalytics(t)
+
+	from, to := day(5, 1), day(6, 15)
+	got, err := f.svc.Subscribers(f.creator.ID, &from, &to, repositories.IntervalMonth)
+	require.NoError(t, err)
+	require.Len(t, got.Points, 2)
+
+	// Mai : les réabonnements d'ana ne comptent ni comme arrivée ni comme départ.
+	may := got.Points[0]
+	assert.Equal(t, "2026-05-01", may.Period)
+	assert.EqualValues(t, 1, may.New)
+	assert.EqualValues(t, 2, may.Churned)
+	assert.EqualValues(t, 1, may.Subscribers)
+	assert.EqualValues(t, 3000, may.MRRCents)
+
+	june := got.Points[1]
+	assert.EqualValues(t, 2, june.New)
+	assert.EqualValues(t, 1, june.Churned)
+	assert.EqualValues(t, 2, june.Subscribers)
+	assert.EqualValues(t, 6000, june.MRRCents)
+
+	daily, err := f.svc.Subscribers(f.creator.ID, &from, &to, "")
+	require.NoError(t, err)
+	assert.Len(t, daily.Points, 46)
+
+	_, err = f.svc.Subscribers(f.creator.ID, &from, &to, "year")
+	assert.ErrorIs(t, err, services.ErrInvalidInterval)
+}
+
+func TestCreatorAnalytics_Cohorts(t *testing.T) {
+	f := setupCreatorAnalytics(t)
+
+	from := day(3, 1)
+	got, err := f.svc.Cohorts(f.creator.ID, &from, nil)
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+
+	assert.Equal(t, "2026-03", got[0].Cohort)
+	assert.Equal(t, []int64{1, 1, 1, 0}, got[0].Retained)
+	assert.Equal(t, []int64{1, 1, 0}, got[1].Retained)
+	assert.Equal(t, []int64{1, 1}, got[2].Retained)
+	assert.Equal(t, "2026-06", got[3].Cohort)
+	assert.EqualValues(t, 2, got[3].Size)
+	assert.Equal(t, []float64{1}, got[3].Rates)
+}
+
+func TestCreatorAnalytics_TopFans(t *testing.T) {
+	f := setupCreatorAnalytics(t)
+
+	var contents []*models.Content
+	for range 3 {
+		contents = append(contents, f.content(f.creator, "t", "approved", time.Time{}))
+	}
+	at := day(6, 10)
+	for _, c := range contents {
+		f.like(c, f.fans["bob"], at)
+		f.like(c, f.creator, at)
+		f.create(&models.ContentEvent{ContentID: c.ID, UserID: &f.fans["dan"].ID, Type: models.ContentEventView, OccurredAt: at})
+	}
+	f.create(
+		&models.Comment{ContentID: contents[0].ID, AuthorID: f.fans["cleo"].ID, Text: "bravo",
+			Status: models.TextStatusVisible, CreatedAt: at},
+		&models.Comment{ContentID: contents[1].ID, AuthorID: f.fans["eve"].ID, Text: "retenu",
+			Status: models.TextStatusHeld, CreatedAt: at})
+
+	got, err := f.svc.TopFans(f.creator.ID, nil, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+
+	assert.Equal(t, "bob", got[0].Username)
+	assert.EqualValues(t, 3, got[0].Likes)
+	assert.False(t, got[0].IsSubscribed)
+	assert.EqualValues(t, 3000, got[0].TotalSpentCents)
+	assert.Equal(t, "cleo", got[1].Username)
+	assert.True(t, got[1].IsSubscribed)
+	assert.Equal(t, "dan", got[2].Username)
+	assert.EqualValues(t, 3, got[2].Views)
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/bits"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/config"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var (
+	ErrDuplicateFlagNotFound = errors.New("signalement de doublon non trouvé")
+	ErrInvalidFlagDecision   = errors.New("décision invalide (confirmed ou dismissed)")
+)
+
+// minHashBits : une image presque uniforme a un hash quasi nul (ou plein) qui
+// ressemble à celui de toutes les autres images uniformes ; elle est indexée
+// mais ne déclenche pas de signalement.
+const minHashBits = 8
+
+// DuplicateMatch est un contenu visuellement proche d'une image.
+type DuplicateMatch struct {
+	Content  models.Content
+	Distance int
+}
+
+// DuplicateService détecte les ré-uploads d'œuvres existantes à partir de
+// hashs perceptuels comparés par distance de Hamming.
+type DuplicateService struct {
+	hashes      *repositories.ImageHashRepository
+	contents    *repositories.ContentRepository
+	moderation  *ModerationService
+	maxDistance int
+}
+
+func NewDuplicateService(
+	hashes *repositories.ImageHashRepository,
+	contents *repositories.ContentRepository,
+	moderation *ModerationService,
+) *DuplicateService {
+	maxDistance := config.C.DuplicateMaxDistance
+	// Au-delà, la recherche par octets ne garantit plus de trouver les candidats.
+	if maxDistance <= 0 || maxDistance >= models.ImageHashBands {
+		maxDistance = 6
+	}
+	return &DuplicateService{hashes: hashes, contents: contents, moderation: moderation, maxDistance: maxDistance}
+}
+
+// Index enregistre le hash d'un contenu, puis le signale s'il ressemble au
+// contenu d'un autre créateur publié avant lui. Un contenu signalé déjà en ligne
+// repasse en modération. Sans service configuré, rien n'est fait.
+func (s *DuplicateService) Index(content *models.Content, hash uint64) (*models.DuplicateFlag, error) {
+	if s == nil {
+		return nil, nil
+	}
+	if err := s.hashes.SaveHash(content.ID, hash); err != nil {
+		return nil, fmt.Errorf("enregistrement du hash: %w", err)
+	}
+	signed := int64(hash)
+	content.PHash = &signed
+	if ones := bits.OnesCount64(hash); ones < minHashBits || ones > 64-minHashBits {
+		return nil, nil
+	}
+
+	matches, err := s.FindSimilar(hash, content.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// L'original est le plus ancien contenu proche d'un autre créateur ; les
+	// autres correspondances sont en général des copies de ce même original.
+	var original *DuplicateMatch
+	for i, m := range matches {
+		if m.Content.CreatorID == content.CreatorID || !m.Content.CreatedAt.Before(content.CreatedAt) {
+			continue
+		}
+		if original == nil || m.Content.CreatedAt.Before(original.Content.CreatedAt) {
+			original = &matches[i]
+		}
+	}
+	if original == nil {
+		return nil, nil
+	}
+	exists, err := s.hashes.FlagExists(content.ID, original.Content.ID)
+	if err != nil || exists {
+		return nil, err
+	}
+
+	flag := models.DuplicateFlag{
+		ContentID:         content.ID,
+		OriginalContentID: original.Content.ID,
+		Distance:          original.Distance,
+		Status:            models.DuplicateFlagOpen,
+	}
+	if err := s.hashes.CreateFlag(&flag); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🕵️ Doublon probable : %s ressemble à %s (distance %d)", content.ID, original.Content.ID, original.Distance)
+	logger.LogContent("duplicate_flagged", content.CreatorID.String(), content.ID.String(), map[string]interface{}{
+		"original_content_id": original.Content.ID.String(),
+		"original_creator_id": original.Content.CreatorID.String(),
+		"distance":            original.Distance,
+	})
+
+	if content.Status == models.ContentStatusApproved || content.Status == models.ContentStatusScheduled {
+		if err := s.contents.UpdateStatus(content.ID, models.ContentStatusPending); err != nil {
+			return &flag, err
+		}
+		content.Status = models.ContentStatusPending
+	}
+	return &flag, nil
+}
+
+// Forget retire un contenu supprimé de l'index et de ses signalements.
+func (s *DuplicateService) Forget(contentID uuid.UUID) error {
+	if s == nil {
+		return nil
+	}
+	return s.hashes.DeleteByContent(contentID)
+}
+
+// FindSimilar renvoie les contenus à distance <= maxDistance de hash, du plus
+// proche au plus éloigné.
+func (s *DuplicateService) FindSimilar(hash uint64, exclude uuid.UUID) ([]DuplicateMatch, error) {
+	candidates, err := s.hashes.FindCandidates(hash, exclude)
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]DuplicateMatch, 0, len(candidates))
+	for _, c := range candidates {
+		if c.PHash == nil {
+			continue
+		}
+		if d := HammingDistance(hash, uint64(*c.PHash)); d <= s.maxDistance {
+			matches = append(matches, DuplicateMatch{Content: c, Distance: d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		return matches[i].Content.CreatedAt.Before(matches[j].Content.CreatedAt)
+	})
+	return matches, nil
+}
+
+// SearchByImage cherche les contenus proches d'une image envoyée (admin).
+func (s *DuplicateService) SearchByImage(r io.Reader) ([]DuplicateMatch, error) {
+	validated, err := ValidateImageUpload(r, DefaultUploadLimits())
+	if err != nil {
+		return nil, err
+	}
+	return s.FindSimilar(validated.PHash, uuid.Nil)
+}
+
+// ListFlags liste les signalements d'un statut (open par défaut).
+func (s *DuplicateService) ListFlags(status string, limit, offset int) ([]models.DuplicateFlag, error) {
+	if status == "" {
+		status = models.DuplicateFlagOpen
+	}
+	limit, offset = Pagination(limit, offset)
+	return s.hashes.FindFlags(status, limit, offset)
+}
+
+// ReviewFlag tranche un signalement : confirmé, la copie est rejetée pour
+// atteinte aux droits d'auteur ; écarté, le contenu suit la modération normale.
+func (s *DuplicateService) ReviewFlag(id, reviewerID uuid.UUID, decision string) (*models.DuplicateFlag, error) {
+	if decision != models.DuplicateFlagConfirmed && decision != models.DuplicateFlagDismissed {
+		return nil, ErrInvalidFlagDecision
+	}
+	flag, err := s.hashes.FindFlagByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if flag == nil {
+		return nil, ErrDuplicateFlagNotFound
+	}
+
+	now := time.Now()
+	flag.Status = decision
+	flag.ReviewedBy = &reviewerID
+	flag.ReviewedAt = &now
+	if err := s.hashes.UpdateFlag(flag); err != nil {
+		return nil, err
+	}
+	if decision == models.DuplicateFlagConfirmed {
+		note := fmt.Sprintf("Copie du contenu %s d'un autre créateur.", flag.OriginalContentID)
+		if _, err := s.moderation.Reject(flag.ContentID, reviewerID, models.ModerationReasonCopyright, note); err != nil &&
+			!errors.Is(err, ErrInvalidTransition) {
+			return nil, err
+		}
+	}
+	return flag, nil
+}
+
+// BackfillHashes calcule le hash des contenus antérieurs à la détection, par
+// lots, et renvoie le nombre de contenus indexés.
+func (s *ContentService) BackfillHashes(batch int) (int, error) {
+	if s.duplicates == nil {
+		return 0, nil
+	}
+	indexed, failed := 0, 0
+	for {
+		// Les contenus indexés sortent du lot suivant ; seuls les échecs restent.
+		contents, err := s.duplicates.hashes.FindUnhashed(batch, failed)
+		if err != nil {
+			return indexed, err
+		}
+		if len(contents) == 0 {
+			return indexed, nil
+		}
+		for i := range contents {
+			if !hasImagePreview(contents[i].FilePath) {
+				failed++
+				continue
+			}
+			img, _, err := decodeImageFile(filepath.Join(s.uploadPath, contents[i].FilePath))
+			if err != nil {
+				log.Printf("⚠️ Hash perceptuel impossible pour %s: %v", contents[i].ID, err)
+				failed++
+				continue
+			}
+			if _, err := s.duplicates.Index(&contents[i], DifferenceHash(img)); err != nil {
+				return indexed, err
+			}
+			indexed++
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+// artwork dessine une image déterministe en blocs de luminosité aléatoire ;
+// seed change le motif.
+func artwork(w, h int, seed int64) *image.RGBA {
+	rng := rand.New(rand.NewSource(seed))
+	var cells [12][12]color.RGBA
+	for y := range cells {
+		for x := range cells[y] {
+			v := uint8(rng.Intn(256))
+			cells[y][x] = color.RGBA{v, v / 2, 255 - v, 255}
+		}
+	}
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, cells[y*12/h][x*12/w])
+		}
+	}
+	return img
+}
+
+// resized réduit une image (plus proche voisin), comme une copie d'écran.
+func resized(src image.Image, w, h int) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			dst.Set(x, y, src.At(b.Min.X+x*b.Dx()/w, b.Min.Y+y*b.Dy()/h))
+		}
+	}
+	return dst
+}
+
+func validated(t *testing.T, img image.Image, asJPEG bool) *services.ValidatedImage {
+	var buf bytes.Buffer
+	if asJPEG {
+		require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 70}))
+	} else {
+		require.NoError(t, png.Encode(&buf, img))
+	}
+	v, err := services.ValidateImageUpload(&buf, services.DefaultUploadLimits())
+	require.NoError(t, err)
+	return v
+}
+
+func TestDifferenceHash_StableAcrossResizeAndReencode(t *testing.T) {
+	original := artwork(640, 480, 3)
+	copyHash := validated(t, resized(original, 320, 240), true).PHash
+	otherHash := validated(t, artwork(640, 480, 11), false).PHash
+
+	assert.LessOrEqual(t, services.HammingDistance(services.DifferenceHash(original), copyHash), 4)
+	assert.Greater(t, services.HammingDistance(services.DifferenceHash(original), otherHash), 10)
+}
+
+func TestDuplicateService_FlagsCopiesFromOtherCreators(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, database.MigrateSQLite(db, &models.User{}, &models.Content{}, &models.ContentRevision{},
+		&models.ModerationDecision{}, &models.ImageHashBand{}, &models.DuplicateFlag{}))
+	repositories.SetTestDB(db)
+
+	contentRepo := repositories.NewContentRepository()
+	moderation := services.NewModerationService(contentRepo, repositories.NewModerationRepository(), nil)
+	dups := services.NewDuplicateService(repositories.NewImageHashRepository(), contentRepo, moderation)
+	svc := services.NewContentService(contentRepo, repositories.NewContentRevisionRepository(), t.TempDir(), nil, dups, nil)
+
+	author, thief := uuid.New(), uuid.New()
+	art := artwork(640, 480, 3)
+	original, err := svc.CreateContentFromImage(author, "author", "Original", "b", 5,
+		validated(t, art, false), models.PreviewSettings{}, services.ContentPublication{})
+	require.NoError(t, err)
+	require.NoError(t, contentRepo.Approve(original.ID, original.CreatedAt))
+
+	// Même créateur : une variante de sa propre œuvre n'est pas signalée.
+	_, err = svc.CreateContentFromImage(author, "author", "Variante", "b", 5,
+		validated(t, resized(art, 400, 300), true), models.PreviewSettings{}, services.ContentPublication{})
+	require.NoError(t, err)
+	flags, err := dups.ListFlags("", 0, 0)
+	require.NoError(t, err)
+	assert.Empty(t, flags)
+
+	copied, err := svc.CreateContentFromImage(thief, "thief", "Mon œuvre", "b", 5,
+		validated(t, resized(art, 320, 240), true), models.PreviewSettings{}, services.ContentPublication{})
+	require.NoError(t, err)
+	_, err = svc.CreateContentFromImage(thief, "thief", "Autre", "b", 5,
+		validated(t, artwork(640, 480, 11), false), models.PreviewSettings{}, services.ContentPublication{})
+	require.NoError(t, err)
+
+	flags, err = dups.ListFlags(models.DuplicateFlagOpen, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, flags, 1)
+	assert.Equal(t, copied.ID, flags[0].ContentID)
+	assert.Equal(t, original.ID, flags[0].OriginalContentID)
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, resized(art, 500, 375)))
+	matches, err := dups.SearchByImage(&buf)
+	require.NoError(t, err)
+	assert.Len(t, matches, 3)
+
+	_, err = dups.ReviewFlag(flags[0].ID, uuid.New(), "maybe")
+	assert.ErrorIs(t, err, services.ErrInvalidFlagDecision)
+	_, err = dups.ReviewFlag(flags[0].ID, uuid.New(), models.DuplicateFlagConfirmed)
+	require.NoError(t, err)
+	rejected, err := contentRepo.FindByID(copied.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ContentStatusRejected, rejected.Status)
+
+	require.NoError(t, svc.DeleteContent(copied.ID))
+	flags, err = dups.ListFlags(models.DuplicateFlagConfirmed, 0, 0)
+	require.NoError(t, err)
+	assert.Empty(t, flags)
+}
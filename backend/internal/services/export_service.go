@@ -0,0 +1,519 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/config"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var (
+	ErrInvalidExportDataset = errors.New("jeu de données d'export inconnu")
+	ErrExportNotFound       = errors.New("export introuvable ou expiré")
+	ErrExportNotReady       = errors.New("export en cours de génération")
+	ErrExportFailed         = errors.New("la génération de l'export a échoué")
+)
+
+// Périmètres d'export : l'administration voit toute la plateforme, un
+// créateur uniquement ses abonnés et ses revenus.
+const (
+	ExportScopeAdmin   = "admin"
+	ExportScopeCreator = "creator"
+)
+
+const (
+	// exportWorkers borne le nombre d'exports générés en parallèle.
+	exportWorkers      = 2
+	defaultExportDays  = 30
+	maxExportJobsShown = 20
+	// Une instance renouvelle le bail de ses exports en cours toutes les
+	// exportHeartbeat ; passé exportLease sans renouvellement, l'export est
+	// considéré comme perdu.
+	exportHeartbeat = time.Minute
+	exportLease     = 5 * time.Minute
+)
+
+// ExportRequest décrit un export : jeu de données, format et filtres, ceux
+// des écrans correspondants. Days ne sert qu'au revenu par jour.
+type ExportRequest struct {
+	Scope   string                    `json:"scope"`
+	Dataset string                    `json:"dataset"`
+	Format  string                    `json:"format"`
+	Filter  repositories.ExportFilter `json:"filter"`
+	Days    int                       `json:"days,omitempty"`
+	// Async force la génération en tâche de fond quelle que soit la taille.
+	Async bool `json:"-"`
+}
+
+// exportDataset décrit un jeu de données : ses colonnes, son nombre de
+// lignes (nil s'il est toujours petit) et son parcours.
+type exportDataset struct {
+	header []string
+	count  func(s *ExportService, req ExportRequest) (int64, error)
+	rows   func(s *ExportService, req ExportRequest, emit func(cells ...interface{}) error) error
+}
+
+var exportDatasets = map[string]map[string]exportDataset{
+	ExportScopeAdmin: {
+		"users": {
+			header: []string{"id", "username", "email", "role", "age_verification_status", "created_at"},
+			count:  func(s *ExportService, req ExportRequest) (int64, error) { return s.repo.CountUsers(req.Filter) },
+			rows: func(s *ExportService, req ExportRequest, emit func(...interface{}) error) error {
+				return s.repo.EachUser(req.Filter, func(u repositories.ExportUser) error {
+					return emit(u.ID, u.Username, u.Email, u.Role, u.AgeVerificationStatus, u.CreatedAt)
+				})
+			},
+		},
+		"contents": {
+			header: []string{"id", "title", "creator", "status", "maturity", "price", "created_at", "published_at"},
+			count:  func(s *ExportService, req ExportRequest) (int64, error) { return s.repo.CountContents(req.Filter) },
+			rows: func(s *ExportService, req ExportRequest, emit func(...interface{}) error) error {
+				return s.repo.EachContent(req.Filter, func(c repositories.ExportContent) error {
+					return emit(c.ID, c.Title, c.Creator, c.Status, c.Maturity, c.Price, c.CreatedAt, c.PublishedAt)
+				})
+			},
+		},
+		"reports": {
+			header: []string{"id", "target_type", "target_id", "reason", "status", "reporter", "assignee_id",
+				"resolution_action", "created_at", "resolved_at"},
+			count: func(s *ExportService, req ExportRequest) (int64, error) { return s.repo.CountReports(req.Filter) },
+			rows: func(s *ExportService, req ExportRequest, emit func(...interface{}) error) error {
+				return s.repo.EachReport(req.Filter, func(r repositories.ExportReport) error {
+					return emit(r.ID, r.TargetType, r.TargetID, r.ReasonCode, r.Status, r.Reporter, r.AssigneeID,
+						r.ResolutionAction, r.CreatedAt, r.ResolvedAt)
+				})
+			},
+		},
+		"payments": {
+			header: []string{"id", "paid_at", "status", "amount_cents", "subscription_id", "subscriber", "creator"},
+			count:  func(s *ExportService, req ExportRequest) (int64, error) { return s.repo.CountPayments(req.Filter) },
+			rows: func(s *ExportService, req ExportRequest, emit func(...interface{}) error) error {
+				return s.repo.EachPayment(req.Filter, func(p repositories.ExportPayment) error {
+					return emit(p.ID, p.PaidAt, p.Status, p.Amount, p.SubscriptionID, p.Subscriber, p.Creator)
+				})
+			},
+		},
+		"revenue": {
+			header: []string{"day", "payments", "revenue_cents", "new_subscriptions", "cancellations"},
+			rows: func(s *ExportService, req ExportRequest, emit func(...interface{}) error) error {
+				from, to := s.revenueRange(req)
+				stats, err := s.stats.GetDailyStatsBetween(from, to)
+				if err != nil {
+					return err
+				}
+				for _, st := range stats {
+					if err := emit(st.Day.Format(time.DateOnly), st.Payments, st.RevenueCents,
+						st.NewSubscriptions, st.Cancellations); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		"creator-earnings": {
+			header: []string{"creator_id", "username", "email", "payments", "subscribers", "revenue_cents"},
+			count: func(s *ExportService, req ExportRequest) (int64, error) {
+				return s.repo.CountCreatorEarnings(req.Filter)
+			},
+			rows: func(s *ExportService, req ExportRequest, emit func(...interface{}) error) error {
+				return s.repo.EachCreatorEarning(req.Filter, func(e repositories.ExportCreatorEarning) error {
+					return emit(e.CreatorID, e.Username, e.Email, e.Payments, e.Subscribers, e.RevenueCents)
+				})
+			},
+		},
+	},
+	ExportScopeCreator: {
+		"subscribers": {
+			header: []string{"subscriber", "start_date", "end_date", "status", "price_cents", "canceled_at"},
+			count:  func(s *ExportService, req ExportRequest) (int64, error) { return s.repo.CountSubscribers(req.Filter) },
+			rows: func(s *ExportService, req ExportRequest, emit func(...interface{}) error) error {
+				return s.repo.EachSubscriber(req.Filter, func(sub repositories.ExportSubscriber) error {
+					return emit(sub.Subscriber, sub.StartDate, sub.EndDate, sub.Status, sub.Price, sub.CanceledAt)
+				})
+			},
+		},
+		"earnings": {
+			header: []string{"id", "paid_at", "status", "amount_cents", "subscription_id", "subscriber"},
+			count:  func(s *ExportService, req ExportRequest) (int64, error) { return s.repo.CountPayments(req.Filter) },
+			rows: func(s *ExportService, req ExportRequest, emit func(...interface{}) error) error {
+				return s.repo.EachPayment(req.Filter, func(p repositories.ExportPayment) error {
+					return emit(p.ID, p.PaidAt, p.Status, p.Amount, p.SubscriptionID, p.Subscriber)
+				})
+			},
+		},
+	},
+}
+
+// ExportService génère les exports CSV et XLSX : servis directement quand
+// ils sont petits, générés en tâche de fond puis téléchargeables sinon.
+type ExportService struct {
+	repo      *repositories.ExportRepository
+	jobs      *repositories.ExportJobRepository
+	stats     *AdminStatsService
+	dir       string
+	instance  string
+	asyncRows int64
+	ttl       time.Duration
+	now       func() time.Time
+	slots     chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewExportService(
+	repo *repositories.ExportRepository,
+	jobs *repositories.ExportJobRepository,
+	stats *AdminStatsService,
+	dir string,
+) *ExportService {
+	asyncRows := config.C.ExportAsyncRows
+	if asyncRows <= 0 {
+		asyncRows = 5000
+	}
+	ttl := config.C.ExportTTL
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	instance := config.C.InstanceID
+	if instance == "" {
+		instance, _ = os.Hostname()
+	}
+	return &ExportService{
+		repo:      repo,
+		jobs:      jobs,
+		stats:     stats,
+		dir:       dir,
+		instance:  instance,
+		asyncRows: asyncRows,
+		ttl:       ttl,
+		now:       time.Now,
+		slots:     make(chan struct{}, exportWorkers),
+	}
+}
+
+// WithAsyncThreshold change le nombre de lignes au-delà duquel un export
+// passe en tâche de fond.
+func (s *ExportService) WithAsyncThreshold(rows int64) *ExportService {
+	s.asyncRows = rows
+	return s
+}
+
+// WithInstance change l'identifiant de l'instance qui génère les exports.
+func (s *ExportService) WithInstance(id string) *ExportService {
+	s.instance = id
+	return s
+}
+
+// prepare valide la demande et la restreint au créateur qui la fait.
+func (s *ExportService) prepare(ownerID uuid.UUID, req ExportRequest) (ExportRequest, exportDataset, error) {
+	if _, ok := exportContentTypes[req.Format]; !ok {
+		return req, exportDataset{}, ErrInvalidExportFormat
+	}
+	ds, ok := exportDatasets[req.Scope][req.Dataset]
+	if !ok {
+		return req, exportDataset{}, ErrInvalidExportDataset
+	}
+	if req.Scope == ExportScopeCreator {
+		req.Filter = repositories.ExportFilter{From: req.Filter.From, To: req.Filter.To, Status: req.Filter.Status, CreatorID: &ownerID}
+	}
+	if req.Days <= 0 {
+		req.Days = defaultExportDays
+	}
+	if req.Filter.From != nil && req.Filter.To != nil && !req.Filter.From.Before(*req.Filter.To) {
+		return req, exportDataset{}, ErrInvalidDateRange
+	}
+	if req.Dataset == "revenue" {
+		if from, to := s.revenueRange(req); to.Sub(from) > maxAnalyticsDays*24*time.Hour {
+			return req, exportDataset{}, ErrInvalidDateRange
+		}
+	}
+	return req, ds, nil
+}
+
+// revenueRange renvoie la période de l'export revenue : from/to s'ils sont
+// donnés, sinon les Days derniers jours (aujourd'hui compris) jusqu'à to.
+func (s *ExportService) revenueRange(req ExportRequest) (time.Time, time.Time) {
+	to := utcDay(s.now()).AddDate(0, 0, 1)
+	if req.Filter.To != nil {
+		to = *req.Filter.To
+	}
+	from := to.AddDate(0, 0, -req.Days)
+	if req.Filter.From != nil {
+		from = *req.Filter.From
+	}
+	return from, to
+}
+
+// Schedule lance la génération en tâche de fond d'un export volumineux (ou
+// demandé comme tel) et renvoie son suivi ; nil si l'export peut être servi
+// directement par Write.
+func (s *ExportService) Schedule(ownerID uuid.UUID, req ExportRequest) (*models.ExportJob, error) {
+	req, ds, err := s.prepare(ownerID, req)
+	if err != nil {
+		return nil, err
+	}
+	if !req.Async {
+		if ds.count == nil {
+			return nil, nil
+		}
+		n, err := ds.count(s, req)
+		if err != nil {
+			return nil, err
+		}
+		if n <= s.asyncRows {
+			return nil, nil
+		}
+	}
+
+	params, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	job := &models.ExportJob{
+		OwnerID:     ownerID,
+		Scope:       req.Scope,
+		Dataset:     req.Dataset,
+		Format:      req.Format,
+		Params:      string(params),
+		Status:      models.ExportStatusPending,
+		InstanceID:  s.instance,
+		HeartbeatAt: now,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+	if err := s.jobs.Create(job); err != nil {
+		return nil, err
+	}
+	log.Printf("📦 Export différé créé - id: %s | %s/%s.%s | user: %s", job.ID, req.Scope, req.Dataset, req.Format, ownerID)
+	s.wg.Add(1)
+	go s.run(*job, req, ds)
+	return job, nil
+}
+
+// Write écrit l'export dans w et renvoie le nombre de lignes de données.
+func (s *ExportService) Write(w io.Writer, ownerID uuid.UUID, req ExportRequest) (int64, error) {
+	req, ds, err := s.prepare(ownerID, req)
+	if err != nil {
+		return 0, err
+	}
+	return s.write(w, req, ds)
+}
+
+func (s *ExportService) write(w io.Writer, req ExportRequest, ds exportDataset) (int64, error) {
+	rw, err := newRowWriter(w, req.Format)
+	if err != nil {
+		return 0, err
+	}
+	header := make([]interface{}, len(ds.header))
+	for i, h := range ds.header {
+		header[i] = h
+	}
+	if err := rw.Write(header); err != nil {
+		return 0, err
+	}
+	var n int64
+	err = ds.rows(s, req, func(cells ...interface{}) error {
+		n++
+		return rw.Write(cells)
+	})
+	if err != nil {
+		return n, err
+	}
+	return n, rw.Close()
+}
+
+// ExportFilename renvoie le nom du fichier téléchargé.
+func ExportFilename(dataset, format string, at time.Time) string {
+	return fmt.Sprintf("artfans-%s-%s.%s", dataset, at.UTC().Format("20060102"), format)
+}
+
+// run génère le fichier d'un export différé ; le fichier n'est visible
+// qu'une fois complet.
+func (s *ExportService) run(job models.ExportJob, req ExportRequest, ds exportDataset) {
+	defer s.wg.Done()
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+
+	job.Status = models.ExportStatusRunning
+	job.HeartbeatAt = s.now()
+	if err := s.jobs.Update(&job); err != nil {
+		logger.LogError(err, "export_job_update_failed", map[string]interface{}{"export_id": job.ID.String()})
+	}
+
+	path := filepath.Join(s.dir, job.ID.String()+"."+job.Format)
+	rows, err := s.writeFile(path, req, ds)
+	now := s.now()
+	job.CompletedAt = &now
+	job.ExpiresAt = now.Add(s.ttl)
+	if err != nil {
+		job.Status = models.ExportStatusFailed
+		job.Error = ErrExportFailed.Error()
+		logger.LogError(err, "export_job_failed", map[string]interface{}{
+			"export_id": job.ID.String(),
+			"dataset":   job.Dataset,
+		})
+	} else {
+		job.Status = models.ExportStatusCompleted
+		job.Rows = rows
+		job.FilePath = path
+		log.Printf("📦 Export %s terminé - %s | %d ligne(s)", job.ID, job.Dataset, rows)
+	}
+	if err := s.jobs.Update(&job); err != nil {
+		logger.LogError(err, "export_job_update_failed", map[string]interface{}{"export_id": job.ID.String()})
+	}
+}
+
+func (s *ExportService) writeFile(path string, req ExportRequest, ds exportDataset) (int64, error) {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return 0, err
+	}
+	part := path + ".part"
+	f, err := os.Create(part)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := s.write(f, req, ds)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(part, path)
+	}
+	if err != nil {
+		os.Remove(part)
+		return 0, err
+	}
+	return rows, nil
+}
+
+// Wait attend la fin des exports en cours de génération.
+func (s *ExportService) Wait() {
+	s.wg.Wait()
+}
+
+// FailInterrupted marque en échec les exports que cette instance a laissés en
+// attente ou en cours en s'arrêtant ; à appeler au démarrage, avant tout
+// nouvel export. Ceux des autres réplicas ne sont pas touchés.
+func (s *ExportService) FailInterrupted() {
+	n, err := s.jobs.FailUnfinished(s.instance, "export interrompu par un redémarrage du serveur")
+	if err != nil {
+		logger.LogError(err, "export_fail_unfinished_failed", nil)
+		return
+	}
+	if n > 0 {
+		log.Printf("⚠️ %d export(s) interrompu(s) marqué(s) en échec", n)
+	}
+}
+
+// RenewLeases renouvelle le bail des exports en cours de cette instance et
+// marque en échec ceux dont le bail a expiré (réplica arrêtée ou remplacée) ;
+// renvoie le nombre d'exports mis en échec.
+func (s *ExportService) RenewLeases() (int64, error) {
+	now := s.now()
+	if err := s.jobs.Heartbeat(s.instance, now); err != nil {
+		return 0, err
+	}
+	return s.jobs.FailStale(now.Add(-exportLease), "export interrompu par l'arrêt de son serveur")
+}
+
+// Job renvoie un export différé de l'utilisateur.
+func (s *ExportService) Job(ownerID, id uuid.UUID) (*models.ExportJob, error) {
+	job, err := s.jobs.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil || job.OwnerID != ownerID || s.now().After(job.ExpiresAt) {
+		return nil, ErrExportNotFound
+	}
+	return job, nil
+}
+
+// Jobs renvoie les derniers exports différés de l'utilisateur.
+func (s *ExportService) Jobs(ownerID uuid.UUID) ([]models.ExportJob, error) {
+	jobs, err := s.jobs.FindByOwner(ownerID, maxExportJobsShown)
+	if err != nil {
+		return nil, err
+	}
+	if jobs == nil {
+		jobs = []models.ExportJob{}
+	}
+	return jobs, nil
+}
+
+// File renvoie le chemin et le nom de téléchargement d'un export terminé.
+func (s *ExportService) File(ownerID, id uuid.UUID) (string, string, error) {
+	job, err := s.Job(ownerID, id)
+	if err != nil {
+		return "", "", err
+	}
+	switch job.Status {
+	case models.ExportStatusCompleted:
+		return job.FilePath, ExportFilename(job.Dataset, job.Format, job.CreatedAt), nil
+	case models.ExportStatusFailed:
+		return "", "", ErrExportFailed
+	}
+	return "", "", ErrExportNotReady
+}
+
+// CleanupExpired supprime les exports expirés et leurs fichiers.
+func (s *ExportService) CleanupExpired() (int, error) {
+	jobs, err := s.jobs.FindExpired(s.now())
+	if err != nil {
+		return 0, err
+	}
+	for _, job := range jobs {
+		if job.FilePath != "" {
+			if err := os.Remove(job.FilePath); err != nil && !os.IsNotExist(err) {
+				log.Printf("⚠️ Suppression fichier d'export %s: %v", job.ID, err)
+			}
+		}
+		if err := s.jobs.Delete(job.ID); err != nil {
+			return 0, err
+		}
+	}
+	return len(jobs), nil
+}
+
+// StartCleanup lance le nettoyage périodique des exports expirés et le
+// renouvellement des baux des exports en cours.
+func (s *ExportService) StartCleanup(interval time.Duration) {
+	go func() {
+		cleanup := time.NewTicker(interval)
+		defer cleanup.Stop()
+		heartbeat := time.NewTicker(exportHeartbeat)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-heartbeat.C:
+				n, err := s.RenewLeases()
+				if err != nil {
+					logger.LogError(err, "export_heartbeat_failed", nil)
+					continue
+				}
+				if n > 0 {
+					log.Printf("⚠️ %d export(s) sans serveur marqué(s) en échec", n)
+				}
+			case <-cleanup.C:
+				n, err := s.CleanupExpired()
+				if err != nil {
+					logger.LogError(err, "export_cleanup_failed", nil)
+					continue
+				}
+				if n > 0 {
+					log.Printf("🧹 %d export(s) expiré(s) supprimé(s)", n)
+				}
+			}
+		}
+	}()
+}
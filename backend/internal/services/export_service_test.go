@@ -0,0 +1,276 @@
+package services_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+type exportFixture struct {
+	*testStore
+	svc           *services.ExportService
+	admin         *models.User
+	alice, bruno  *models.User
+	fan1, fan2    *models.User
+	aliceSubs     int
+	firstReportID uuid.UUID
+}
+
+// setupExport : alice a deux abonnés payants, bruno un paiement échoué ;
+// deux signalements dont un traité.
+func setupExport(t *testing.T) *exportFixture {
+	// Les exports différés tournent dans d'autres goroutines, donc d'autres
+	// connexions.
+	f := &exportFixture{testStore: newTestStore(t, &models.User{}, &models.Content{}, &models.Subscription{},
+		&models.Payment{}, &models.Report{}, &models.Like{}, &models.Comment{}, &models.ContentEvent{},
+		&models.PlatformDailyStat{}, &models.ExportJob{})}
+	f.svc = services.NewExportService(repositories.NewExportRepository(), repositories.NewExportJobRepository(),
+		services.NewAdminStatsService(repositories.NewAdminStatsRepository()), t.TempDir())
+
+	f.admin = f.user("admin", models.RoleAdmin, joined(day(1, 5)))
+	f.alice = f.user("alice", models.RoleCreator, joined(day(2, 1)))
+	f.bruno = f.user("=bruno", models.RoleCreator, joined(day(5, 20)))
+	f.fan1 = f.user("fan1", models.RoleSubscriber, joined(day(5, 2)))
+	f.fan2 = f.user("fan2", models.RoleSubscriber, joined(day(6, 1)))
+
+	f.paid(f.fan1, f.alice, day(5, 3), models.StatusSucceeded)
+	f.paid(f.fan2, f.alice, day(6, 2), models.StatusSucceeded)
+	f.paid(f.fan1, f.bruno, day(6, 3), models.StatusFailed)
+	f.aliceSubs = 2
+
+	open := &models.Report{TargetType: models.ReportTargetUser, TargetID: f.bruno.ID, ReporterID: f.fan1.ID,
+		ReasonCode: models.ReportReasonSpam, Status: models.ReportStatusOpen, CreatedAt: day(6, 4)}
+	resolvedAt := day(6, 6)
+	f.create(open, &models.Report{TargetType: models.ReportTargetUser, TargetID: f.alice.ID, ReporterID: f.fan2.ID,
+		ReasonCode: models.ReportReasonScam, Status: models.ReportStatusDismissed, CreatedAt: day(6, 5),
+		ResolvedAt: &resolvedAt})
+	f.firstReportID = open.ID
+	return f
+}
+
+func readCSV(t *testing.T, raw []byte) [][]string {
+	require.True(t, bytes.HasPrefix(raw, []byte("\ufeff")), "BOM UTF-8 attendu")
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(raw, []byte("\ufeff")))).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+func TestExport_CSVRespectsFilters(t *testing.T) {
+	f := setupExport(t)
+
+	var buf bytes.Buffer
+	from, to := day(5, 1), day(6, 1)
+	n, err := f.svc.Write(&buf, f.admin.ID, services.ExportRequest{Scope: services.ExportScopeAdmin, Dataset: "users",
+		Format: services.ExportFormatCSV, Filter: repositories.ExportFilter{From: &from, To: &to}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+	records := readCSV(t, buf.Bytes())
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"id", "username", "email", "role", "age_verification_status", "created_at"}, records[0])
+	assert.Equal(t, "fan1", records[1][1])
+	// Un pseudo commençant par « = » n'est pas interprété comme une formule.
+	assert.Equal(t, "'=bruno", records[2][1])
+	assert.Equal(t, "2026-05-20T00:00:00Z", records[2][5])
+
+	buf.Reset()
+	_, err = f.svc.Write(&buf, f.admin.ID, services.ExportRequest{Scope: services.ExportScopeAdmin, Dataset: "reports",
+		Format: services.ExportFormatCSV, Filter: repositories.ExportFilter{
+			Report: repositories.ReportFilter{Status: models.ReportStatusOpen}}})
+	require.NoError(t, err)
+	records = readCSV(t, buf.Bytes())
+	require.Len(t, records, 2)
+	assert.Equal(t, f.firstReportID.String(), records[1][0])
+	assert.Equal(t, "fan1", records[1][5])
+	assert.Empty(t, records[1][9], "pas encore traité")
+
+	buf.Reset()
+	_, err = f.svc.Write(&buf, f.admin.ID, services.ExportRequest{Scope: services.ExportScopeAdmin, Dataset: "creator-earnings",
+		Format: services.ExportFormatCSV})
+	require.NoError(t, err)
+	records = readCSV(t, buf.Bytes())
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{f.alice.ID.String(), "alice", "alice@test", "2", "2", "6000"}, records[1])
+	assert.Equal(t, "0", records[2][5], "le paiement échoué ne compte pas")
+}
+
+func TestExport_XLSXWorkbook(t *testing.T) {
+	f := setupExport(t)
+
+	var buf bytes.Buffer
+	n, err := f.svc.Write(&buf, f.admin.ID, services.ExportRequest{Scope: services.ExportScopeAdmin, Dataset: "payments",
+		Format: services.ExportFormatXLSX, Filter: repositories.ExportFilter{Status: string(models.StatusSucceeded)}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	parts := map[string]string{}
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		body, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		parts[file.Name] = string(body)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		assert.Contains(t, parts, name)
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.Equal(t, 3, strings.Count(sheet, "<row "))
+	assert.Contains(t, sheet, `<c r="D2"><v>3000</v></c>`, "montant numérique")
+	assert.Contains(t, sheet, `<c r="G3" t="inlineStr"><is><t xml:space="preserve">alice</t></is></c>`)
+}
+
+func TestExport_CreatorOnlySeesOwnData(t *testing.T) {
+	f := setupExport(t)
+
+	var buf bytes.Buffer
+	// Le filtre créateur transmis est ignoré : seules les données d'alice sortent.
+	_, err := f.svc.Write(&buf, f.alice.ID, services.ExportRequest{Scope: services.ExportScopeCreator, Dataset: "earnings",
+		Format: services.ExportFormatCSV, Filter: repositories.ExportFilter{CreatorID: &f.bruno.ID}})
+	require.NoError(t, err)
+	records := readCSV(t, buf.Bytes())
+	require.Len(t, records, f.aliceSubs+1)
+	assert.Equal(t, []string{"id", "paid_at", "status", "amount_cents", "subscription_id", "subscriber"}, records[0])
+	assert.Equal(t, "fan1", records[1][5])
+
+	buf.Reset()
+	from := day(6, 1)
+	_, err = f.svc.Write(&buf, f.alice.ID, services.ExportRequest{Scope: services.ExportScopeCreator, Dataset: "subscribers",
+		Format: services.ExportFormatCSV, Filter: repositories.ExportFilter{From: &from}})
+	require.NoError(t, err)
+	records = readCSV(t, buf.Bytes())
+	require.Len(t, records, 2)
+	assert.Equal(t, "fan2", records[1][0])
+
+	_, err = f.svc.Write(&buf, f.alice.ID, services.ExportRequest{Scope: services.ExportScopeCreator, Dataset: "users",
+		Format: services.ExportFormatCSV})
+	assert.ErrorIs(t, err, services.ErrInvalidExportDataset)
+	_, err = f.svc.Write(&buf, f.alice.ID, services.ExportRequest{Scope: services.ExportScopeCreator, Dataset: "earnings",
+		Format: "pdf"})
+	assert.ErrorIs(t, err, services.ErrInvalidExportFormat)
+}
+
+func TestExport_LargeExportRunsInBackground(t *testing.T) {
+	f := setupExport(t)
+	f.svc.WithAsyncThreshold(3)
+
+	small, err := f.svc.Schedule(f.admin.ID, services.ExportRequest{Scope: services.ExportScopeAdmin, Dataset: "payments",
+		Format: services.ExportFormatCSV})
+	require.NoError(t, err)
+	assert.Nil(t, small, "3 lignes : servi directement")
+
+	job, err := f.svc.Schedule(f.admin.ID, services.ExportRequest{Scope: services.ExportScopeAdmin, Dataset: "users",
+		Format: services.ExportFormatCSV})
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	f.svc.Wait()
+
+	got, err := f.svc.Job(f.admin.ID, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExportStatusCompleted, got.Status)
+	assert.EqualValues(t, 5, got.Rows)
+
+	path, filename, err := f.svc.File(f.admin.ID, job.ID)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(filename, "artfans-users-"))
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, readCSV(t, raw), 6)
+
+	_, _, err = f.svc.File(f.alice.ID, job.ID)
+	assert.ErrorIs(t, err, services.ErrExportNotFound)
+
+	// Une fois expiré, l'export et son fichier disparaissent.
+	require.NoError(t, f.db.Model(&models.ExportJob{}).Where("id = ?", job.ID).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+	removed, err := f.svc.CleanupExpired()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestExport_RevenueRespectsDateRange(t *testing.T) {
+	f := setupExport(t)
+	for d := 1; d <= 4; d++ {
+		f.create(&models.PlatformDailyStat{Day: day(5, d), Payments: int64(d),
+			RevenueCents: int64(d) * models.SubscriptionPriceCents})
+	}
+
+	var buf bytes.Buffer
+	from, to := day(5, 2), day(5, 4)
+	n, err := f.svc.Write(&buf, f.admin.ID, services.ExportRequest{Scope: services.ExportScopeAdmin, Dataset: "revenue",
+		Format: services.ExportFormatCSV, Filter: repositories.ExportFilter{From: &from, To: &to}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+	records := readCSV(t, buf.Bytes())
+	require.Len(t, records, 3)
+	assert.Equal(t, "2026-05-02", records[1][0])
+	assert.Equal(t, "2026-05-03", records[2][0])
+	assert.Equal(t, "3", records[2][1])
+
+	// Au-delà d'un an, la période est refusée comme pour les autres statistiques.
+	from = day(5, 1).AddDate(-2, 0, 0)
+	_, err = f.svc.Write(&buf, f.admin.ID, services.ExportRequest{Scope: services.ExportScopeAdmin, Dataset: "revenue",
+		Format: services.ExportFormatCSV, Filter: repositories.ExportFilter{From: &from, To: &to}})
+	assert.ErrorIs(t, err, services.ErrInvalidDateRange)
+}
+
+func TestExport_InterruptedJobsFailAtStartup(t *testing.T) {
+	f := setupExport(t)
+	f.svc.WithInstance("api-1")
+	job := func(instance, status string, heartbeat time.Time) *models.ExportJob {
+		j := &models.ExportJob{ID: uuid.New(), OwnerID: f.admin.ID, Scope: services.ExportScopeAdmin, Dataset: "users",
+			Format: services.ExportFormatCSV, Status: status, InstanceID: instance, HeartbeatAt: heartbeat,
+			ExpiresAt: time.Now().Add(time.Hour)}
+		f.create(j)
+		return j
+	}
+	status := func(j *models.ExportJob) string {
+		got, err := f.svc.Job(f.admin.ID, j.ID)
+		require.NoError(t, err)
+		return got.Status
+	}
+	now, old := time.Now(), time.Now().Add(-time.Hour)
+	running := job("api-1", models.ExportStatusRunning, now)
+	pending := job("api-1", models.ExportStatusPending, now)
+	done := job("api-1", models.ExportStatusCompleted, old)
+	elsewhere := job("api-2", models.ExportStatusRunning, now)
+	lost := job("api-3", models.ExportStatusRunning, old)
+
+	f.svc.FailInterrupted()
+
+	for _, j := range []*models.ExportJob{running, pending} {
+		got, err := f.svc.Job(f.admin.ID, j.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ExportStatusFailed, got.Status)
+		assert.NotEmpty(t, got.Error)
+		_, _, err = f.svc.File(f.admin.ID, j.ID)
+		assert.ErrorIs(t, err, services.ErrExportFailed)
+	}
+	assert.Equal(t, models.ExportStatusCompleted, status(done))
+	assert.Equal(t, models.ExportStatusRunning, status(elsewhere), "export d'une autre réplica en cours")
+	assert.Equal(t, models.ExportStatusRunning, status(lost), "bail pas encore vérifié")
+
+	// Seul l'export dont le bail a expiré (réplica disparue) est abandonné.
+	n, err := f.svc.RenewLeases()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+	assert.Equal(t, models.ExportStatusFailed, status(lost))
+	assert.Equal(t, models.ExportStatusRunning, status(elsewhere))
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+// FeedScorer note un candidat du fil, idéalement entre 0 et 1 ; le
+// classement somme les notes pondérées de tous les scoreurs.
+type FeedScorer interface {
+	Name() string
+	Score(c *repositories.FeedCandidate, now time.Time) float64
+}
+
+// RecencyScorer décroît de moitié toutes les HalfLife.
+type RecencyScorer struct {
+	HalfLife time.Duration
+}
+
+func (RecencyScorer) Name() string { return "recency" }
+
+func (s RecencyScorer) Score(c *repositories.FeedCandidate, now time.Time) float64 {
+	age := max(now.Sub(c.CreatedAt), 0)
+	return math.Pow(0.5, float64(age)/float64(s.HalfLife))
+}
+
+// EngagementScorer valorise les likes et, davantage, les commentaires.
+type EngagementScorer struct{}
+
+func (EngagementScorer) Name() string { return "engagement" }
+
+func (EngagementScorer) Score(c *repositories.FeedCandidate, _ time.Time) float64 {
+	return logScale(float64(c.LikesCount+2*c.CommentsCount), 100)
+}
+
+// TrendingScorer valorise les likes récents.
+type TrendingScorer struct{}
+
+func (TrendingScorer) Name() string { return "trending" }
+
+func (TrendingScorer) Score(c *repositories.FeedCandidate, _ time.Time) float64 {
+	return logScale(float64(c.RecentLikes), 50)
+}
+
+// FollowingScorer favorise les créateurs auxquels le lecteur est abonné.
+type FollowingScorer struct{}
+
+func (FollowingScorer) Name() string { return "following" }
+
+func (FollowingScorer) Score(c *repositories.FeedCandidate, _ time.Time) float64 {
+	if c.IsSubscribed {
+		return 1
+	}
+	return 0
+}
+
+// TagAffinityScorer favorise les tags des contenus que le lecteur a aimés.
+type TagAffinityScorer struct{}
+
+func (TagAffinityScorer) Name() string { return "tags" }
+
+func (TagAffinityScorer) Score(c *repositories.FeedCandidate, _ time.Time) float64 {
+	return min(float64(c.SharedTags)/3, 1)
+}
+
+// CreatorAffinityScorer favorise les créateurs avec qui le lecteur a déjà
+// interagi (likes, commentaires).
+type CreatorAffinityScorer struct{}
+
+func (CreatorAffinityScorer) Name() string { return "affinity" }
+
+func (CreatorAffinityScorer) Score(c *repositories.FeedCandidate, _ time.Time) float64 {
+	return logScale(float64(c.CreatorAffinity), 20)
+}
+
+// SeenScorer signale les contenus déjà likés ; un poids négatif les fait
+// reculer dans le fil.
+type SeenScorer struct{}
+
+func (SeenScorer) Name() string { return "seen" }
+
+func (SeenScorer) Score(c *repositories.FeedCandidate, _ time.Time) float64 {
+	if c.LikedByUser {
+		return 1
+	}
+	return 0
+}
+
+// logScale ramène n entre 0 et 1, saturé à partir de ceiling.
+func logScale(n, ceiling float64) float64 {
+	return min(math.Log1p(n)/math.Log1p(ceiling), 1)
+}
+
+// DefaultFeedWeights sont les poids appliqués aux scoreurs sans réglage.
+var DefaultFeedWeights = map[string]float64{
+	"recency":    1.0,
+	"engagement": 0.6,
+	"trending":   0.8,
+	"following":  1.2,
+	"tags":       0.7,
+	"affinity":   0.5,
+	"seen":       -0.4,
+}
+
+type weightedScorer struct {
+	scorer FeedScorer
+	weight float64
+}
+
+// FeedRanker classe les candidats par somme pondérée des scoreurs.
+type FeedRanker struct {
+	scorers []weightedScorer
+	now     func() time.Time
+}
+
+// NewFeedRanker associe chaque scoreur à son poids (DefaultFeedWeights à
+// défaut) ; un poids nul désactive le scoreur, un nom inconnu est refusé.
+func NewFeedRanker(weights map[string]float64, scorers ...FeedScorer) (*FeedRanker, error) {
+	known := map[string]bool{}
+	r := &FeedRanker{now: time.Now}
+	for _, s := range scorers {
+		known[s.Name()] = true
+		w, ok := weights[s.Name()]
+		if !ok {
+			w = DefaultFeedWeights[s.Name()]
+		}
+		if w != 0 {
+			r.scorers = append(r.scorers, weightedScorer{scorer: s, weight: w})
+		}
+	}
+	for name := range weights {
+		if !known[name] {
+			return nil, fmt.Errorf("scoreur de fil inconnu : %q", name)
+		}
+	}
+	return r, nil
+}
+
+const defaultFeedHalfLife = 24 * time.Hour
+
+// NewDefaultFeedRanker assemble les scoreurs standard.
+func NewDefaultFeedRanker(weights map[string]float64, halfLife time.Duration) (*FeedRanker, error) {
+	if halfLife <= 0 {
+		halfLife = defaultFeedHalfLife
+	}
+	return NewFeedRanker(weights,
+		RecencyScorer{HalfLife: halfLife},
+		EngagementScorer{},
+		TrendingScorer{},
+		FollowingScorer{},
+		TagAffinityScorer{},
+		CreatorAffinityScorer{},
+		SeenScorer{},
+	)
+}
+
+// WithClock remplace l'horloge du classement, pour des tests déterministes.
+func (r *FeedRanker) WithClock(now func() time.Time) *FeedRanker {
+	r.now = now
+	return r
+}
+
+// Now renvoie l'heure de référence du classement.
+func (r *FeedRanker) Now() time.Time {
+	return r.now()
+}
+
+// Score renvoie la note pondérée d'un candidat.
+func (r *FeedRanker) Score(c *repositories.FeedCandidate, now time.Time) float64 {
+	total := 0.0
+	for _, ws := range r.scorers {
+		total += ws.weight * ws.scorer.Score(c, now)
+	}
+	return total
+}
+
+// Rank trie les candidats du mieux noté au moins bien noté ; à note égale,
+// le plus récent d'abord, puis par identifiant pour un ordre stable.
+func (r *FeedRanker) Rank(candidates []repositories.FeedCandidate, now time.Time) {
+	scores := make(map[int]float64, len(candidates))
+	for i := range candidates {
+		scores[i] = r.Score(&candidates[i], now)
+	}
+	idx := make([]int, len(candidates))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		ca, cb := &candidates[idx[a]], &candidates[idx[b]]
+		if sa, sb := scores[idx[a]], scores[idx[b]]; sa != sb {
+			return sa > sb
+		}
+		if !ca.CreatedAt.Equal(cb.CreatedAt) {
+			return ca.CreatedAt.After(cb.CreatedAt)
+		}
+		return ca.ID.String() > cb.ID.String()
+	})
+	sorted := make([]repositories.FeedCandidate, len(candidates))
+	for i, j := range idx {
+		sorted[i] = candidates[j]
+	}
+	copy(candidates, sorted)
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/config"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var (
+	ErrInvalidCursor   = errors.New("curseur de pagination invalide")
+	ErrInvalidFeedMode = errors.New("mode de fil invalide")
+)
+
+// Modes du fil d'actualité.
+const (
+	// FeedModeForYou classe les contenus selon les scoreurs configurés.
+	FeedModeForYou = "foryou"
+	// FeedModeFollowing se limite aux créateurs suivis, du plus récent au plus ancien.
+	FeedModeFollowing = "following"
+	// FeedModeLatest liste tous les contenus du plus récent au plus ancien.
+	FeedModeLatest = "latest"
+)
+
+const (
+	defaultFeedPageSize = 20
+	maxFeedPageSize     = 50
+
+	// Collecte des candidats du fil classé.
+	feedCandidateWindow = 30 * 24 * time.Hour
+	feedTrendingWindow  = 7 * 24 * time.Hour
+	feedCandidatesLimit = 200
+
+	rankedCursorPrefix = "r"
+)
+
+// FeedPage est une page du fil ; NextCursor est vide sur la dernière page.
+type FeedPage struct {
+	Items      []repositories.FeedItem `json:"feed"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+}
+
+// SetFeedRanker remplace le classement du fil « pour vous ».
+func (s *ContentService) SetFeedRanker(ranker *FeedRanker) {
+	s.ranker = ranker
+}
+
+func (s *ContentService) feedRanker() *FeedRanker {
+	if s.ranker == nil {
+		ranker, err := NewDefaultFeedRanker(config.C.FeedWeights, config.C.FeedHalfLife)
+		if err != nil {
+			log.Printf("⚠️ Poids du fil invalides, valeurs par défaut utilisées: %v", err)
+			ranker, _ = NewDefaultFeedRanker(nil, config.C.FeedHalfLife)
+		}
+		s.ranker = ranker
+	}
+	return s.ranker
+}
+
+// GetFeed renvoie une page du fil d'actualité dans le mode demandé (vide
+// pour « pour vous »), après le curseur donné (vide pour la première page).
+func (s *ContentService) GetFeed(viewerID uuid.UUID, includeMature bool, mode, cursor string, limit int) (*FeedPage, error) {
+	if limit <= 0 {
+		limit = defaultFeedPageSize
+	}
+	limit = min(limit, maxFeedPageSize)
+
+	var (
+		page *FeedPage
+		err  error
+	)
+	switch mode {
+	case "", FeedModeForYou:
+		page, err = s.rankedFeed(viewerID, includeMature, cursor, limit)
+	case FeedModeFollowing, FeedModeLatest:
+		page, err = s.chronoFeed(viewerID, includeMature, mode == FeedModeFollowing, cursor, limit)
+	default:
+		return nil, ErrInvalidFeedMode
+	}
+	if err != nil {
+		return nil, err
+	}
+	if page.Items == nil {
+		page.Items = []repositories.FeedItem{}
+	}
+	if s.events != nil {
+		ids := make([]uuid.UUID, 0, len(page.Items))
+		for _, item := range page.Items {
+			if item.CreatorID != viewerID {
+				ids = append(ids, item.ID)
+			}
+		}
+		s.events.RecordImpressions(ids, viewerID)
+	}
+	return page, nil
+}
+
+func (s *ContentService) chronoFeed(viewerID uuid.UUID, includeMature, following bool, cursor string, limit int) (*FeedPage, error) {
+	after, err := DecodeFeedCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	// Une ligne de plus indique s'il reste une page suivante.
+	items, err := s.repo.FeedPage(repositories.FeedQuery{
+		ViewerID:      viewerID,
+		IncludeMature: includeMature,
+		FollowingOnly: following,
+		After:         after,
+		Limit:         limit + 1,
+		Now:           s.feedRanker().Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	page := &FeedPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		last := page.Items[limit-1]
+		page.NextCursor = EncodeFeedCursor(repositories.FeedCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	return page, nil
+}
+
+// rankedFeed classe les candidats puis les découpe par position. Le curseur
+// fige l'heure de référence et toutes les sources sont bornées par elle : un
+// contenu ou un like arrivé entre deux pages ne décale pas le classement, et
+// les pages suivantes ne répètent ni ne sautent d'éléments.
+func (s *ContentService) rankedFeed(viewerID uuid.UUID, includeMature bool, cursor string, limit int) (*FeedPage, error) {
+	ranker := s.feedRanker()
+	offset, now := 0, ranker.Now()
+	if cursor != "" {
+		var err error
+		if offset, now, err = decodeRankedCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	candidates, err := s.repo.FeedCandidates(repositories.CandidateQuery{
+		ViewerID:      viewerID,
+		IncludeMature: includeMature,
+		Now:           now,
+		Since:         now.Add(-feedCandidateWindow),
+		TrendingSince: now.Add(-feedTrendingWindow),
+		PerSource:     feedCandidatesLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ranker.Rank(candidates, now)
+
+	page := &FeedPage{}
+	for i := offset; i < len(candidates) && i < offset+limit; i++ {
+		page.Items = append(page.Items, candidates[i].FeedItem)
+	}
+	if offset+limit < len(candidates) {
+		page.NextCursor = encodeRankedCursor(offset+limit, now)
+	}
+	return page, nil
+}
+
+// EncodeFeedCursor rend un curseur opaque pour le client.
+func EncodeFeedCursor(c repositories.FeedCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeFeedCursor relit un curseur ; une chaîne vide désigne le début du fil.
+func DecodeFeedCursor(cursor string) (*repositories.FeedCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	contentID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &repositories.FeedCursor{CreatedAt: createdAt, ID: contentID}, nil
+}
+
+func encodeRankedCursor(offset int, now time.Time) string {
+	raw := rankedCursorPrefix + "|" + strconv.Itoa(offset) + "|" + strconv.FormatInt(now.UnixNano(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeRankedCursor(cursor string) (int, time.Time, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, time.Time{}, ErrInvalidCursor
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 || parts[0] != rankedCursorPrefix {
+		return 0, time.Time{}, ErrInvalidCursor
+	}
+	offset, err := strconv.Atoi(parts[1])
+	if err != nil || offset < 0 {
+		return 0, time.Time{}, ErrInvalidCursor
+	}
+	nanos, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, ErrInvalidCursor
+	}
+	return offset, time.Unix(0, nanos).UTC(), nil
+}
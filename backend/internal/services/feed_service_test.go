@@ -0,0 +1,301 @@
+package services_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+type feedFixture struct {
+	*testStore
+	svc     *services.ContentService
+	viewer  *models.User
+	creator *models.User
+	queries *atomic.Int64
+}
+
+// setupFeed crée n contenus approuvés, chacun liké par deux utilisateurs, et
+// compte les requêtes SQL exécutées.
+func setupFeed(tb testing.TB, n int) *feedFixture {
+	f := &feedFixture{testStore: newTestStore(tb, &models.User{}, &models.Content{}, &models.Like{},
+		&models.Subscription{}, &models.Comment{}, &models.Tag{}, &models.ContentTag{}), queries: &atomic.Int64{}}
+	f.viewer = f.user("fan", models.RoleSubscriber)
+	f.creator = f.user("artiste", models.RoleCreator)
+	other := f.user("autre", models.RoleSubscriber)
+	base := time.Now().Add(-time.Hour)
+	for i := range n {
+		c := f.content(f.creator, fmt.Sprintf("c%d", i), models.ContentStatusApproved, base.Add(time.Duration(i)*time.Second))
+		for _, u := range []*models.User{f.viewer, other} {
+			f.like(c, u, time.Time{})
+		}
+	}
+	f.content(f.creator, "attente", models.ContentStatusPending, time.Time{})
+	f.content(f.creator, "adulte", models.ContentStatusApproved, time.Time{},
+		func(c *models.Content) { c.Maturity = models.MaturityMature })
+
+	count := func(*gorm.DB) { f.queries.Add(1) }
+	require.NoError(tb, f.db.Callback().Query().After("gorm:query").Register("test:count_query", count))
+	require.NoError(tb, f.db.Callback().Row().After("gorm:row").Register("test:count_row", count))
+	f.svc = services.NewContentService(repositories.NewContentRepository(), nil, tb.TempDir(), nil, nil, nil)
+	return f
+}
+
+func TestFeed_CursorPagination(t *testing.T) {
+	f := setupFeed(t, 5)
+	f.subscription(f.viewer, f.creator, time.Now(), time.Now().Add(24*time.Hour), models.SubscriptionStatusActive)
+
+	var titles []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5)
+		page, err := f.svc.GetFeed(f.viewer.ID, false, services.FeedModeLatest, cursor, 2)
+		require.NoError(t, err)
+		for _, item := range page.Items {
+			titles = append(titles, item.Title)
+			assert.Equal(t, "artiste", item.CreatorName)
+			assert.Equal(t, int64(2), item.LikesCount)
+			assert.True(t, item.LikedByUser)
+			assert.True(t, item.IsSubscribed)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	assert.Equal(t, []string{"c4", "c3", "c2", "c1", "c0"}, titles, "seuls les contenus approuvés et tous publics")
+
+	page, err := f.svc.GetFeed(f.creator.ID, false, services.FeedModeLatest, "", 1)
+	require.NoError(t, err)
+	assert.False(t, page.Items[0].LikedByUser)
+	assert.False(t, page.Items[0].IsSubscribed)
+
+	_, err = f.svc.GetFeed(f.viewer.ID, false, services.FeedModeLatest, "pas-un-curseur", 2)
+	assert.ErrorIs(t, err, services.ErrInvalidCursor)
+}
+
+func TestFeed_ConstantQueryCount(t *testing.T) {
+	f := setupFeed(t, 60)
+	for _, mode := range []string{services.FeedModeLatest, services.FeedModeForYou} {
+		perPage := map[int]int64{}
+		for _, limit := range []int{1, 10, 50} {
+			f.queries.Store(0)
+			page, err := f.svc.GetFeed(f.viewer.ID, true, mode, "", limit)
+			require.NoError(t, err)
+			require.Len(t, page.Items, limit)
+			perPage[limit] = f.queries.Load()
+		}
+		assert.Equal(t, map[int]int64{1: 1, 10: 1, 50: 1}, perPage, mode)
+	}
+}
+
+var feedNow = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestFeedRanker_Order(t *testing.T) {
+	at := func(ago time.Duration) repositories.FeedItem {
+		return repositories.FeedItem{ID: uuid.New(), CreatedAt: feedNow.Add(-ago)}
+	}
+	fresh := repositories.FeedCandidate{FeedItem: at(time.Hour)}
+	followed := repositories.FeedCandidate{FeedItem: at(48 * time.Hour)}
+	followed.IsSubscribed = true
+	seen := repositories.FeedCandidate{FeedItem: at(2 * time.Hour)}
+	seen.LikedByUser = true
+	trending := repositories.FeedCandidate{FeedItem: at(72 * time.Hour), RecentLikes: 50}
+	trending.LikesCount = 100
+
+	rank := func(weights map[string]float64) []uuid.UUID {
+		ranker, err := services.NewDefaultFeedRanker(weights, 24*time.Hour)
+		require.NoError(t, err)
+		list := []repositories.FeedCandidate{fresh, followed, seen, trending}
+		ranker.Rank(list, feedNow)
+		ids := make([]uuid.UUID, len(list))
+		for i, c := range list {
+			ids[i] = c.ID
+		}
+		return ids
+	}
+
+	assert.Equal(t, []uuid.UUID{trending.ID, followed.ID, fresh.ID, seen.ID}, rank(nil))
+	assert.Equal(t, []uuid.UUID{trending.ID, fresh.ID, seen.ID, followed.ID}, rank(map[string]float64{"following": 0}))
+	assert.Equal(t, []uuid.UUID{fresh.ID, seen.ID, followed.ID, trending.ID},
+		rank(map[string]float64{"trending": 0, "engagement": 0, "following": 0, "seen": 0}))
+
+	_, err := services.NewDefaultFeedRanker(map[string]float64{"inconnu": 1}, 24*time.Hour)
+	assert.Error(t, err)
+}
+
+func TestFeedRanker_TieBreak(t *testing.T) {
+	ranker, err := services.NewDefaultFeedRanker(nil, 24*time.Hour)
+	require.NoError(t, err)
+	a := repositories.FeedCandidate{FeedItem: repositories.FeedItem{ID: uuid.MustParse("00000000-0000-0000-0000-00000000000a"), CreatedAt: feedNow}}
+	b := repositories.FeedCandidate{FeedItem: repositories.FeedItem{ID: uuid.MustParse("00000000-0000-0000-0000-00000000000b"), CreatedAt: feedNow}}
+	for _, list := range [][]repositories.FeedCandidate{{a, b}, {b, a}} {
+		ranker.Rank(list, feedNow)
+		assert.Equal(t, b.ID, list[0].ID, "à note et date égales, l'ordre ne dépend que de l'identifiant")
+	}
+}
+
+func TestFeed_ForYou(t *testing.T) {
+	f := setupFeed(t, 0)
+	ranker, err := services.NewDefaultFeedRanker(nil, 24*time.Hour)
+	require.NoError(t, err)
+	f.svc.SetFeedRanker(ranker.WithClock(func() time.Time { return feedNow }))
+
+	rival := f.user("rival", models.RoleCreator)
+	f.subscription(f.viewer, f.creator, feedNow.Add(-time.Hour), feedNow.Add(24*time.Hour), models.SubscriptionStatusActive)
+
+	post := func(creator *models.User, title string, ago time.Duration) *models.Content {
+		return f.content(creator, title, models.ContentStatusApproved, feedNow.Add(-ago))
+	}
+	post(f.creator, "suivi", 30*time.Hour)
+	post(rival, "recent", time.Hour)
+	trending := post(rival, "tendance", 40*time.Hour)
+	post(rival, "vieux", 40*24*time.Hour)
+	for i := range 3 {
+		f.like(trending, f.user(fmt.Sprintf("fan%d", i), models.RoleSubscriber), feedNow.Add(-time.Hour))
+	}
+
+	titles := func(mode string, limit int) []string {
+		var out []string
+		cursor := ""
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 5)
+			page, err := f.svc.GetFeed(f.viewer.ID, false, mode, cursor, limit)
+			require.NoError(t, err)
+			for _, item := range page.Items {
+				out = append(out, item.Title)
+			}
+			if cursor = page.NextCursor; cursor == "" {
+				return out
+			}
+		}
+	}
+
+	assert.Equal(t, []string{"suivi", "recent", "tendance"}, titles(services.FeedModeForYou, 2),
+		"abonnement puis fraîcheur puis tendance ; les contenus anciens sans activité sont écartés")
+	assert.Equal(t, titles(services.FeedModeForYou, 10), titles("", 10), "« pour vous » est le mode par défaut")
+	assert.Equal(t, []string{"suivi"}, titles(services.FeedModeFollowing, 2))
+	assert.Equal(t, []string{"recent", "suivi", "tendance", "vieux"}, titles(services.FeedModeLatest, 2))
+
+	_, err = f.svc.GetFeed(f.viewer.ID, false, "populaire", "", 2)
+	assert.ErrorIs(t, err, services.ErrInvalidFeedMode)
+	latest, err := f.svc.GetFeed(f.viewer.ID, false, services.FeedModeLatest, "", 1)
+	require.NoError(t, err)
+	_, err = f.svc.GetFeed(f.viewer.ID, false, services.FeedModeForYou, latest.NextCursor, 1)
+	assert.ErrorIs(t, err, services.ErrInvalidCursor, "un curseur chronologique n'est pas valable en mode classé")
+}
+
+func TestFeed_CandidateSignals(t *testing.T) {
+	f := setupFeed(t, 0)
+	liked := f.content(f.creator, "aimé", models.ContentStatusApproved, feedNow.Add(-48*time.Hour))
+	candidate := f.content(f.creator, "nouveau", models.ContentStatusApproved, feedNow.Add(-time.Hour))
+	tag := &models.Tag{Name: "encre"}
+	f.create(tag)
+	for _, c := range []*models.Content{liked, candidate} {
+		f.create(&models.ContentTag{ContentID: c.ID, TagID: tag.ID})
+	}
+	f.like(liked, f.viewer, feedNow.Add(-2*time.Hour))
+	commentedAt := feedNow.Add(-time.Hour)
+	f.create(
+		&models.Comment{ContentID: liked.ID, AuthorID: f.viewer.ID, Text: "bravo", CreatedAt: commentedAt},
+		&models.Comment{ContentID: candidate.ID, AuthorID: f.creator.ID, Text: "merci", CreatedAt: commentedAt},
+		&models.Comment{ContentID: candidate.ID, AuthorID: f.creator.ID, Text: "retenu",
+			Status: models.TextStatusHeld, CreatedAt: commentedAt},
+		// Postérieur à l'heure de référence : ignoré.
+		&models.Comment{ContentID: candidate.ID, AuthorID: f.viewer.ID, Text: "plus tard", CreatedAt: feedNow.Add(time.Minute)},
+	)
+
+	list, err := repositories.NewContentRepository().FeedCandidates(repositories.CandidateQuery{
+		ViewerID: f.viewer.ID, Now: feedNow, Since: feedNow.Add(-72 * time.Hour),
+		TrendingSince: feedNow.Add(-24 * time.Hour), PerSource: 10,
+	})
+	require.NoError(t, err)
+	byTitle := map[string]repositories.FeedCandidate{}
+	for _, c := range list {
+		byTitle[c.Title] = c
+	}
+	require.Contains(t, byTitle, "nouveau")
+	got := byTitle["nouveau"]
+	assert.Equal(t, int64(1), got.CommentsCount, "les commentaires retenus ne comptent pas")
+	assert.Equal(t, int64(1), got.SharedTags)
+	assert.Equal(t, int64(2), got.CreatorAffinity, "un like et un commentaire sur le même créateur")
+	assert.Equal(t, int64(1), byTitle["aimé"].RecentLikes)
+	assert.True(t, byTitle["aimé"].LikedByUser)
+}
+
+func TestFeed_RankedPagesIgnoreLaterActivity(t *testing.T) {
+	f := setupFeed(t, 0)
+	now := feedNow
+	ranker, err := services.NewDefaultFeedRanker(nil, 24*time.Hour)
+	require.NoError(t, err)
+	f.svc.SetFeedRanker(ranker.WithClock(func() time.Time { return now }))
+
+	var posts []*models.Content
+	for i := range 6 {
+		posts = append(posts, f.content(f.creator, fmt.Sprintf("c%d", i), models.ContentStatusApproved,
+			feedNow.Add(-time.Duration(i+1)*time.Hour)))
+	}
+	walk := func() []string {
+		var out []string
+		cursor := ""
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 10)
+			page, err := f.svc.GetFeed(f.viewer.ID, false, services.FeedModeForYou, cursor, 2)
+			require.NoError(t, err)
+			for _, item := range page.Items {
+				out = append(out, item.Title)
+			}
+			if pages == 0 {
+				// Entre la première et la deuxième page : un nouveau contenu
+				// et des likes qui le hisseraient en tête du classement.
+				later := feedNow.Add(time.Minute)
+				f.content(f.creator, "nouveau", models.ContentStatusApproved, later)
+				for i := range 5 {
+					f.like(posts[5], f.user(fmt.Sprintf("fan%d", i), models.RoleSubscriber), later)
+				}
+				now = feedNow.Add(2 * time.Minute)
+			}
+			if cursor = page.NextCursor; cursor == "" {
+				return out
+			}
+		}
+	}
+
+	assert.Equal(t, []string{"c0", "c1", "c2", "c3", "c4", "c5"}, walk(),
+		"ni doublon ni saut : l'activité postérieure au curseur est ignorée")
+	now = feedNow.Add(2 * time.Minute)
+	first, err := f.svc.GetFeed(f.viewer.ID, false, services.FeedModeForYou, "", 2)
+	require.NoError(t, err)
+	assert.Contains(t, []string{first.Items[0].Title, first.Items[1].Title}, "nouveau",
+		"un nouveau parcours tient compte de l'activité récente")
+}
+
+func BenchmarkFeedPage(b *testing.B) {
+	f := setupFeed(b, 200)
+	f.queries.Store(0)
+	pages := 0
+	b.ResetTimer()
+	for range b.N {
+		cursor := ""
+		for {
+			pages++
+			page, err := f.svc.GetFeed(f.viewer.ID, true, services.FeedModeLatest, cursor, 20)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if cursor = page.NextCursor; cursor == "" {
+				break
+			}
+		}
+	}
+	b.ReportMetric(float64(f.queries.Load())/float64(pages), "queries/page")
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/config"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var ErrForensicForbidden = errors.New("seuls l'administrateur ou le créateur du contenu peuvent analyser une fuite")
+
+// ForensicMatch décrit l'abonné identifié à partir d'une image fuitée.
+type ForensicMatch struct {
+	Mark               models.ForensicMark `json:"mark"`
+	SubscriberUsername string              `json:"subscriber_username"`
+	Confidence         float64             `json:"confidence"`
+}
+
+// ForensicService émet les jetons de watermark invisibles et identifie les fuites.
+type ForensicService struct {
+	markRepo    *repositories.ForensicMarkRepository
+	contentRepo *repositories.ContentRepository
+	userRepo    *repositories.UserRepository
+	uploadPath  string
+	key         []byte
+}
+
+func NewForensicService(
+	markRepo *repositories.ForensicMarkRepository,
+	contentRepo *repositories.ContentRepository,
+	userRepo *repositories.UserRepository,
+	uploadPath string,
+) *ForensicService {
+	return &ForensicService{
+		markRepo:    markRepo,
+		contentRepo: contentRepo,
+		userRepo:    userRepo,
+		uploadPath:  uploadPath,
+		key:         []byte(config.C.WatermarkSecret),
+	}
+}
+
+// IssueMark renvoie le jeton à embarquer pour un abonné. Pour les affichages,
+// le jeton est réutilisé d'une requête à l'autre (et l'image reste en cache) ;
+// chaque téléchargement reçoit un nouveau jeton horodaté.
+func (s *ForensicService) IssueMark(contentID, subscriberID uuid.UUID, kind string) (*models.ForensicMark, error) {
+	if kind == models.ForensicMarkKindView {
+		existing, err := s.markRepo.FindLatest(contentID, subscriberID, kind)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil && existing.Version == ForensicMarkVersion {
+			return existing, nil
+		}
+	}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		token, err := randomToken()
+		if err != nil {
+			return nil, err
+		}
+		if token == 0 {
+			continue
+		}
+		existing, err := s.markRepo.FindByToken(token)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			continue
+		}
+		mark := &models.ForensicMark{
+			Token:        token,
+			ContentID:    contentID,
+			SubscriberID: subscriberID,
+			Kind:         kind,
+			Version:      ForensicMarkVersion,
+			IssuedAt:     time.Now().UTC(),
+		}
+		if err := s.markRepo.Create(mark); err != nil {
+			return nil, err
+		}
+		return mark, nil
+	}
+	return nil, fmt.Errorf("impossible de générer un jeton forensique unique")
+}
+
+// Apply embarque le jeton dans l'image.
+func (s *ForensicService) Apply(img image.Image, mark *models.ForensicMark) (image.Image, error) {
+	return EmbedForensicMark(img, uint32(mark.Token), s.key)
+}
+
+// Identify analyse une image fuitée d'un contenu et retrouve l'abonné à qui
+// elle a été servie. Réservé aux admins et au créateur du contenu.
+func (s *ForensicService) Identify(requesterID, contentID uuid.UUID, leaked io.Reader) (*ForensicMatch, error) {
+	content, err := s.contentRepo.FindByID(contentID)
+	if err != nil {
+		return nil, fmt.Errorf("contenu non trouvé")
+	}
+
+	requester, err := s.userRepo.FindByID(requesterID)
+	if err != nil {
+		return nil, err
+	}
+	if requester == nil || (requester.Role != models.RoleAdmin && requester.ID != content.CreatorID) {
+		return nil, ErrForensicForbidden
+	}
+
+	original, _, err := decodeImageFile(filepath.Join(s.uploadPath, content.FilePath))
+	if err != nil {
+		return nil, err
+	}
+	leakedImg, _, err := image.Decode(leaked)
+	if err != nil {
+		return nil, fmt.Errorf("image fournie illisible: %v", err)
+	}
+
+	token, confidence, err := ExtractForensicMark(leakedImg, original, s.key)
+	if err != nil {
+		return nil, err
+	}
+
+	mark, err := s.markRepo.FindByToken(int64(token))
+	if err != nil {
+		return nil, err
+	}
+	if mark == nil || mark.ContentID != contentID {
+		return nil, ErrForensicNoMark
+	}
+
+	match := &ForensicMatch{Mark: *mark, Confidence: confidence}
+	if subscriber, err := s.userRepo.FindByID(mark.SubscriberID); err == nil && subscriber != nil {
+		match.SubscriberUsername = subscriber.Username
+	}
+
+	log.Printf("🕵️ Fuite identifiée - contentID: %s | subscriberID: %s", contentID, mark.SubscriberID)
+	logger.LogSecurity("forensic_leak_identified", map[string]interface{}{
+		"requester_id":  requesterID.String(),
+		"content_id":    contentID.String(),
+		"subscriber_id": mark.SubscriberID.String(),
+		"issued_at":     mark.IssuedAt,
+		"confidence":    confidence,
+	})
+
+	return match, nil
+}
+
+func randomToken() (int64, error) {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint32(b[:])), nil
+}
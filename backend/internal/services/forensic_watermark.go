@@ -0,0 +1,247 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	"math"
+	"math/rand/v2"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// Le watermark forensique encode un jeton de 32 bits (+ CRC-8) dans la
+// luminance de l'image. L'image est découpée en une grille relative de
+// forensicGrid x forensicGrid cellules ; chaque bit est étalé sur plusieurs
+// cellules avec un signe pseudo-aléatoire dérivé d'une clé secrète. Les
+// variations sont basse fréquence (fenêtre sinusoïdale par cellule), ce qui
+// résiste à la recompression JPEG et aux redimensionnements modérés.
+//
+// L'extraction est non aveugle : elle compare l'image fuitée à l'original.
+const (
+	ForensicMarkVersion = 1
+
+	forensicGrid     = 16
+	forensicBits     = 40
+	forensicStrength = 4.0
+	forensicMinSize  = 64
+	// En dessous de cette confiance, le signal est considéré comme du bruit.
+	forensicMinConfidence = 0.2
+)
+
+var (
+	ErrForensicImageTooSmall = errors.New("image trop petite pour un marquage forensique")
+	ErrForensicNoMark        = errors.New("aucune marque forensique détectée")
+)
+
+type forensicCell struct {
+	bit  int
+	sign float64
+}
+
+// forensicLayout associe chaque cellule de la grille à un bit et un signe.
+func forensicLayout(key []byte) []forensicCell {
+	sum := sha256.Sum256(append([]byte("artfans-forensic-v1:"), key...))
+	rng := rand.New(rand.NewPCG(binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:16])))
+
+	cells := forensicGrid * forensicGrid
+	perm := rng.Perm(cells)
+	layout := make([]forensicCell, cells)
+	perBit := cells / forensicBits
+	for i := range layout {
+		layout[i] = forensicCell{bit: -1}
+	}
+	for i := 0; i < perBit*forensicBits; i++ {
+		sign := 1.0
+		if rng.IntN(2) == 0 {
+			sign = -1.0
+		}
+		layout[perm[i]] = forensicCell{bit: i % forensicBits, sign: sign}
+	}
+	return layout
+}
+
+func forensicPayload(token uint32) [forensicBits]bool {
+	var bits [forensicBits]bool
+	var raw [4]byte
+	binary.BigEndian.PutUint32(raw[:], token)
+	crc := crc8(raw[:])
+	for i := 0; i < 32; i++ {
+		bits[i] = token&(1<<(31-i)) != 0
+	}
+	for i := 0; i < 8; i++ {
+		bits[32+i] = crc&(1<<(7-i)) != 0
+	}
+	return bits
+}
+
+func crc8(data []byte) byte {
+	var crc byte
+	for _, b := range data {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// cellWindow renvoie le poids (0..1) d'un pixel à la position relative
+// (u, v) dans sa cellule : nul sur les bords, maximal au centre.
+func cellWindow(u, v float64) float64 {
+	return math.Sin(math.Pi*u) * math.Sin(math.Pi*v)
+}
+
+// cellAt renvoie l'index de cellule et la position relative d'un pixel.
+func cellAt(x, y, w, h int) (int, float64, float64) {
+	fx := (float64(x) + 0.5) * forensicGrid / float64(w)
+	fy := (float64(y) + 0.5) * forensicGrid / float64(h)
+	cx, cy := int(fx), int(fy)
+	if cx >= forensicGrid {
+		cx = forensicGrid - 1
+	}
+	if cy >= forensicGrid {
+		cy = forensicGrid - 1
+	}
+	return cy*forensicGrid + cx, fx - float64(cx), fy - float64(cy)
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba
+}
+
+func clamp8(v float64) uint8 {
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(math.Round(v))
+}
+
+// EmbedForensicMark renvoie une copie de l'image portant le jeton invisible.
+func EmbedForensicMark(img image.Image, token uint32, key []byte) (image.Image, error) {
+	b := img.Bounds()
+	if b.Dx() < forensicMinSize || b.Dy() < forensicMinSize {
+		return nil, ErrForensicImageTooSmall
+	}
+
+	src := toRGBA(img)
+	out := image.NewRGBA(src.Rect)
+	copy(out.Pix, src.Pix)
+
+	layout := forensicLayout(key)
+	payload := forensicPayload(token)
+	w, h := out.Rect.Dx(), out.Rect.Dy()
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			idx, u, v := cellAt(x, y, w, h)
+			cell := layout[idx]
+			if cell.bit < 0 {
+				continue
+			}
+			value := -1.0
+			if payload[cell.bit] {
+				value = 1.0
+			}
+			delta := value * cell.sign * forensicStrength * cellWindow(u, v)
+			i := out.PixOffset(x, y)
+			out.Pix[i] = clamp8(float64(out.Pix[i]) + delta)
+			out.Pix[i+1] = clamp8(float64(out.Pix[i+1]) + delta)
+			out.Pix[i+2] = clamp8(float64(out.Pix[i+2]) + delta)
+		}
+	}
+	return out, nil
+}
+
+// ExtractForensicMark retrouve le jeton présent dans une image fuitée en la
+// comparant à l'original. La confiance est la marge moyenne normalisée des
+// bits décodés (proche de 1 pour une copie intacte).
+func ExtractForensicMark(leaked, original image.Image, key []byte) (uint32, float64, error) {
+	ob := original.Bounds()
+	if ob.Dx() < forensicMinSize || ob.Dy() < forensicMinSize {
+		return 0, 0, ErrForensicImageTooSmall
+	}
+
+	orig := toRGBA(original)
+	resized := image.NewRGBA(orig.Rect)
+	xdraw.BiLinear.Scale(resized, resized.Bounds(), leaked, leaked.Bounds(), xdraw.Src, nil)
+
+	w, h := orig.Rect.Dx(), orig.Rect.Dy()
+	cells := forensicGrid * forensicGrid
+	diffSum := make([]float64, cells)
+	weightSum := make([]float64, cells)
+	var globalDiff float64
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			idx, u, v := cellAt(x, y, w, h)
+			i := orig.PixOffset(x, y)
+			d := luma(resized.Pix[i:i+3]) - luma(orig.Pix[i:i+3])
+			weight := cellWindow(u, v)
+			diffSum[idx] += d * weight
+			weightSum[idx] += weight
+			globalDiff += d
+		}
+	}
+	globalDiff /= float64(w * h)
+
+	layout := forensicLayout(key)
+	var bitSums [forensicBits]float64
+	var bitWeights [forensicBits]float64
+	for idx, cell := range layout {
+		if cell.bit < 0 || weightSum[idx] == 0 {
+			continue
+		}
+		mean := diffSum[idx]/weightSum[idx] - globalDiff
+		bitSums[cell.bit] += cell.sign * mean
+		bitWeights[cell.bit]++
+	}
+
+	var token uint32
+	var crc byte
+	var margin float64
+	// Amplitude moyenne attendue d'une cellule pondérée par sa fenêtre.
+	expected := forensicStrength * math.Pi * math.Pi / 16
+	for i := 0; i < forensicBits; i++ {
+		if bitWeights[i] == 0 {
+			return 0, 0, ErrForensicNoMark
+		}
+		avg := bitSums[i] / bitWeights[i]
+		margin += math.Min(math.Abs(avg)/expected, 1)
+		set := avg > 0
+		if i < 32 {
+			if set {
+				token |= 1 << (31 - i)
+			}
+		} else if set {
+			crc |= 1 << (7 - (i - 32))
+		}
+	}
+
+	var raw [4]byte
+	binary.BigEndian.PutUint32(raw[:], token)
+	confidence := margin / forensicBits
+	if confidence < forensicMinConfidence || crc8(raw[:]) != crc {
+		return 0, 0, ErrForensicNoMark
+	}
+	return token, confidence, nil
+}
+
+func luma(p []uint8) float64 {
+	return 0.299*float64(p[0]) + 0.587*float64(p[1]) + 0.114*float64(p[2])
+}
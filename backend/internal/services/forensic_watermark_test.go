@@ -0,0 +1,79 @@
+package services_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	xdraw "golang.org/x/image/draw"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+// testArtwork génère une image texturée déterministe.
+func testArtwork(w, h int) *image.RGBA {
+	rng := rand.New(rand.NewPCG(1, 2))
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			n := uint8(rng.IntN(24))
+			img.Set(x, y, color.RGBA{
+				R: uint8(x*200/w) + n,
+				G: uint8(y*200/h) + n,
+				B: uint8((x+y)*100/(w+h)) + n,
+				A: 255,
+			})
+		}
+	}
+	return img
+}
+
+func TestForensicMark_SurvivesJPEGAndResize(t *testing.T) {
+	key := []byte("secret")
+	original := testArtwork(640, 480)
+	token := uint32(0xC0FFEE42)
+
+	marked, err := services.EmbedForensicMark(original, token, key)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, marked, &jpeg.Options{Quality: 70}))
+	recompressed, err := jpeg.Decode(&buf)
+	require.NoError(t, err)
+
+	resized := image.NewRGBA(image.Rect(0, 0, 480, 360))
+	xdraw.CatmullRom.Scale(resized, resized.Bounds(), recompressed, recompressed.Bounds(), xdraw.Src, nil)
+
+	buf.Reset()
+	require.NoError(t, jpeg.Encode(&buf, resized, &jpeg.Options{Quality: 80}))
+	leaked, err := jpeg.Decode(&buf)
+	require.NoError(t, err)
+
+	got, confidence, err := services.ExtractForensicMark(leaked, original, key)
+	require.NoError(t, err)
+	assert.Equal(t, token, got)
+	assert.Greater(t, confidence, 0.3)
+}
+
+func TestForensicMark_UnmarkedImage(t *testing.T) {
+	original := testArtwork(320, 240)
+
+	_, _, err := services.ExtractForensicMark(original, original, []byte("secret"))
+	assert.ErrorIs(t, err, services.ErrForensicNoMark)
+}
+
+func TestForensicMark_WrongKey(t *testing.T) {
+	original := testArtwork(320, 240)
+	marked, err := services.EmbedForensicMark(original, 12345, []byte("secret"))
+	require.NoError(t, err)
+
+	got, _, err := services.ExtractForensicMark(marked, original, []byte("autre"))
+	if err == nil {
+		assert.NotEqual(t, uint32(12345), got)
+	}
+}
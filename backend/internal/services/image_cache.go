@@ -0,0 +1,175 @@
+package services
+
+import (
+	"container/list"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// WatermarkVersion doit être incrémenté à chaque modification du rendu du
+// watermark : il fait partie de la clé de cache et de l'ETag.
+const WatermarkVersion = 1
+
+const (
+	ImageVariantClean     = "clean"
+	ImageVariantWatermark = "watermark"
+	ImageVariantForensic  = "forensic"
+)
+
+var (
+	imageCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "image_cache_requests_total",
+		Help: "Image cache lookups by variant and result (hit/miss)",
+	}, []string{"variant", "result"})
+
+	imageCacheBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "image_cache_bytes",
+		Help: "Current size in bytes of the rendered image cache",
+	})
+
+	imageRenderDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "image_render_duration_seconds",
+		Help:    "Duration of image decoding, watermarking and encoding",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+	}, []string{"variant"})
+)
+
+// ImageCacheKey identifie un rendu d'image : contenu, variante et version du
+// watermark. Options résume les réglages d'aperçu du créateur et Mark porte
+// le jeton forensique des rendus propres à un abonné.
+type ImageCacheKey struct {
+	ContentID uuid.UUID
+	Variant   string
+	Version   int
+	Options   uint32
+	Mark      uint32
+}
+
+func (k ImageCacheKey) String() string {
+	if k.Mark != 0 {
+		return fmt.Sprintf("%s:%s:v%d:%08x", k.ContentID, k.Variant, k.Version, k.Mark)
+	}
+	return fmt.Sprintf("%s:%s:v%d:%08x", k.ContentID, k.Variant, k.Version, k.Options)
+}
+
+// CachedImage est un rendu encodé prêt à être servi.
+type CachedImage struct {
+	Data        []byte
+	ContentType string
+	ModTime     time.Time
+}
+
+type imageCacheEntry struct {
+	key   string
+	image CachedImage
+}
+
+// ImageCache est un cache LRU borné en taille (octets) pour les images rendues.
+type ImageCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	size     int64
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+// NewImageCache crée un cache LRU limité à maxBytes octets.
+func NewImageCache(maxBytes int64) *ImageCache {
+	return &ImageCache{
+		maxBytes: maxBytes,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get renvoie le rendu associé à la clé s'il est présent et toujours à jour
+// par rapport à la date de modification du fichier source.
+func (c *ImageCache) Get(key ImageCacheKey, modTime time.Time) (CachedImage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key.String()]
+	if !ok {
+		imageCacheRequests.WithLabelValues(key.Variant, "miss").Inc()
+		return CachedImage{}, false
+	}
+	entry := el.Value.(*imageCacheEntry)
+	if !entry.image.ModTime.Equal(modTime) {
+		c.removeElement(el)
+		imageCacheRequests.WithLabelValues(key.Variant, "miss").Inc()
+		return CachedImage{}, false
+	}
+	c.ll.MoveToFront(el)
+	imageCacheRequests.WithLabelValues(key.Variant, "hit").Inc()
+	return entry.image, true
+}
+
+// Put ajoute (ou remplace) un rendu puis évince les entrées les moins
+// récemment utilisées jusqu'à repasser sous la limite.
+func (c *ImageCache) Put(key ImageCacheKey, img CachedImage) {
+	size := int64(len(img.Data))
+	if size > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := key.String()
+	if el, ok := c.items[k]; ok {
+		c.removeElement(el)
+	}
+	el := c.ll.PushFront(&imageCacheEntry{key: k, image: img})
+	c.items[k] = el
+	c.size += size
+
+	for c.size > c.maxBytes {
+		oldest := c.ll.Back()
+		if oldest == nil {
+			break
+		}
+		c.removeElement(oldest)
+	}
+	imageCacheBytes.Set(float64(c.size))
+}
+
+// InvalidateContent supprime toutes les variantes d'un contenu.
+func (c *ImageCache) InvalidateContent(contentID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prefix := contentID.String() + ":"
+	for k, el := range c.items {
+		if strings.HasPrefix(k, prefix) {
+			c.removeElement(el)
+		}
+	}
+	imageCacheBytes.Set(float64(c.size))
+}
+
+// Len renvoie le nombre d'entrées en cache.
+func (c *ImageCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// Size renvoie la taille totale (octets) des entrées en cache.
+func (c *ImageCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+func (c *ImageCache) removeElement(el *list.Element) {
+	entry := el.Value.(*imageCacheEntry)
+	c.ll.Remove(el)
+	delete(c.items, entry.key)
+	c.size -= int64(len(entry.image.Data))
+}
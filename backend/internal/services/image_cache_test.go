@@ -0,0 +1,62 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+func TestImageCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := services.NewImageCache(10)
+	now := time.Now()
+
+	k1 := services.ImageCacheKey{ContentID: uuid.New(), Variant: services.ImageVariantClean, Version: 1}
+	k2 := services.ImageCacheKey{ContentID: uuid.New(), Variant: services.ImageVariantClean, Version: 1}
+	k3 := services.ImageCacheKey{ContentID: uuid.New(), Variant: services.ImageVariantWatermark, Version: 1}
+
+	cache.Put(k1, services.CachedImage{Data: make([]byte, 4), ModTime: now})
+	cache.Put(k2, services.CachedImage{Data: make([]byte, 4), ModTime: now})
+
+	// k1 devient le plus récemment utilisé, k2 doit être évincé.
+	_, ok := cache.Get(k1, now)
+	assert.True(t, ok)
+
+	cache.Put(k3, services.CachedImage{Data: make([]byte, 4), ModTime: now})
+
+	_, ok = cache.Get(k2, now)
+	assert.False(t, ok)
+	_, ok = cache.Get(k1, now)
+	assert.True(t, ok)
+	assert.Equal(t, int64(8), cache.Size())
+}
+
+func TestImageCache_StaleEntryIsMiss(t *testing.T) {
+	cache := services.NewImageCache(1024)
+	now := time.Now()
+	key := services.ImageCacheKey{ContentID: uuid.New(), Variant: services.ImageVariantWatermark, Version: 1}
+
+	cache.Put(key, services.CachedImage{Data: []byte("img"), ModTime: now})
+
+	_, ok := cache.Get(key, now.Add(time.Second))
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestImageCache_InvalidateContent(t *testing.T) {
+	cache := services.NewImageCache(1024)
+	now := time.Now()
+	id := uuid.New()
+
+	cache.Put(services.ImageCacheKey{ContentID: id, Variant: services.ImageVariantClean, Version: 1}, services.CachedImage{Data: []byte("a"), ModTime: now})
+	cache.Put(services.ImageCacheKey{ContentID: id, Variant: services.ImageVariantWatermark, Version: 1}, services.CachedImage{Data: []byte("b"), ModTime: now})
+	cache.Put(services.ImageCacheKey{ContentID: uuid.New(), Variant: services.ImageVariantClean, Version: 1}, services.CachedImage{Data: []byte("c"), ModTime: now})
+
+	cache.InvalidateContent(id)
+
+	assert.Equal(t, 1, cache.Len())
+	assert.Equal(t, int64(1), cache.Size())
+}
@@ -11,22 +11,36 @@ import (
 type MessageService struct {
 	messageRepo *repositories.MessageRepository
 	userRepo    *repositories.UserRepository
+	sanctions   *SanctionService
+	text        *TextModerationService
 }
 
 func NewMessageService(
 	messageRepo *repositories.MessageRepository,
 	userRepo *repositories.UserRepository,
+	sanctions *SanctionService,
 ) *MessageService {
 	return &MessageService{
 		messageRepo: messageRepo,
 		userRepo:    userRepo,
+		sanctions:   sanctions,
 	}
 }
 
+// SetTextModeration branche la modération automatique des messages.
+func (s *MessageService) SetTextModeration(text *TextModerationService) {
+	s.text = text
+}
+
+// SendMessage envoie un message ; un message retenu par la modération n'est
+// remis au destinataire qu'après validation.
 func (s *MessageService) SendMessage(
 	senderID, receiverID uuid.UUID,
 	text string,
 ) (*models.Message, error) {
+	if err := s.sanctions.Ensure(senderID, models.SanctionRestrictMessaging); err != nil {
+		return nil, err
+	}
 
 	receiver, err := s.userRepo.FindByID(receiverID)
 	if err != nil {
@@ -40,14 +54,24 @@ func (s *MessageService) SendMessage(
 		return nil, errors.New("impossible de s'envoyer un message à soi-même")
 	}
 
+	text, rating, err := s.text.Screen(models.TextTargetMessage, senderID, text)
+	if err != nil {
+		return nil, err
+	}
 	msg := &models.Message{
+		ID:         uuid.New(),
 		SenderID:   senderID,
 		ReceiverID: receiverID,
 		Text:       text,
+		Status:     rating.Visibility(),
 	}
-	if err := s.messageRepo.Create(msg); err != nil {
+	if err := repositories.InTransaction(
+		s.messageRepo.CreateStep(msg),
+		s.text.HoldStep(models.TextTargetMessage, msg.ID, senderID, text, rating),
+	); err != nil {
 		return nil, err
 	}
+	messagesSent.WithLabelValues(msg.Status).Inc()
 	return msg, nil
 }
 
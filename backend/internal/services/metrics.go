@@ -0,0 +1,91 @@
+package services
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+// Métriques métier, exposées avec les métriques HTTP sur /metrics. Le temps
+// de rendu des watermarks est suivi par image_render_duration_seconds.
+var (
+	subscriptionsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "subscriptions_created_total",
+		Help: "Subscriptions created",
+	})
+
+	subscriptionsCanceled = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "subscriptions_canceled_total",
+		Help: "Subscriptions canceled by the subscriber",
+	})
+
+	paymentsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "payments_total",
+		Help: "Subscription payments by status and failure reason",
+	}, []string{"status", "reason"})
+
+	revenueCents = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "revenue_cents_total",
+		Help: "Revenue collected from succeeded payments, in cents",
+	})
+
+	contentUploads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "content_uploads_total",
+		Help: "Content uploads by resulting status (draft, pending, scheduled, invalid, refused)",
+	}, []string{"status"})
+
+	moderationQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "moderation_queue_depth",
+		Help: "Items waiting for a moderator, by queue",
+	}, []string{"queue"})
+
+	messagesSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "messages_sent_total",
+		Help: "Private messages sent by visibility (visible, held)",
+	}, []string{"status"})
+)
+
+// Motifs d'échec d'un paiement.
+const (
+	paymentReasonNone   = "none"
+	paymentReasonInsert = "payment_insert"
+	paymentReasonCommit = "commit"
+)
+
+// Statuts d'upload qui ne créent pas de contenu.
+const (
+	uploadStatusInvalid = "invalid"
+	uploadStatusRefused = "refused"
+)
+
+// RefreshQueueMetrics met à jour la profondeur des files de modération.
+func RefreshQueueMetrics(repo *repositories.ModerationRepository) error {
+	depths, err := repo.CountQueues()
+	if err != nil {
+		return err
+	}
+	moderationQueueDepth.WithLabelValues("contents").Set(float64(depths.Contents))
+	moderationQueueDepth.WithLabelValues("texts").Set(float64(depths.Texts))
+	moderationQueueDepth.WithLabelValues("reports").Set(float64(depths.Reports))
+	moderationQueueDepth.WithLabelValues("duplicates").Set(float64(depths.Duplicates))
+	moderationQueueDepth.WithLabelValues("age_verifications").Set(float64(depths.AgeVerifications))
+	return nil
+}
+
+// StartQueueMetrics rafraîchit périodiquement la profondeur des files.
+func StartQueueMetrics(repo *repositories.ModerationRepository, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := RefreshQueueMetrics(repo); err != nil {
+				logger.LogError(err, "queue_metrics_failed", nil)
+			}
+			<-ticker.C
+		}
+	}()
+}
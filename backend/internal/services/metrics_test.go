@@ -0,0 +1,102 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+// metricValue lit la valeur courante d'un compteur ou d'une jauge du
+// registre par défaut (0 si la série n'existe pas encore).
+func metricValue(t *testing.T, name string, labels map[string]string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range family.GetMetric() {
+			for _, pair := range m.GetLabel() {
+				if labels[pair.GetName()] != pair.GetValue() {
+					continue metrics
+				}
+			}
+			if m.GetCounter() != nil {
+				return m.GetCounter().GetValue()
+			}
+			return m.GetGauge().GetValue()
+		}
+	}
+	return 0
+}
+
+func setupMetrics(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		NamingStrategy: schema.NamingStrategy{SingularTable: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, database.MigrateSQLite(db, &models.User{}, &models.Content{}, &models.Subscription{},
+		&models.Payment{}, &models.TextReview{}, &models.Report{}, &models.DuplicateFlag{}))
+	repositories.SetTestDB(db)
+	return db
+}
+
+func TestMetrics_ModerationQueueDepth(t *testing.T) {
+	db := setupMetrics(t)
+
+	creator := &models.User{Username: "artist", Email: "artist@test", HashedPassword: "x", Role: models.RoleCreator,
+		AgeVerificationStatus: models.AgeVerificationPending}
+	require.NoError(t, db.Create(creator).Error)
+	for _, status := range []string{models.ContentStatusPending, models.ContentStatusPending, models.ContentStatusApproved} {
+		require.NoError(t, db.Create(&models.Content{CreatorID: creator.ID, Title: "t", Body: "b", Price: 1,
+			FilePath: "x.jpg", Status: status}).Error)
+	}
+	for _, status := range []string{models.ReportStatusOpen, models.ReportStatusInReview, models.ReportStatusResolved} {
+		require.NoError(t, db.Create(&models.Report{TargetType: models.ReportTargetUser, TargetID: creator.ID,
+			ReporterID: creator.ID, Status: status}).Error)
+	}
+	require.NoError(t, db.Create(&models.TextReview{TargetType: models.TextTargetComment, AuthorID: creator.ID,
+		Excerpt: "…", Status: models.TextReviewPending}).Error)
+
+	require.NoError(t, services.RefreshQueueMetrics(repositories.NewModerationRepository()))
+	assert.EqualValues(t, 2, metricValue(t, "moderation_queue_depth", map[string]string{"queue": "contents"}))
+	assert.EqualValues(t, 2, metricValue(t, "moderation_queue_depth", map[string]string{"queue": "reports"}))
+	assert.EqualValues(t, 1, metricValue(t, "moderation_queue_depth", map[string]string{"queue": "texts"}))
+	assert.EqualValues(t, 0, metricValue(t, "moderation_queue_depth", map[string]string{"queue": "duplicates"}))
+	assert.EqualValues(t, 1, metricValue(t, "moderation_queue_depth", map[string]string{"queue": "age_verifications"}))
+}
+
+func TestMetrics_SubscriptionLifecycle(t *testing.T) {
+	db := setupMetrics(t)
+	creator := &models.User{Username: "artist", Email: "artist@test", HashedPassword: "x", Role: models.RoleCreator}
+	fan := &models.User{Username: "fan", Email: "fan@test", HashedPassword: "x", Role: models.RoleSubscriber}
+	require.NoError(t, db.Create(creator).Error)
+	require.NoError(t, db.Create(fan).Error)
+	svc := services.NewSubscriptionService(repositories.NewSubscriptionRepository(), nil)
+
+	succeeded := map[string]string{"status": string(models.StatusSucceeded), "reason": "none"}
+	created := metricValue(t, "subscriptions_created_total", nil)
+	canceled := metricValue(t, "subscriptions_canceled_total", nil)
+	payments := metricValue(t, "payments_total", succeeded)
+	revenue := metricValue(t, "revenue_cents_total", nil)
+
+	require.NoError(t, svc.Subscribe(creator.ID, fan.ID))
+	require.Error(t, svc.Subscribe(creator.ID, fan.ID), "déjà abonné : ni abonnement ni paiement")
+	require.NoError(t, svc.Unsubscribe(fan.ID, creator.ID))
+
+	assert.Equal(t, created+1, metricValue(t, "subscriptions_created_total", nil))
+	assert.Equal(t, canceled+1, metricValue(t, "subscriptions_canceled_total", nil))
+	assert.Equal(t, payments+1, metricValue(t, "payments_total", succeeded))
+	assert.Equal(t, revenue+models.SubscriptionPriceCents, metricValue(t, "revenue_cents_total", nil))
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var (
+	ErrReasonRequired  = errors.New("motif de rejet requis")
+	ErrInvalidReason   = errors.New("motif de rejet inconnu")
+	ErrNoteRequired    = errors.New("une note est requise pour ce motif")
+	ErrAppealRequired  = errors.New("le message d'appel est requis")
+	ErrAlreadyAppealed = errors.New("ce rejet a déjà fait l'objet d'un appel")
+	ErrNotContentOwner = errors.New("ce contenu ne vous appartient pas")
+
+	ErrModerationNoteTooLong = errors.New("note trop longue")
+)
+
+// maxModerationNote borne la longueur des notes et messages d'appel.
+const maxModerationNote = 2000
+
+// ModerationService applique les décisions de modération, les historise et
+// prévient le créateur.
+type ModerationService struct {
+	contents      *repositories.ContentRepository
+	decisions     *repositories.ModerationRepository
+	notifications *NotificationService
+	now           func() time.Time
+}
+
+func NewModerationService(
+	contents *repositories.ContentRepository,
+	decisions *repositories.ModerationRepository,
+	notifications *NotificationService,
+) *ModerationService {
+	return &ModerationService{contents: contents, decisions: decisions, notifications: notifications, now: time.Now}
+}
+
+// Approve publie (ou programme) un contenu en attente ou rejeté.
+func (s *ModerationService) Approve(contentID, moderatorID uuid.UUID, note string) (*models.ModerationDecision, error) {
+	content, err := s.findContent(contentID)
+	if err != nil {
+		return nil, err
+	}
+	if content.Status != models.ContentStatusPending && content.Status != models.ContentStatusRejected {
+		return nil, fmt.Errorf("%w: seul un contenu en attente ou rejeté peut être approuvé", ErrInvalidTransition)
+	}
+	note, err = cleanNote(note)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := repositories.ApprovalUpdates(content, s.now())
+	decision := &models.ModerationDecision{
+		ContentID:    content.ID,
+		ActorID:      moderatorID,
+		Action:       models.ModerationActionApprove,
+		Note:         note,
+		StatusBefore: content.Status,
+		StatusAfter:  updates["status"].(string),
+	}
+	if err := s.decisions.Apply(decision, updates); err != nil {
+		return nil, err
+	}
+
+	s.notifications.Notify(content.CreatorID, models.NotificationContentApproved, &content.ID,
+		fmt.Sprintf("Votre contenu « %s » a été approuvé.", content.Title))
+	s.logDecision(decision, content)
+	return decision, nil
+}
+
+// Reject rejette un contenu avec un motif obligatoire ; le motif "other"
+// exige une note, transmise au créateur. Les étapes also sont validées dans
+// la transaction du rejet.
+func (s *ModerationService) Reject(contentID, moderatorID uuid.UUID, reasonCode, note string, also ...repositories.TxStep) (*models.ModerationDecision, error) {
+	if reasonCode == "" {
+		return nil, ErrReasonRequired
+	}
+	label, ok := models.ModerationReasonLabels[reasonCode]
+	if !ok {
+		return nil, ErrInvalidReason
+	}
+	note, err := cleanNote(note)
+	if err != nil {
+		return nil, err
+	}
+	if reasonCode == models.ModerationReasonOther && note == "" {
+		return nil, ErrNoteRequired
+	}
+
+	content, err := s.findContent(contentID)
+	if err != nil {
+		return nil, err
+	}
+	if content.Status == models.ContentStatusRejected || content.Status == models.ContentStatusDraft {
+		return nil, fmt.Errorf("%w: contenu %s", ErrInvalidTransition, content.Status)
+	}
+
+	decision := &models.ModerationDecision{
+		ContentID:    content.ID,
+		ActorID:      moderatorID,
+		Action:       models.ModerationActionReject,
+		ReasonCode:   reasonCode,
+		Note:         note,
+		StatusBefore: content.Status,
+		StatusAfter:  models.ContentStatusRejected,
+	}
+	if err := s.decisions.Apply(decision, map[string]interface{}{"status": models.ContentStatusRejected}, also...); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Votre contenu « %s » a été rejeté : %s.", content.Title, label)
+	if note != "" {
+		message += " " + note
+	}
+	s.notifications.Notify(content.CreatorID, models.NotificationContentRejected, &content.ID, message)
+	s.logDecision(decision, content)
+	return decision, nil
+}
+
+// Appeal permet au créateur de contester un rejet : le contenu repasse en
+// attente de modération. Un seul appel par rejet.
+func (s *ModerationService) Appeal(contentID, creatorID uuid.UUID, message string) (*models.ModerationDecision, error) {
+	content, err := s.findContent(contentID)
+	if err != nil {
+		return nil, err
+	}
+	if content.CreatorID != creatorID {
+		return nil, ErrNotContentOwner
+	}
+	if content.Status != models.ContentStatusRejected {
+		return nil, fmt.Errorf("%w: seul un contenu rejeté peut faire l'objet d'un appel", ErrInvalidTransition)
+	}
+	message, err = cleanNote(message)
+	if err != nil {
+		return nil, err
+	}
+	if message == "" {
+		return nil, ErrAppealRequired
+	}
+
+	history, err := s.decisions.FindByContent(content.ID)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(history); n > 0 && history[n-1].Action == models.ModerationActionAppeal {
+		return nil, ErrAlreadyAppealed
+	}
+
+	decision := &models.ModerationDecision{
+		ContentID:    content.ID,
+		ActorID:      creatorID,
+		Action:       models.ModerationActionAppeal,
+		Note:         message,
+		StatusBefore: content.Status,
+		StatusAfter:  models.ContentStatusPending,
+	}
+	if err := s.decisions.Apply(decision, map[string]interface{}{"status": models.ContentStatusPending}); err != nil {
+		return nil, err
+	}
+	s.logDecision(decision, content)
+	return decision, nil
+}
+
+// ContentHistory renvoie l'historique de modération d'un contenu.
+func (s *ModerationService) ContentHistory(contentID uuid.UUID) ([]models.ModerationDecision, error) {
+	return s.decisions.FindByContent(contentID)
+}
+
+// CreatorHistory renvoie les décisions portant sur les contenus d'un créateur.
+func (s *ModerationService) CreatorHistory(creatorID uuid.UUID, limit, offset int) ([]models.ModerationDecision, error) {
+	limit, offset = Pagination(limit, offset)
+	return s.decisions.FindByCreator(creatorID, limit, offset)
+}
+
+func (s *ModerationService) findContent(id uuid.UUID) (*models.Content, error) {
+	content, err := s.contents.FindByID(id)
+	if err != nil {
+		return nil, ErrContentNotFound
+	}
+	return content, nil
+}
+
+func (s *ModerationService) logDecision(d *models.ModerationDecision, content *models.Content) {
+	log.Printf("⚖️ Modération %s du contenu %s par %s (%s → %s)", d.Action, d.ContentID, d.ActorID, d.StatusBefore, d.StatusAfter)
+	logger.LogContent("content_moderated", d.ActorID.String(), d.ContentID.String(), map[string]interface{}{
+		"action":      d.Action,
+		"reason_code": d.ReasonCode,
+		"creator_id":  content.CreatorID.String(),
+		"status":      d.StatusAfter,
+	})
+}
+
+func cleanNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if len([]rune(note)) > maxModerationNote {
+		return "", fmt.Errorf("%w (%d caractères max)", ErrModerationNoteTooLong, maxModerationNote)
+	}
+	return note, nil
+}
@@ -0,0 +1,74 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+func TestModeration_RejectNotifyAndAppeal(t *testing.T) {
+	_, repo, db := setupContentLifecycle(t)
+	require.NoError(t, database.MigrateSQLite(db, &models.ModerationDecision{}, &models.Notification{}))
+	notifications := services.NewNotificationService(repositories.NewNotificationRepository())
+	moderation := services.NewModerationService(repo, repositories.NewModerationRepository(), notifications)
+
+	creatorID, moderatorID := uuid.New(), uuid.New()
+	content := &models.Content{CreatorID: creatorID, Title: "Aquarelle", Body: "b", Price: 3, FilePath: "a.jpg",
+		Status: models.ContentStatusPending}
+	require.NoError(t, db.Create(content).Error)
+
+	// Motif obligatoire, "other" exige une note.
+	_, err := moderation.Reject(content.ID, moderatorID, "", "")
+	assert.ErrorIs(t, err, services.ErrReasonRequired)
+	_, err = moderation.Reject(content.ID, moderatorID, "nope", "")
+	assert.ErrorIs(t, err, services.ErrInvalidReason)
+	_, err = moderation.Reject(content.ID, moderatorID, models.ModerationReasonOther, " ")
+	assert.ErrorIs(t, err, services.ErrNoteRequired)
+	_, err = moderation.Reject(content.ID, moderatorID, models.ModerationReasonOther, strings.Repeat("x", 5000))
+	assert.ErrorIs(t, err, services.ErrModerationNoteTooLong)
+
+	_, err = moderation.Reject(content.ID, moderatorID, models.ModerationReasonLowQuality, "Image floue")
+	require.NoError(t, err)
+	stored, err := repo.FindByID(content.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ContentStatusRejected, stored.Status)
+
+	list, unread, err := notifications.List(creatorID, true, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), unread)
+	require.Len(t, list, 1)
+	assert.Equal(t, models.NotificationContentRejected, list[0].Type)
+	assert.Contains(t, list[0].Message, "Image floue")
+	require.NoError(t, notifications.MarkRead(creatorID, list[0].ID))
+	assert.ErrorIs(t, notifications.MarkRead(uuid.New(), list[0].ID), services.ErrNotificationNotFound)
+
+	// Appel : réservé au créateur, remet le contenu en attente, une seule fois.
+	_, err = moderation.Appeal(content.ID, uuid.New(), "C'est mon œuvre")
+	assert.ErrorIs(t, err, services.ErrNotContentOwner)
+	_, err = moderation.Appeal(content.ID, creatorID, "Version nette jointe")
+	require.NoError(t, err)
+	stored, err = repo.FindByID(content.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ContentStatusPending, stored.Status)
+
+	_, err = moderation.Reject(content.ID, moderatorID, models.ModerationReasonLowQuality, "")
+	require.NoError(t, err)
+	_, err = moderation.Appeal(content.ID, creatorID, "Encore")
+	require.NoError(t, err, "un nouveau rejet ouvre droit à un nouvel appel")
+	_, err = moderation.Approve(content.ID, moderatorID, "")
+	require.NoError(t, err)
+
+	history, err := moderation.CreatorHistory(creatorID, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 5)
+	assert.Equal(t, models.ModerationActionApprove, history[0].Action)
+	assert.Equal(t, "Aquarelle", history[0].Content.Title)
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var ErrNotificationNotFound = errors.New("notification non trouvée")
+
+// NotificationService crée et sert les notifications des utilisateurs.
+type NotificationService struct {
+	repo *repositories.NotificationRepository
+}
+
+func NewNotificationService(repo *repositories.NotificationRepository) *NotificationService {
+	return &NotificationService{repo: repo}
+}
+
+// Notify envoie une notification ; un échec est journalisé sans bloquer
+// l'action qui l'a déclenchée.
+func (s *NotificationService) Notify(userID uuid.UUID, kind string, contentID *uuid.UUID, message string) {
+	if s == nil {
+		return
+	}
+	n := &models.Notification{UserID: userID, Type: kind, ContentID: contentID, Message: message}
+	if err := s.repo.Create(n); err != nil {
+		log.Printf("⚠️ Notification %s pour %s non enregistrée: %v", kind, userID, err)
+	}
+}
+
+// List renvoie les notifications d'un utilisateur et le nombre de non lues.
+func (s *NotificationService) List(userID uuid.UUID, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error) {
+	limit, offset = Pagination(limit, offset)
+	list, err := s.repo.FindByUser(userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	unread, err := s.repo.CountUnread(userID)
+	return list, unread, err
+}
+
+// MarkRead marque une notification de l'utilisateur comme lue.
+func (s *NotificationService) MarkRead(userID, id uuid.UUID) error {
+	found, err := s.repo.MarkRead(userID, id, time.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"math/bits"
+)
+
+// dHash : l'image est réduite à 9x8 niveaux de gris, chaque bit compare deux
+// pixels voisins d'une ligne. Le hash résiste au ré-encodage, au
+// redimensionnement et aux légères retouches de couleur.
+const (
+	dhashWidth  = 9
+	dhashHeight = 8
+	// dhashSamples borne le nombre de points lus par case (coût constant
+	// quelle que soit la taille de l'image).
+	dhashSamples = 8
+)
+
+// DifferenceHash calcule le hash perceptuel (dHash 64 bits) d'une image.
+func DifferenceHash(img image.Image) uint64 {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w == 0 || h == 0 {
+		return 0
+	}
+
+	var grid [dhashHeight][dhashWidth]float64
+	for cy := 0; cy < dhashHeight; cy++ {
+		y0, y1 := b.Min.Y+cy*h/dhashHeight, b.Min.Y+(cy+1)*h/dhashHeight
+		for cx := 0; cx < dhashWidth; cx++ {
+			x0, x1 := b.Min.X+cx*w/dhashWidth, b.Min.X+(cx+1)*w/dhashWidth
+			grid[cy][cx] = averageGray(img, x0, y0, x1, y1)
+		}
+	}
+
+	var hash uint64
+	for y := 0; y < dhashHeight; y++ {
+		for x := 0; x < dhashWidth-1; x++ {
+			hash <<= 1
+			if grid[y][x] < grid[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// averageGray renvoie la luminance moyenne d'une zone, échantillonnée.
+func averageGray(img image.Image, x0, y0, x1, y1 int) float64 {
+	if x1 <= x0 {
+		x1 = x0 + 1
+	}
+	if y1 <= y0 {
+		y1 = y0 + 1
+	}
+	stepX := max(1, (x1-x0)/dhashSamples)
+	stepY := max(1, (y1-y0)/dhashSamples)
+
+	var sum float64
+	var n int
+	for y := y0 + stepY/2; y < y1; y += stepY {
+		for x := x0 + stepX/2; x < x1; x += stepX {
+			sum += float64(color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y)
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// HammingDistance compte les bits qui diffèrent entre deux hashs.
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
@@ -0,0 +1,315 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strings"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+)
+
+const (
+	defaultWatermarkText = "Abonne-toi pour voir l'image !"
+
+	defaultBlurRadius       = 25
+	maxBlurRadius           = 200
+	defaultPixelSize        = 24
+	maxPixelSize            = 256
+	defaultWatermarkOpacity = 0.5
+	maxWatermarkTextLength  = 64
+	// Un teaser ne doit jamais dévoiler plus de la moitié de l'œuvre.
+	maxCropArea = 0.5
+)
+
+var ErrInvalidPreview = errors.New("paramètres d'aperçu invalides")
+
+// NormalizePreview valide les réglages d'aperçu et complète les valeurs par défaut.
+func NormalizePreview(p *models.PreviewSettings) error {
+	if p.Mode == "" {
+		p.Mode = models.PreviewModeWatermark
+	}
+
+	switch p.Mode {
+	case models.PreviewModeWatermark:
+		p.WatermarkText = strings.TrimSpace(p.WatermarkText)
+		if len([]rune(p.WatermarkText)) > maxWatermarkTextLength {
+			return fmt.Errorf("%w: texte du watermark trop long (max %d caractères)", ErrInvalidPreview, maxWatermarkTextLength)
+		}
+		if p.WatermarkOpacity == 0 {
+			p.WatermarkOpacity = defaultWatermarkOpacity
+		}
+		if p.WatermarkOpacity < 0 || p.WatermarkOpacity > 1 {
+			return fmt.Errorf("%w: opacité entre 0 et 1", ErrInvalidPreview)
+		}
+		if p.WatermarkPosition == "" {
+			p.WatermarkPosition = models.WatermarkPositionTile
+		}
+		switch p.WatermarkPosition {
+		case models.WatermarkPositionTile, models.WatermarkPositionCenter,
+			models.WatermarkPositionTopLeft, models.WatermarkPositionTopRight,
+			models.WatermarkPositionBottomLeft, models.WatermarkPositionBottomRight:
+		default:
+			return fmt.Errorf("%w: position de watermark inconnue", ErrInvalidPreview)
+		}
+	case models.PreviewModeBlur:
+		if p.BlurRadius == 0 {
+			p.BlurRadius = defaultBlurRadius
+		}
+		if p.BlurRadius < 1 || p.BlurRadius > maxBlurRadius {
+			return fmt.Errorf("%w: rayon de flou entre 1 et %d", ErrInvalidPreview, maxBlurRadius)
+		}
+	case models.PreviewModePixelate:
+		if p.PixelSize == 0 {
+			p.PixelSize = defaultPixelSize
+		}
+		if p.PixelSize < 2 || p.PixelSize > maxPixelSize {
+			return fmt.Errorf("%w: taille de pixel entre 2 et %d", ErrInvalidPreview, maxPixelSize)
+		}
+	case models.PreviewModeCrop:
+		if p.CropX < 0 || p.CropY < 0 || p.CropWidth <= 0 || p.CropHeight <= 0 ||
+			p.CropX+p.CropWidth > 1 || p.CropY+p.CropHeight > 1 {
+			return fmt.Errorf("%w: zone de recadrage hors de l'image", ErrInvalidPreview)
+		}
+		if p.CropWidth*p.CropHeight > maxCropArea {
+			return fmt.Errorf("%w: le teaser ne peut pas dépasser %d%% de l'image", ErrInvalidPreview, int(maxCropArea*100))
+		}
+	default:
+		return fmt.Errorf("%w: mode d'aperçu inconnu", ErrInvalidPreview)
+	}
+	return nil
+}
+
+// previewFingerprint résume les réglages d'aperçu pour la clé de cache et l'ETag.
+func previewFingerprint(p models.PreviewSettings) uint32 {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%+v", p)
+	return h.Sum32()
+}
+
+// applyPreview transforme l'image selon les réglages du créateur. logo peut être nil.
+func applyPreview(img image.Image, p models.PreviewSettings, logo image.Image) image.Image {
+	switch p.Mode {
+	case models.PreviewModeBlur:
+		return blurImage(img, p.BlurRadius)
+	case models.PreviewModePixelate:
+		return pixelateImage(img, p.PixelSize)
+	case models.PreviewModeCrop:
+		return cropImage(img, p)
+	default:
+		if p.WatermarkText == "" && logo == nil {
+			return addWatermark(img, defaultWatermarkText)
+		}
+		return addCustomWatermark(img, p, logo)
+	}
+}
+
+// blurImage approxime un flou gaussien par trois passes de flou boîte.
+func blurImage(img image.Image, radius int) image.Image {
+	rgba := toRGBA(img)
+	out := image.NewRGBA(rgba.Rect)
+	copy(out.Pix, rgba.Pix)
+
+	// Rayon des boîtes pour que trois passes approchent un sigma de radius/2.
+	sigma := float64(radius) / 2
+	box := int((math.Sqrt(12*sigma*sigma/3+1) - 1) / 2)
+	if box < 1 {
+		box = 1
+	}
+
+	tmp := make([]uint8, len(out.Pix))
+	for pass := 0; pass < 3; pass++ {
+		boxBlurH(out.Pix, tmp, out.Rect.Dx(), out.Rect.Dy(), out.Stride, box)
+		boxBlurV(tmp, out.Pix, out.Rect.Dx(), out.Rect.Dy(), out.Stride, box)
+	}
+	return out
+}
+
+func boxBlurH(src, dst []uint8, w, h, stride, r int) {
+	for y := 0; y < h; y++ {
+		row := y * stride
+		for ch := 0; ch < 4; ch++ {
+			var sum int
+			for x := -r; x <= r; x++ {
+				sum += int(src[row+clampInt(x, 0, w-1)*4+ch])
+			}
+			for x := 0; x < w; x++ {
+				dst[row+x*4+ch] = uint8(sum / (2*r + 1))
+				sum += int(src[row+clampInt(x+r+1, 0, w-1)*4+ch])
+				sum -= int(src[row+clampInt(x-r, 0, w-1)*4+ch])
+			}
+		}
+	}
+}
+
+func boxBlurV(src, dst []uint8, w, h, stride, r int) {
+	for x := 0; x < w; x++ {
+		col := x * 4
+		for ch := 0; ch < 4; ch++ {
+			var sum int
+			for y := -r; y <= r; y++ {
+				sum += int(src[clampInt(y, 0, h-1)*stride+col+ch])
+			}
+			for y := 0; y < h; y++ {
+				dst[y*stride+col+ch] = uint8(sum / (2*r + 1))
+				sum += int(src[clampInt(y+r+1, 0, h-1)*stride+col+ch])
+				sum -= int(src[clampInt(y-r, 0, h-1)*stride+col+ch])
+			}
+		}
+	}
+}
+
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// pixelateImage remplace chaque bloc size x size par sa couleur moyenne.
+func pixelateImage(img image.Image, size int) image.Image {
+	rgba := toRGBA(img)
+	w, h := rgba.Rect.Dx(), rgba.Rect.Dy()
+	out := image.NewRGBA(rgba.Rect)
+
+	for by := 0; by < h; by += size {
+		for bx := 0; bx < w; bx += size {
+			var sum [4]int
+			var n int
+			for y := by; y < by+size && y < h; y++ {
+				for x := bx; x < bx+size && x < w; x++ {
+					i := rgba.PixOffset(x, y)
+					for ch := 0; ch < 4; ch++ {
+						sum[ch] += int(rgba.Pix[i+ch])
+					}
+					n++
+				}
+			}
+			avg := color.RGBA{uint8(sum[0] / n), uint8(sum[1] / n), uint8(sum[2] / n), uint8(sum[3] / n)}
+			draw.Draw(out, image.Rect(bx, by, bx+size, by+size).Intersect(out.Rect), image.NewUniform(avg), image.Point{}, draw.Src)
+		}
+	}
+	return out
+}
+
+// cropImage ne conserve que la zone teaser choisie par le créateur.
+func cropImage(img image.Image, p models.PreviewSettings) image.Image {
+	b := img.Bounds()
+	rect := image.Rect(
+		b.Min.X+int(p.CropX*float64(b.Dx())),
+		b.Min.Y+int(p.CropY*float64(b.Dy())),
+		b.Min.X+int((p.CropX+p.CropWidth)*float64(b.Dx())),
+		b.Min.Y+int((p.CropY+p.CropHeight)*float64(b.Dy())),
+	).Intersect(b)
+	if rect.Empty() {
+		return addWatermark(img, defaultWatermarkText)
+	}
+	out := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(out, out.Bounds(), img, rect.Min, draw.Src)
+	return out
+}
+
+// addCustomWatermark incruste le texte et/ou le logo du créateur avec l'opacité
+// et la position choisies. Le tampon occupe environ un tiers de la largeur.
+func addCustomWatermark(img image.Image, p models.PreviewSettings, logo image.Image) image.Image {
+	b := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(out, out.Bounds(), img, b.Min, draw.Src)
+
+	stamp := watermarkStamp(p.WatermarkText, logo)
+	if stamp == nil {
+		return out
+	}
+
+	targetW := out.Rect.Dx() / 3
+	if targetW < 1 {
+		targetW = 1
+	}
+	sb := stamp.Bounds()
+	targetH := sb.Dy() * targetW / sb.Dx()
+	if targetH < 1 {
+		targetH = 1
+	}
+	scaled := image.NewRGBA(image.Rect(0, 0, targetW, targetH))
+	xdraw.BiLinear.Scale(scaled, scaled.Bounds(), stamp, sb, xdraw.Src, nil)
+
+	mask := image.NewUniform(color.Alpha{A: uint8(math.Round(p.WatermarkOpacity * 255))})
+	margin := out.Rect.Dx() / 40
+
+	var origins []image.Point
+	W, H := out.Rect.Dx(), out.Rect.Dy()
+	switch p.WatermarkPosition {
+	case models.WatermarkPositionCenter:
+		origins = []image.Point{{(W - targetW) / 2, (H - targetH) / 2}}
+	case models.WatermarkPositionTopLeft:
+		origins = []image.Point{{margin, margin}}
+	case models.WatermarkPositionTopRight:
+		origins = []image.Point{{W - targetW - margin, margin}}
+	case models.WatermarkPositionBottomLeft:
+		origins = []image.Point{{margin, H - targetH - margin}}
+	case models.WatermarkPositionBottomRight:
+		origins = []image.Point{{W - targetW - margin, H - targetH - margin}}
+	default:
+		stepX, stepY := targetW+targetW/4, targetH*3
+		for y := targetH; y < H; y += stepY {
+			offset := 0
+			if (y/stepY)%2 == 1 {
+				offset = stepX / 2
+			}
+			for x := -offset; x < W; x += stepX {
+				origins = append(origins, image.Point{x, y})
+			}
+		}
+	}
+
+	for _, o := range origins {
+		r := image.Rectangle{Min: o, Max: o.Add(image.Point{targetW, targetH})}
+		draw.DrawMask(out, r, scaled, image.Point{}, mask, image.Point{}, draw.Over)
+	}
+	return out
+}
+
+// watermarkStamp compose le logo (au-dessus) et le texte sur fond transparent.
+func watermarkStamp(text string, logo image.Image) image.Image {
+	var textImg *image.RGBA
+	if text != "" {
+		face := basicfont.Face7x13
+		d := &font.Drawer{Face: face}
+		w := d.MeasureString(text).Ceil() + 4
+		h := face.Metrics().Height.Ceil() + 4
+		textImg = image.NewRGBA(image.Rect(0, 0, w, h))
+		d.Dst = textImg
+		d.Src = image.NewUniform(color.White)
+		d.Dot = fixed.P(2, face.Metrics().Ascent.Ceil()+2)
+		d.DrawString(text)
+	}
+
+	switch {
+	case logo == nil && textImg == nil:
+		return nil
+	case logo == nil:
+		return textImg
+	case textImg == nil:
+		return logo
+	}
+
+	// Le texte est mis à l'échelle de la largeur du logo.
+	lb := logo.Bounds()
+	th := textImg.Rect.Dy() * lb.Dx() / textImg.Rect.Dx()
+	stamp := image.NewRGBA(image.Rect(0, 0, lb.Dx(), lb.Dy()+th))
+	draw.Draw(stamp, image.Rect(0, 0, lb.Dx(), lb.Dy()), logo, lb.Min, draw.Src)
+	xdraw.NearestNeighbor.Scale(stamp, image.Rect(0, lb.Dy(), lb.Dx(), lb.Dy()+th), textImg, textImg.Rect, xdraw.Over, nil)
+	return stamp
+}
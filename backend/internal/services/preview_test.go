@@ -0,0 +1,39 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+func TestNormalizePreview_Defaults(t *testing.T) {
+	p := models.PreviewSettings{}
+	require.NoError(t, services.NormalizePreview(&p))
+	assert.Equal(t, models.PreviewModeWatermark, p.Mode)
+	assert.Equal(t, models.WatermarkPositionTile, p.WatermarkPosition)
+	assert.Greater(t, p.WatermarkOpacity, 0.0)
+
+	blur := models.PreviewSettings{Mode: models.PreviewModeBlur}
+	require.NoError(t, services.NormalizePreview(&blur))
+	assert.Greater(t, blur.BlurRadius, 0)
+}
+
+func TestNormalizePreview_Rejects(t *testing.T) {
+	cases := []models.PreviewSettings{
+		{Mode: "sepia"},
+		{Mode: models.PreviewModePixelate, PixelSize: 1},
+		{Mode: models.PreviewModeWatermark, WatermarkOpacity: 1.5},
+		{Mode: models.PreviewModeCrop, CropX: 0.6, CropY: 0, CropWidth: 0.5, CropHeight: 0.5},
+		{Mode: models.PreviewModeCrop, CropWidth: 0.9, CropHeight: 0.9},
+	}
+	for _, c := range cases {
+		p := c
+		err := services.NormalizePreview(&p)
+		assert.True(t, errors.Is(err, services.ErrInvalidPreview), "%+v", c)
+	}
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var (
+	ErrInvalidReportTarget  = errors.New("type de cible de signalement invalide")
+	ErrInvalidReportReason  = errors.New("catégorie de signalement inconnue")
+	ErrReportDetailsNeeded  = errors.New("précisez le motif du signalement")
+	ErrReportTargetNotFound = errors.New("élément signalé introuvable")
+	ErrSelfReport           = errors.New("vous ne pouvez pas signaler votre propre publication")
+	ErrDuplicateReport      = errors.New("vous avez déjà un signalement en cours sur cet élément")
+	ErrReportNotFound       = errors.New("signalement non trouvé")
+	ErrReportClosed         = errors.New("ce signalement est déjà clos")
+	ErrInvalidReportAction  = errors.New("action de résolution invalide pour cette cible")
+	ErrInvalidReportStatus  = errors.New("statut de signalement invalide")
+)
+
+// ReportTargetGroup regroupe les signalements d'une même cible pour le tri.
+type ReportTargetGroup struct {
+	TargetType   string          `json:"target_type"`
+	TargetID     uuid.UUID       `json:"target_id"`
+	ReportCount  int64           `json:"report_count"`
+	OpenCount    int             `json:"open_count"`
+	ReasonCounts map[string]int  `json:"reason_counts"`
+	FirstAt      time.Time       `json:"first_reported_at"`
+	LastAt       time.Time       `json:"last_reported_at"`
+	Reports      []models.Report `json:"reports"`
+}
+
+// ReportService reçoit les signalements des utilisateurs et outille leur
+// traitement par les administrateurs.
+type ReportService struct {
+	reports    *repositories.ReportRepository
+	contents   *repositories.ContentRepository
+	comments   *repositories.CommentRepository
+	messages   *repositories.MessageRepository
+	users      *repositories.UserRepository
+	moderation *ModerationService
+	now        func() time.Time
+}
+
+func NewReportService(
+	reports *repositories.ReportRepository,
+	contents *repositories.ContentRepository,
+	comments *repositories.CommentRepository,
+	messages *repositories.MessageRepository,
+	users *repositories.UserRepository,
+	moderation *ModerationService,
+) *ReportService {
+	return &ReportService{
+		reports:    reports,
+		contents:   contents,
+		comments:   comments,
+		messages:   messages,
+		users:      users,
+		moderation: moderation,
+		now:        time.Now,
+	}
+}
+
+// Submit enregistre un signalement ; un utilisateur n'a qu'un signalement en
+// cours par cible.
+func (s *ReportService) Submit(reporterID uuid.UUID, targetType string, targetID uuid.UUID, reasonCode, details string) (*models.Report, error) {
+	if _, ok := models.ReportReasonLabels[reasonCode]; !ok {
+		return nil, ErrInvalidReportReason
+	}
+	details, err := cleanNote(details)
+	if err != nil {
+		return nil, err
+	}
+	if reasonCode == models.ReportReasonOther && details == "" {
+		return nil, ErrReportDetailsNeeded
+	}
+	if err := s.checkTarget(reporterID, targetType, targetID); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.reports.HasOpen(reporterID, targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrDuplicateReport
+	}
+
+	report := &models.Report{
+		TargetType: targetType,
+		TargetID:   targetID,
+		ReporterID: reporterID,
+		ReasonCode: reasonCode,
+		Details:    details,
+		Status:     models.ReportStatusOpen,
+	}
+	// Deux envois simultanés passent tous deux HasOpen : l'index unique des
+	// signalements ouverts départage.
+	if err := s.reports.Create(report); errors.Is(err, repositories.ErrDuplicateKey) {
+		return nil, ErrDuplicateReport
+	} else if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🚩 Signalement %s sur %s %s (%s)", report.ID, targetType, targetID, reasonCode)
+	logger.LogSecurity("report_submitted", map[string]interface{}{
+		"report_id":   report.ID.String(),
+		"reporter_id": reporterID.String(),
+		"target_type": targetType,
+		"target_id":   targetID.String(),
+		"reason_code": reasonCode,
+	})
+	return report, nil
+}
+
+// checkTarget vérifie que la cible existe et n'appartient pas à l'auteur du
+// signalement. Un message ne peut être signalé que par son destinataire.
+func (s *ReportService) checkTarget(reporterID uuid.UUID, targetType string, targetID uuid.UUID) error {
+	var ownerID uuid.UUID
+	switch targetType {
+	case models.ReportTargetContent:
+		content, err := s.contents.FindByID(targetID)
+		if err != nil {
+			return ErrReportTargetNotFound
+		}
+		ownerID = content.CreatorID
+	case models.ReportTargetComment:
+		comment, err := s.comments.FindByID(targetID)
+		if err != nil {
+			return err
+		}
+		if comment == nil {
+			return ErrReportTargetNotFound
+		}
+		ownerID = comment.AuthorID
+	case models.ReportTargetMessage:
+		message, err := s.messages.GetMessageByID(targetID)
+		if err != nil {
+			return err
+		}
+		if message == nil || message.ReceiverID != reporterID {
+			return ErrReportTargetNotFound
+		}
+		ownerID = message.SenderID
+	case models.ReportTargetUser:
+		if _, err := s.users.FindByID(targetID); err != nil {
+			return ErrReportTargetNotFound
+		}
+		ownerID = targetID
+	default:
+		return ErrInvalidReportTarget
+	}
+	if ownerID == reporterID {
+		return ErrSelfReport
+	}
+	return nil
+}
+
+// List renvoie une page de cibles signalées, les plus signalées d'abord, avec
+// le détail des signalements correspondant au filtre.
+func (s *ReportService) List(filter repositories.ReportFilter, limit, offset int) ([]ReportTargetGroup, int64, error) {
+	if filter.Status != "" && !validReportStatus(filter.Status) {
+		return nil, 0, ErrInvalidReportStatus
+	}
+	limit, offset = Pagination(limit, offset)
+	groups, total, err := s.reports.FindGroups(filter, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	idsByType := make(map[string][]uuid.UUID)
+	for _, g := range groups {
+		idsByType[g.TargetType] = append(idsByType[g.TargetType], g.TargetID)
+	}
+	byTarget := make(map[string][]models.Report)
+	for targetType, ids := range idsByType {
+		reports, err := s.reports.FindByTargets(filter, targetType, ids)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, r := range reports {
+			key := r.TargetType + ":" + r.TargetID.String()
+			byTarget[key] = append(byTarget[key], r)
+		}
+	}
+
+	out := make([]ReportTargetGroup, 0, len(groups))
+	for _, g := range groups {
+		item := ReportTargetGroup{
+			TargetType:   g.TargetType,
+			TargetID:     g.TargetID,
+			ReportCount:  g.ReportCount,
+			ReasonCounts: make(map[string]int),
+			Reports:      byTarget[g.TargetType+":"+g.TargetID.String()],
+		}
+		// Les signalements sont triés du plus récent au plus ancien.
+		if n := len(item.Reports); n > 0 {
+			item.LastAt, item.FirstAt = item.Reports[0].CreatedAt, item.Reports[n-1].CreatedAt
+		}
+		for _, r := range item.Reports {
+			item.ReasonCounts[r.ReasonCode]++
+			if r.IsOpen() {
+				item.OpenCount++
+			}
+		}
+		out = append(out, item)
+	}
+	return out, total, nil
+}
+
+// Assign confie un signalement à un administrateur et le passe en examen.
+func (s *ReportService) Assign(reportID, assigneeID uuid.UUID) (*models.Report, error) {
+	report, err := s.openReport(reportID)
+	if err != nil {
+		return nil, err
+	}
+	report.AssigneeID = &assigneeID
+	report.Status = models.ReportStatusInReview
+	if err := s.reports.Update(report); err != nil {
+		return nil, err
+	}
+	log.Printf("🗂️ Signalement %s confié à %s", report.ID, assigneeID)
+	return report, nil
+}
+
+// Resolve applique la suite donnée au signalement. Une action effective
+// (rejet du contenu, suppression du commentaire) clôt aussi les autres
+// signalements ouverts sur la même cible.
+func (s *ReportService) Resolve(reportID, adminID uuid.UUID, action, note string) (*models.Report, error) {
+	report, err := s.openReport(reportID)
+	if err != nil {
+		return nil, err
+	}
+	if action == "" {
+		action = models.ReportActionNone
+	}
+	if !reportActionAllowed(report.TargetType, action) {
+		return nil, ErrInvalidReportAction
+	}
+	note, err = cleanNote(note)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	report.Status = models.ReportStatusResolved
+	report.ResolutionAction = action
+	report.ResolutionNote = note
+	report.ResolvedBy = &adminID
+	report.ResolvedAt = &now
+	if err := s.apply(report, adminID, action, note); err != nil {
+		return nil, err
+	}
+
+	s.logClosed(report)
+	return report, nil
+}
+
+// Dismiss classe un signalement sans suite.
+func (s *ReportService) Dismiss(reportID, adminID uuid.UUID, note string) (*models.Report, error) {
+	report, err := s.openReport(reportID)
+	if err != nil {
+		return nil, err
+	}
+	note, err = cleanNote(note)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	report.Status = models.ReportStatusDismissed
+	report.ResolutionAction = models.ReportActionNone
+	report.ResolutionNote = note
+	report.ResolvedBy = &adminID
+	report.ResolvedAt = &now
+	if err := s.reports.Update(report); err != nil {
+		return nil, err
+	}
+	s.logClosed(report)
+	return report, nil
+}
+
+// apply exécute l'action de modération choisie sur la cible.
+// apply exécute l'action et clôt les signalements dans la même transaction :
+// un contenu n'est jamais rejeté avec ses signalements encore ouverts.
+func (s *ReportService) apply(report *models.Report, adminID uuid.UUID, action, note string) error {
+	closeReports := s.reports.CloseStep(report, action != models.ReportActionNone)
+	switch action {
+	case models.ReportActionContentRejected:
+		reason := report.ReasonCode
+		if _, ok := models.ModerationReasonLabels[reason]; !ok {
+			reason = models.ModerationReasonOther
+		}
+		if reason == models.ModerationReasonOther && note == "" {
+			note = fmt.Sprintf("Signalement : %s", strings.ToLower(models.ReportReasonLabels[report.ReasonCode]))
+		}
+		_, err := s.moderation.Reject(report.TargetID, adminID, reason, note, closeReports)
+		if !errors.Is(err, ErrInvalidTransition) {
+			return err
+		}
+		// Contenu déjà rejeté : seuls les signalements restent à clore.
+	case models.ReportActionCommentDeleted:
+		return repositories.InTransaction(s.comments.DeleteStep(report.TargetID), closeReports)
+	}
+	return repositories.InTransaction(closeReports)
+}
+
+func (s *ReportService) openReport(id uuid.UUID) (*models.Report, error) {
+	report, err := s.reports.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, ErrReportNotFound
+	}
+	if !report.IsOpen() {
+		return nil, ErrReportClosed
+	}
+	return report, nil
+}
+
+func (s *ReportService) logClosed(report *models.Report) {
+	log.Printf("✅ Signalement %s clos (%s, %s)", report.ID, report.Status, report.ResolutionAction)
+	logger.LogBusinessEvent("report_closed", map[string]interface{}{
+		"report_id":   report.ID.String(),
+		"target_type": report.TargetType,
+		"target_id":   report.TargetID.String(),
+		"status":      report.Status,
+		"action":      report.ResolutionAction,
+		"resolved_by": report.ResolvedBy.String(),
+	})
+}
+
+func validReportStatus(status string) bool {
+	switch status {
+	case models.ReportStatusOpen, models.ReportStatusInReview, models.ReportStatusResolved, models.ReportStatusDismissed:
+		return true
+	}
+	return false
+}
+
+func reportActionAllowed(targetType, action string) bool {
+	switch action {
+	case models.ReportActionNone:
+		return true
+	case models.ReportActionContentRejected:
+		return targetType == models.ReportTargetContent
+	case models.ReportActionCommentDeleted:
+		return targetType == models.ReportTargetComment
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+func TestReports_DeduplicateGroupAndResolve(t *testing.T) {
+	_, repo, db := setupContentLifecycle(t)
+	require.NoError(t, database.MigrateSQLite(db, &models.Comment{}, &models.Message{}, &models.Report{},
+		&models.ModerationDecision{}, &models.Notification{}))
+	moderation := services.NewModerationService(repo, repositories.NewModerationRepository(), nil)
+	reports := services.NewReportService(repositories.NewReportRepository(), repo, repositories.NewCommentRepository(),
+		repositories.NewMessageRepository(), repositories.NewUserRepository(), moderation)
+
+	creator := &models.User{Username: "creatrice", Email: "c@test", HashedPassword: "x", Role: models.RoleCreator}
+	alice := &models.User{Username: "alice", Email: "a@test", HashedPassword: "x", Role: models.RoleSubscriber}
+	bob := &models.User{Username: "bob", Email: "b@test", HashedPassword: "x", Role: models.RoleSubscriber}
+	for _, u := range []*models.User{creator, alice, bob} {
+		require.NoError(t, db.Create(u).Error)
+	}
+	content := &models.Content{CreatorID: creator.ID, Title: "Copie", Body: "b", Price: 3, FilePath: "c.jpg",
+		Status: models.ContentStatusApproved}
+	require.NoError(t, db.Create(content).Error)
+	comment := &models.Comment{ContentID: content.ID, AuthorID: bob.ID, Text: "achetez ici"}
+	require.NoError(t, db.Omit("Author", "Content").Create(comment).Error)
+	message := &models.Message{SenderID: bob.ID, ReceiverID: alice.ID, Text: "insulte"}
+	require.NoError(t, db.Create(message).Error)
+
+	_, err := reports.Submit(alice.ID, models.ReportTargetContent, content.ID, "bad", "")
+	assert.ErrorIs(t, err, services.ErrInvalidReportReason)
+	_, err = reports.Submit(alice.ID, models.ReportTargetContent, content.ID, models.ReportReasonOther, "")
+	assert.ErrorIs(t, err, services.ErrReportDetailsNeeded)
+	_, err = reports.Submit(creator.ID, models.ReportTargetContent, content.ID, models.ReportReasonSpam, "")
+	assert.ErrorIs(t, err, services.ErrSelfReport)
+	_, err = reports.Submit(creator.ID, models.ReportTargetMessage, message.ID, models.ReportReasonHarassment, "")
+	assert.ErrorIs(t, err, services.ErrReportTargetNotFound, "seul le destinataire peut signaler un message")
+
+	first, err := reports.Submit(alice.ID, models.ReportTargetContent, content.ID, models.ReportReasonCopyright, "")
+	require.NoError(t, err)
+	_, err = reports.Submit(alice.ID, models.ReportTargetContent, content.ID, models.ReportReasonSpam, "")
+	assert.ErrorIs(t, err, services.ErrDuplicateReport)
+	_, err = reports.Submit(bob.ID, models.ReportTargetContent, content.ID, models.ReportReasonCopyright, "")
+	require.NoError(t, err)
+	commentReport, err := reports.Submit(alice.ID, models.ReportTargetComment, comment.ID, models.ReportReasonSpam, "")
+	require.NoError(t, err)
+	_, err = reports.Submit(alice.ID, models.ReportTargetMessage, message.ID, models.ReportReasonHarassment, "")
+	require.NoError(t, err)
+	_, err = reports.Submit(bob.ID, models.ReportTargetUser, creator.ID, models.ReportReasonImpersonation, "")
+	require.NoError(t, err)
+
+	groups, total, err := reports.List(repositories.ReportFilter{Status: models.ReportStatusOpen}, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	require.Len(t, groups, 2)
+	assert.Equal(t, content.ID, groups[0].TargetID)
+	assert.Equal(t, int64(2), groups[0].ReportCount)
+	assert.Equal(t, 2, groups[0].ReasonCounts[models.ReportReasonCopyright])
+
+	_, err = reports.Resolve(commentReport.ID, uuid.New(), models.ReportActionContentRejected, "")
+	assert.ErrorIs(t, err, services.ErrInvalidReportAction)
+
+	adminID := uuid.New()
+	assigned, err := reports.Assign(first.ID, adminID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReportStatusInReview, assigned.Status)
+	_, err = reports.Resolve(first.ID, adminID, models.ReportActionContentRejected, "")
+	require.NoError(t, err)
+
+	stored, err := repo.FindByID(content.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ContentStatusRejected, stored.Status)
+
+	// Les deux signalements du contenu sont clos ; alice peut de nouveau le signaler.
+	groups, total, err = reports.List(repositories.ReportFilter{Status: models.ReportStatusOpen, TargetType: models.ReportTargetContent}, 0, 0)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, groups)
+	_, err = reports.Dismiss(first.ID, adminID, "")
+	assert.ErrorIs(t, err, services.ErrReportClosed)
+}
+
+func TestReports_ResolveIsAtomicAndRaceMapsToDuplicate(t *testing.T) {
+	_, repo, db := setupContentLifecycle(t)
+	require.NoError(t, database.MigrateSQLite(db, &models.Comment{}, &models.Message{}, &models.Report{},
+		&models.ModerationDecision{}))
+	require.NoError(t, db.Exec(`CREATE UNIQUE INDEX idx_report_open_unique ON reports (reporter_id, target_type, target_id) WHERE status IN ('open', 'in_review')`).Error)
+	moderation := services.NewModerationService(repo, repositories.NewModerationRepository(), nil)
+	reportRepo := repositories.NewReportRepository()
+	reports := services.NewReportService(reportRepo, repo, repositories.NewCommentRepository(),
+		repositories.NewMessageRepository(), repositories.NewUserRepository(), moderation)
+
+	creator := &models.User{Username: "creatrice", Email: "c@test", HashedPassword: "x", Role: models.RoleCreator}
+	alice := &models.User{Username: "alice", Email: "a@test", HashedPassword: "x", Role: models.RoleSubscriber}
+	require.NoError(t, db.Create(creator).Error)
+	require.NoError(t, db.Create(alice).Error)
+	content := &models.Content{CreatorID: creator.ID, Title: "Copie", Body: "b", Price: 3, FilePath: "c.jpg",
+		Status: models.ContentStatusApproved}
+	require.NoError(t, db.Create(content).Error)
+
+	report, err := reports.Submit(alice.ID, models.ReportTargetContent, content.ID, models.ReportReasonCopyright, "")
+	require.NoError(t, err)
+	// Envoi concurrent ayant passé le contrôle préalable : l'index unique tranche.
+	err = reportRepo.Create(&models.Report{TargetType: models.ReportTargetContent, TargetID: content.ID,
+		ReporterID: alice.ID, ReasonCode: models.ReportReasonSpam, Status: models.ReportStatusOpen})
+	assert.ErrorIs(t, err, repositories.ErrDuplicateKey)
+
+	// Échec de la clôture des signalements : le rejet est annulé avec elle.
+	require.NoError(t, db.Callback().Update().Before("gorm:update").Register("test:fail_reports", func(tx *gorm.DB) {
+		if tx.Statement.Table == "reports" {
+			tx.AddError(errors.New("panne"))
+		}
+	}))
+	_, err = reports.Resolve(report.ID, uuid.New(), models.ReportActionContentRejected, "")
+	require.Error(t, err)
+	require.NoError(t, db.Callback().Update().Remove("test:fail_reports"))
+
+	stored, err := repo.FindByID(content.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ContentStatusApproved, stored.Status)
+	var decisions int64
+	require.NoError(t, db.Model(&models.ModerationDecision{}).Count(&decisions).Error)
+	assert.Zero(t, decisions)
+
+	_, err = reports.Resolve(report.ID, uuid.New(), models.ReportActionContentRejected, "")
+	require.NoError(t, err)
+	stored, err = repo.FindByID(content.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ContentStatusRejected, stored.Status)
+}
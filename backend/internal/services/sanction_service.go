@@ -0,0 +1,237 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var (
+	ErrInvalidSanctionKind    = errors.New("type de sanction invalide")
+	ErrSanctionReasonRequired = errors.New("le motif de la sanction est requis")
+	ErrSanctionExpiryRequired = errors.New("une suspension doit avoir une date de fin")
+	ErrSanctionExpiryInPast   = errors.New("la date de fin doit être dans le futur")
+	ErrCannotSanctionAdmin    = errors.New("impossible de sanctionner un administrateur")
+	ErrSanctionNotFound       = errors.New("sanction non trouvée")
+	ErrSanctionLifted         = errors.New("cette sanction n'est plus en vigueur")
+
+	ErrAccountBanned        = errors.New("compte banni")
+	ErrAccountSuspended     = errors.New("compte suspendu")
+	ErrPostingRestricted    = errors.New("la publication de contenus vous est interdite")
+	ErrCommentingRestricted = errors.New("les commentaires vous sont interdits")
+	ErrMessagingRestricted  = errors.New("la messagerie vous est interdite")
+
+	// ErrSanctionCheckUnavailable : les sanctions n'ont pas pu être lues ;
+	// l'accès est refusé plutôt qu'accordé à l'aveugle.
+	ErrSanctionCheckUnavailable = errors.New("vérification du compte momentanément indisponible")
+)
+
+// restrictionErrors associe chaque restriction à l'erreur renvoyée à
+// l'utilisateur concerné.
+var restrictionErrors = map[string]error{
+	models.SanctionRestrictPosting:    ErrPostingRestricted,
+	models.SanctionRestrictCommenting: ErrCommentingRestricted,
+	models.SanctionRestrictMessaging:  ErrMessagingRestricted,
+}
+
+// SanctionService applique, lève et fait respecter les sanctions de compte.
+// Toutes les vérifications acceptent un service nil (aucune sanction).
+type SanctionService struct {
+	sanctions     *repositories.SanctionRepository
+	users         *repositories.UserRepository
+	subscriptions *repositories.SubscriptionRepository
+	now           func() time.Time
+}
+
+func NewSanctionService(
+	sanctions *repositories.SanctionRepository,
+	users *repositories.UserRepository,
+	subscriptions *repositories.SubscriptionRepository,
+) *SanctionService {
+	return &SanctionService{sanctions: sanctions, users: users, subscriptions: subscriptions, now: time.Now}
+}
+
+// Issue sanctionne un utilisateur. Une suspension exige une date de fin, un
+// bannissement n'en a jamais ; bannir un créateur suspend, dans la même
+// transaction, les abonnements de ses abonnés.
+func (s *SanctionService) Issue(userID, adminID uuid.UUID, kind, reason string, expiresAt *time.Time) (*models.Sanction, error) {
+	if _, ok := models.SanctionLabels[kind]; !ok {
+		return nil, ErrInvalidSanctionKind
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrSanctionReasonRequired
+	}
+	now := s.now()
+	switch {
+	case kind == models.SanctionBan:
+		expiresAt = nil
+	case kind == models.SanctionSuspension && expiresAt == nil:
+		return nil, ErrSanctionExpiryRequired
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, ErrSanctionExpiryInPast
+	}
+
+	user, err := s.users.FindByID(userID)
+	if err != nil || user == nil {
+		return nil, ErrUserNotFound
+	}
+	if user.Role == models.RoleAdmin {
+		return nil, ErrCannotSanctionAdmin
+	}
+
+	sanction := &models.Sanction{
+		UserID:    userID,
+		Kind:      kind,
+		Reason:    reason,
+		IssuedBy:  adminID,
+		ExpiresAt: expiresAt,
+	}
+	var pause repositories.TxStep
+	var paused int64
+	pauses := kind == models.SanctionBan && user.Role == models.RoleCreator
+	if pauses {
+		pause = s.subscriptions.PauseByCreatorStep(userID, now, &paused)
+	}
+	if err := repositories.InTransaction(s.sanctions.CreateStep(sanction), pause); err != nil {
+		return nil, err
+	}
+
+	log.Printf("⛔ Sanction %s appliquée à %s par %s", kind, userID, adminID)
+	logger.LogSecurity("user_sanctioned", map[string]interface{}{
+		"sanction_id": sanction.ID.String(),
+		"user_id":     userID.String(),
+		"admin_id":    adminID.String(),
+		"kind":        kind,
+		"expires_at":  expiresAt,
+	})
+
+	if pauses {
+		log.Printf("⏸️ %d abonnements au créateur %s suspendus", paused, userID)
+		logger.LogBusinessEvent("creator_subscriptions_paused", map[string]interface{}{
+			"creator_id": userID.String(),
+			"count":      paused,
+		})
+	}
+	return sanction, nil
+}
+
+// Lift lève une sanction en vigueur. La levée du dernier bannissement d'un
+// créateur réactive les abonnements suspendus.
+func (s *SanctionService) Lift(sanctionID, adminID uuid.UUID, reason string) (*models.Sanction, error) {
+	sanction, err := s.sanctions.FindByID(sanctionID)
+	if err != nil {
+		return nil, err
+	}
+	if sanction == nil {
+		return nil, ErrSanctionNotFound
+	}
+	now := s.now()
+	if !sanction.ActiveAt(now) {
+		return nil, ErrSanctionLifted
+	}
+
+	sanction.LiftedAt = &now
+	sanction.LiftedBy = &adminID
+	sanction.LiftReason = strings.TrimSpace(reason)
+	if err := s.sanctions.Update(sanction); err != nil {
+		return nil, err
+	}
+	log.Printf("✅ Sanction %s levée pour %s par %s", sanction.Kind, sanction.UserID, adminID)
+	logger.LogSecurity("user_sanction_lifted", map[string]interface{}{
+		"sanction_id": sanction.ID.String(),
+		"user_id":     sanction.UserID.String(),
+		"admin_id":    adminID.String(),
+		"kind":        sanction.Kind,
+	})
+
+	if sanction.Kind == models.SanctionBan {
+		bans, err := s.sanctions.FindActiveByUser(sanction.UserID, now, models.SanctionBan)
+		if err != nil {
+			return sanction, err
+		}
+		if len(bans) == 0 {
+			resumed, err := s.subscriptions.ResumeByCreator(sanction.UserID, now)
+			if err != nil {
+				return sanction, fmt.Errorf("reprise des abonnements: %w", err)
+			}
+			if resumed > 0 {
+				log.Printf("▶️ %d abonnements au créateur %s réactivés", resumed, sanction.UserID)
+			}
+		}
+	}
+	return sanction, nil
+}
+
+// CheckAccess refuse l'accès d'un compte banni ou suspendu. Si les sanctions
+// ne peuvent pas être lues, l'accès est refusé (ErrSanctionCheckUnavailable).
+func (s *SanctionService) CheckAccess(userID uuid.UUID) error {
+	if s == nil {
+		return nil
+	}
+	active, err := s.sanctions.FindActiveByUser(userID, s.now(), models.SanctionBan, models.SanctionSuspension)
+	if err != nil {
+		log.Printf("⚠️ Vérification des sanctions de %s impossible: %v", userID, err)
+		return fmt.Errorf("%w: %v", ErrSanctionCheckUnavailable, err)
+	}
+	for _, sanction := range active {
+		if sanction.Kind == models.SanctionBan {
+			return ErrAccountBanned
+		}
+	}
+	if len(active) > 0 {
+		// Plusieurs suspensions : la plus longue fait foi.
+		until := *active[0].ExpiresAt
+		for _, sanction := range active[1:] {
+			if sanction.ExpiresAt.After(until) {
+				until = *sanction.ExpiresAt
+			}
+		}
+		return fmt.Errorf("%w jusqu'au %s", ErrAccountSuspended, until.Format("02/01/2006 15:04"))
+	}
+	return nil
+}
+
+// Ensure vérifie qu'une restriction (restrict_posting, restrict_commenting,
+// restrict_messaging) ne s'applique pas à l'utilisateur.
+func (s *SanctionService) Ensure(userID uuid.UUID, restriction string) error {
+	if s == nil {
+		return nil
+	}
+	if err := s.CheckAccess(userID); err != nil {
+		return err
+	}
+	active, err := s.sanctions.FindActiveByUser(userID, s.now(), restriction)
+	if err != nil {
+		return err
+	}
+	if len(active) > 0 {
+		return restrictionErrors[restriction]
+	}
+	return nil
+}
+
+// Active renvoie les sanctions en vigueur d'un utilisateur.
+func (s *SanctionService) Active(userID uuid.UUID) ([]models.Sanction, error) {
+	return s.sanctions.FindActiveByUser(userID, s.now())
+}
+
+// History renvoie toutes les sanctions d'un utilisateur.
+func (s *SanctionService) History(userID uuid.UUID) ([]models.Sanction, error) {
+	return s.sanctions.FindByUser(userID)
+}
+
+// ListActive liste les sanctions en vigueur, tous comptes confondus.
+func (s *SanctionService) ListActive(limit, offset int) ([]models.Sanction, error) {
+	limit, offset = Pagination(limit, offset)
+	return s.sanctions.FindActive(s.now(), limit, offset)
+}
@@ -0,0 +1,109 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+func TestSanctions_EnforceAndPauseCreatorBilling(t *testing.T) {
+	_, _, db := setupContentLifecycle(t)
+	require.NoError(t, database.MigrateSQLite(db, &models.Sanction{}, &models.Subscription{}, &models.Comment{}))
+	subscriptionRepo := repositories.NewSubscriptionRepository()
+	sanctions := services.NewSanctionService(repositories.NewSanctionRepository(), repositories.NewUserRepository(), subscriptionRepo)
+	comments := services.NewCommentService(repositories.NewCommentRepository(), repositories.NewCommentLikeRepository(),
+		repositories.NewUserRepository(), sanctions)
+
+	admin := &models.User{Username: "admin", Email: "admin@test", HashedPassword: "x", Role: models.RoleAdmin}
+	creator := &models.User{Username: "artiste", Email: "c@test", HashedPassword: "x", Role: models.RoleCreator}
+	fan := &models.User{Username: "fan", Email: "f@test", HashedPassword: "x", Role: models.RoleSubscriber}
+	for _, u := range []*models.User{admin, creator, fan} {
+		require.NoError(t, db.Create(u).Error)
+	}
+	now := time.Now()
+	endDate := now.Add(10 * 24 * time.Hour)
+	sub := &models.Subscription{CreatorID: creator.ID, SubscriberID: fan.ID, StartDate: now.Add(-time.Hour),
+		EndDate: endDate, PaymentID: uuid.New(), Price: models.SubscriptionPriceCents, Status: models.SubscriptionStatusActive}
+	require.NoError(t, db.Create(sub).Error)
+
+	_, err := sanctions.Issue(fan.ID, admin.ID, models.SanctionSuspension, "spam", nil)
+	assert.ErrorIs(t, err, services.ErrSanctionExpiryRequired)
+	_, err = sanctions.Issue(fan.ID, admin.ID, models.SanctionRestrictCommenting, " ", nil)
+	assert.ErrorIs(t, err, services.ErrSanctionReasonRequired)
+	_, err = sanctions.Issue(admin.ID, admin.ID, models.SanctionBan, "test", nil)
+	assert.ErrorIs(t, err, services.ErrCannotSanctionAdmin)
+
+	// Restriction : le compte reste accessible mais ne peut plus commenter.
+	restriction, err := sanctions.Issue(fan.ID, admin.ID, models.SanctionRestrictCommenting, "insultes répétées", nil)
+	require.NoError(t, err)
+	assert.NoError(t, sanctions.CheckAccess(fan.ID))
+	_, err = comments.PostComment(uuid.New(), fan.ID, "encore", nil)
+	assert.ErrorIs(t, err, services.ErrCommentingRestricted)
+	_, err = sanctions.Lift(restriction.ID, admin.ID, "")
+	require.NoError(t, err)
+	_, err = sanctions.Lift(restriction.ID, admin.ID, "")
+	assert.ErrorIs(t, err, services.ErrSanctionLifted)
+
+	until := now.Add(48 * time.Hour)
+	_, err = sanctions.Issue(fan.ID, admin.ID, models.SanctionSuspension, "spam", &until)
+	require.NoError(t, err)
+	assert.ErrorIs(t, sanctions.CheckAccess(fan.ID), services.ErrAccountSuspended)
+	assert.ErrorIs(t, sanctions.Ensure(fan.ID, models.SanctionRestrictMessaging), services.ErrAccountSuspended)
+
+	// Bannir le créateur suspend l'abonnement de ses abonnés, la levée le reprend.
+	ban, err := sanctions.Issue(creator.ID, admin.ID, models.SanctionBan, "fraude", &until)
+	require.NoError(t, err)
+	assert.Nil(t, ban.ExpiresAt, "un bannissement est définitif")
+	assert.ErrorIs(t, sanctions.CheckAccess(creator.ID), services.ErrAccountBanned)
+
+	var paused models.Subscription
+	require.NoError(t, db.First(&paused, "id = ?", sub.ID).Error)
+	assert.Equal(t, models.SubscriptionStatusPaused, paused.Status)
+	require.NotNil(t, paused.PausedAt)
+	contents := repositories.NewContentRepository()
+	subscribed, err := contents.IsUserSubscribedToCreator(fan.ID, creator.ID)
+	require.NoError(t, err)
+	assert.False(t, subscribed, "un abonnement suspendu ne donne plus accès")
+
+	_, err = sanctions.Lift(ban.ID, admin.ID, "erreur")
+	require.NoError(t, err)
+	assert.NoError(t, sanctions.CheckAccess(creator.ID))
+	var resumed models.Subscription
+	require.NoError(t, db.First(&resumed, "id = ?", sub.ID).Error)
+	assert.Equal(t, models.SubscriptionStatusActive, resumed.Status)
+	assert.Nil(t, resumed.PausedAt)
+	assert.False(t, resumed.EndDate.Before(endDate))
+	subscribed, err = contents.IsUserSubscribedToCreator(fan.ID, creator.ID)
+	require.NoError(t, err)
+	assert.True(t, subscribed)
+
+	// L'abonné d'un créateur banni peut résilier son abonnement suspendu.
+	_, err = sanctions.Issue(creator.ID, admin.ID, models.SanctionBan, "récidive", nil)
+	require.NoError(t, err)
+	require.NoError(t, services.NewSubscriptionService(subscriptionRepo, sanctions).Unsubscribe(fan.ID, creator.ID))
+	var canceled models.Subscription
+	require.NoError(t, db.First(&canceled, "id = ?", sub.ID).Error)
+	assert.Equal(t, models.SubscriptionStatusCanceled, canceled.Status)
+	assert.Nil(t, canceled.PausedAt)
+
+	// Si les abonnements ne peuvent pas être suspendus, le bannissement
+	// n'est pas enregistré : il peut être redemandé sans doublon.
+	other := &models.User{Username: "autre", Email: "o@test", HashedPassword: "x", Role: models.RoleCreator}
+	require.NoError(t, db.Create(other).Error)
+	require.NoError(t, db.Migrator().DropTable(&models.Subscription{}))
+	_, err = sanctions.Issue(other.ID, admin.ID, models.SanctionBan, "fraude", nil)
+	require.Error(t, err)
+	assert.NoError(t, sanctions.CheckAccess(other.ID))
+
+	// Sanctions illisibles : l'accès est refusé, pas accordé.
+	require.NoError(t, db.Migrator().DropTable(&models.Sanction{}))
+	assert.ErrorIs(t, sanctions.CheckAccess(fan.ID), services.ErrSanctionCheckUnavailable)
+}
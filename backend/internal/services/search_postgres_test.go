@@ -0,0 +1,57 @@
+//go:build postgres
+
+package services_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/config"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+// Lancer avec : TEST_DATABASE_URL=postgres://... go test -tags postgres ./internal/services/
+func TestSearchPostgres_HighlightsAreEscaped(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL non défini")
+	}
+	config.C.DatabaseURL = dsn
+	database.Init()
+
+	suffix := uuid.NewString()[:8]
+	creator := &models.User{Username: "xss_" + suffix, Email: "xss_" + suffix + "@test", HashedPassword: "x",
+		Role: models.RoleCreator, Bio: `<script>alert(1)</script> aquarelliste ` + suffix}
+	require.NoError(t, database.DB.Create(creator).Error)
+	content := &models.Content{CreatorID: creator.ID, Title: `<img src=x onerror="alert(1)"> marine ` + suffix,
+		Body: `<a href="javascript:alert(1)">marine</a> & port ` + suffix, Price: 5, FilePath: "x.jpg",
+		Status: models.ContentStatusApproved, Maturity: models.MaturityGeneral}
+	require.NoError(t, database.DB.Create(content).Error)
+	t.Cleanup(func() {
+		database.DB.Delete(&models.Content{}, "id = ?", content.ID)
+		database.DB.Delete(&models.User{}, "id = ?", creator.ID)
+	})
+
+	svc := services.NewSearchService(repositories.NewSearchRepository(), repositories.NewTagRepository(),
+		repositories.NewSearchHistoryRepository())
+	res, err := svc.Search(services.SearchRequest{Query: suffix})
+	require.NoError(t, err)
+	require.Len(t, res.Contents, 1)
+	require.Len(t, res.Creators, 1)
+
+	hit := res.Contents[0]
+	for _, highlight := range []string{hit.TitleHighlight, hit.BodyHighlight, res.Creators[0].BioHighlight} {
+		assert.NotContains(t, highlight, "<script")
+		assert.NotContains(t, highlight, "<img")
+		assert.NotContains(t, highlight, "<a ")
+		assert.Contains(t, highlight, "<mark>"+suffix+"</mark>")
+	}
+	assert.Contains(t, hit.TitleHighlight, `&lt;img src=x onerror=&quot;alert(1)&quot;&gt;`)
+}
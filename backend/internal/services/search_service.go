@@ -0,0 +1,311 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var (
+	ErrInvalidSearchQuery  = errors.New("paramètre 'q' manquant ou trop long (max 256 caractères)")
+	ErrInvalidSearchFilter = errors.New("filtres de recherche invalides")
+	ErrRecentSearchMissing = errors.New("recherche récente introuvable")
+)
+
+// Types de résultats de recherche.
+const (
+	SearchTypeCreators = "creators"
+	SearchTypeContents = "contents"
+)
+
+const (
+	maxSearchQueryLength = 256
+	maxSuggestLength     = 64
+	suggestLimit         = 5
+	// suggestBudget borne la durée de l'autocomplétion : les sources qui
+	// n'ont pas répondu à temps sont omises.
+	suggestBudget = 150 * time.Millisecond
+	// recentSearchesKept est la taille de l'historique par utilisateur.
+	recentSearchesKept = 20
+	// searchMissRetention : les recherches sans résultat plus vues depuis
+	// cette durée sont purgées.
+	searchMissRetention = 90 * 24 * time.Hour
+)
+
+// SearchRequest décrit une recherche ; Types vide cherche partout.
+type SearchRequest struct {
+	Query         string
+	Types         []string
+	ViewerID      uuid.UUID
+	IncludeMature bool
+	MinPrice      *int
+	MaxPrice      *int
+	From          *time.Time
+	To            *time.Time
+	Limit         int
+	Offset        int
+}
+
+// SearchResult regroupe une page de chaque type de résultat demandé.
+type SearchResult struct {
+	Creators      []repositories.CreatorHit `json:"creators"`
+	Contents      []repositories.ContentHit `json:"contents"`
+	TotalCreators int64                     `json:"total_creators"`
+	TotalContents int64                     `json:"total_contents"`
+}
+
+// Suggestions regroupe les propositions d'autocomplétion ; Partial indique
+// qu'une source a dépassé le budget de temps.
+type Suggestions struct {
+	Creators []repositories.CreatorSuggestion `json:"creators"`
+	Tags     []repositories.TagCount          `json:"tags"`
+	Contents []repositories.TitleSuggestion   `json:"contents"`
+	Partial  bool                             `json:"partial,omitempty"`
+}
+
+// SearchService recherche les contenus publiés et les créateurs, propose
+// l'autocomplétion et tient l'historique des recherches.
+type SearchService struct {
+	repo    *repositories.SearchRepository
+	tags    *repositories.TagRepository
+	history *repositories.SearchHistoryRepository
+	now     func() time.Time
+}
+
+func NewSearchService(
+	repo *repositories.SearchRepository,
+	tags *repositories.TagRepository,
+	history *repositories.SearchHistoryRepository,
+) *SearchService {
+	return &SearchService{repo: repo, tags: tags, history: history, now: time.Now}
+}
+
+// normalizeSearch met une recherche en minuscules et réduit ses espaces.
+func normalizeSearch(q string) string {
+	return strings.Join(strings.Fields(strings.ToLower(q)), " ")
+}
+
+// Search valide la requête et les filtres puis interroge chaque type demandé.
+func (s *SearchService) Search(req SearchRequest) (*SearchResult, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" || utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, ErrInvalidSearchQuery
+	}
+	types := map[string]bool{}
+	for _, t := range req.Types {
+		switch t = strings.TrimSpace(t); t {
+		case SearchTypeCreators, SearchTypeContents:
+			types[t] = true
+		case "":
+		default:
+			return nil, ErrInvalidSearchFilter
+		}
+	}
+	if len(types) == 0 {
+		types[SearchTypeCreators], types[SearchTypeContents] = true, true
+	}
+	if (req.MinPrice != nil && *req.MinPrice < 0) ||
+		(req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice) ||
+		(req.From != nil && req.To != nil && !req.From.Before(*req.To)) {
+		return nil, ErrInvalidSearchFilter
+	}
+
+	limit, offset := Pagination(req.Limit, req.Offset)
+	filter := repositories.SearchFilter{
+		Query:         query,
+		ViewerID:      req.ViewerID,
+		IncludeMature: req.IncludeMature,
+		MinPrice:      req.MinPrice,
+		MaxPrice:      req.MaxPrice,
+		From:          req.From,
+		To:            req.To,
+		Limit:         limit,
+		Offset:        offset,
+		Now:           s.now(),
+	}
+
+	result := &SearchResult{Creators: []repositories.CreatorHit{}, Contents: []repositories.ContentHit{}}
+	if types[SearchTypeCreators] {
+		creators, total, err := s.repo.SearchCreators(filter)
+		if err != nil {
+			return nil, err
+		}
+		if creators != nil {
+			result.Creators = creators
+		}
+		result.TotalCreators = total
+	}
+	if types[SearchTypeContents] {
+		contents, total, err := s.repo.SearchContents(filter)
+		if err != nil {
+			return nil, err
+		}
+		for i := range contents {
+			contents[i].ThumbnailURL = "/api/contents/" + contents[i].ID.String() + "/image"
+		}
+		if contents != nil {
+			result.Contents = contents
+		}
+		result.TotalContents = total
+	}
+
+	// Seule la première page compte comme une nouvelle recherche.
+	if offset == 0 {
+		s.record(req.ViewerID, normalizeSearch(query), result.TotalCreators+result.TotalContents == 0)
+	}
+	return result, nil
+}
+
+func (s *SearchService) record(userID uuid.UUID, query string, miss bool) {
+	if s.history == nil {
+		return
+	}
+	now := s.now()
+	if userID != uuid.Nil {
+		if err := s.history.Remember(userID, query, now, recentSearchesKept); err != nil {
+			log.Printf("⚠️ Historique de recherche de %s : %v", userID, err)
+		}
+	}
+	if miss {
+		if err := s.history.RecordMiss(query, now); err != nil {
+			log.Printf("⚠️ Recherche sans résultat %q : %v", query, err)
+		}
+	}
+}
+
+// Suggest propose des créateurs, des tags et des titres commençant par q,
+// en interrogeant les trois sources en parallèle dans le budget de temps.
+func (s *SearchService) Suggest(ctx context.Context, q string, includeMature bool) (*Suggestions, error) {
+	prefix := normalizeSearch(strings.TrimPrefix(strings.TrimSpace(q), "#"))
+	out := &Suggestions{
+		Creators: []repositories.CreatorSuggestion{},
+		Tags:     []repositories.TagCount{},
+		Contents: []repositories.TitleSuggestion{},
+	}
+	if prefix == "" {
+		return out, nil
+	}
+	if utf8.RuneCountInString(prefix) > maxSuggestLength {
+		return nil, ErrInvalidSearchQuery
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, suggestBudget)
+	defer cancel()
+	repo, tags := s.repo.WithContext(ctx), s.tags.WithContext(ctx)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	run := func(fetch func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fetch(); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	var (
+		creators []repositories.CreatorSuggestion
+		tagList  []repositories.TagCount
+		titles   []repositories.TitleSuggestion
+	)
+	run(func() (err error) { creators, err = repo.SuggestCreators(prefix, suggestLimit); return })
+	run(func() (err error) { tagList, err = tags.Autocomplete(prefix, suggestLimit); return })
+	run(func() (err error) { titles, err = repo.SuggestTitles(prefix, includeMature, suggestLimit); return })
+	wg.Wait()
+
+	for _, err := range errs {
+		if ctx.Err() == nil {
+			return nil, err
+		}
+		out.Partial = true
+	}
+	if creators != nil {
+		out.Creators = creators
+	}
+	if tagList != nil {
+		out.Tags = tagList
+	}
+	if titles != nil {
+		out.Contents = titles
+	}
+	return out, nil
+}
+
+// PurgeMisses supprime les recherches sans résultat qui ne sont plus tapées,
+// pour que la table ne grossisse pas sans fin.
+func (s *SearchService) PurgeMisses() (int64, error) {
+	return s.history.PurgeMisses(s.now().Add(-searchMissRetention))
+}
+
+// StartCleanup purge périodiquement les recherches sans résultat.
+func (s *SearchService) StartCleanup(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			n, err := s.PurgeMisses()
+			if err != nil {
+				log.Printf("⚠️ Purge des recherches sans résultat : %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("🧹 %d recherche(s) sans résultat purgée(s)", n)
+			}
+		}
+	}()
+}
+
+// RecentSearches liste l'historique de recherche de l'utilisateur.
+func (s *SearchService) RecentSearches(userID uuid.UUID) ([]models.RecentSearch, error) {
+	list, err := s.history.Recent(userID, recentSearchesKept)
+	if list == nil {
+		list = []models.RecentSearch{}
+	}
+	return list, err
+}
+
+// DeleteRecentSearch retire une recherche de l'historique de l'utilisateur.
+func (s *SearchService) DeleteRecentSearch(userID, id uuid.UUID) error {
+	found, err := s.history.Delete(userID, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrRecentSearchMissing
+	}
+	return nil
+}
+
+// ClearRecentSearches efface l'historique de recherche de l'utilisateur.
+func (s *SearchService) ClearRecentSearches(userID uuid.UUID) error {
+	return s.history.Clear(userID)
+}
+
+// SearchMisses liste les recherches sans résultat des derniers jours, les
+// plus fréquentes d'abord.
+func (s *SearchService) SearchMisses(days, limit, offset int) ([]models.SearchMiss, error) {
+	if days <= 0 {
+		days = 30
+	}
+	limit, offset = Pagination(limit, offset)
+	list, err := s.history.TopMisses(s.now().AddDate(0, 0, -days), limit, offset)
+	if list == nil {
+		list = []models.SearchMiss{}
+	}
+	return list, err
+}
@@ -0,0 +1,220 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+type searchFixture struct {
+	*testStore
+	svc    *services.SearchService
+	viewer *models.User
+	alice  *models.User
+	base   time.Time
+}
+
+func setupSearch(t *testing.T) *searchFixture {
+	f := &searchFixture{testStore: newTestStore(t, &models.User{}, &models.Content{}, &models.Subscription{},
+		&models.Tag{}, &models.ContentTag{}, &models.RecentSearch{}, &models.SearchMiss{}),
+		svc: services.NewSearchService(repositories.NewSearchRepository(), repositories.NewTagRepository(),
+			repositories.NewSearchHistoryRepository()),
+		base: time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)}
+	bio := func(text string) func(*models.User) { return func(u *models.User) { u.Bio = text } }
+	f.viewer = f.user("fan", models.RoleSubscriber)
+	f.alice = f.user("alice_encre", models.RoleCreator, bio("Illustratrice, aquarelles et encre de Chine"))
+	bob := f.user("bob", models.RoleCreator, bio("Sculpture"))
+
+	tag := &models.Tag{Name: "aquarelle"}
+	f.create(tag)
+	add := func(creator *models.User, title, body string, price int, status, maturity string, day int) *models.Content {
+		return f.content(creator, title, status, f.base.AddDate(0, 0, day), func(c *models.Content) {
+			c.Body, c.Price, c.Maturity = body, price, maturity
+		})
+	}
+	add(f.alice, "Aquarelle du port", "Une marine au petit matin", 5, models.ContentStatusApproved, models.MaturityGeneral, 0)
+	add(f.alice, "Brouillon aquarelle", "pas encore publié", 5, models.ContentStatusPending, models.MaturityGeneral, 1)
+	add(f.alice, "Nu à l'aquarelle", "étude", 20, models.ContentStatusApproved, models.MaturityMature, 2)
+	tagged := add(bob, "Buste en bronze", "patine verte", 50, models.ContentStatusApproved, models.MaturityGeneral, 3)
+	add(bob, "Paysage", "une aquarelle lumineuse", 12, models.ContentStatusApproved, models.MaturityGeneral, 4)
+	f.create(&models.ContentTag{ContentID: tagged.ID, TagID: tag.ID})
+	return f
+}
+
+func searchTitles(t *testing.T, f *searchFixture, req services.SearchRequest) []string {
+	req.Types = []string{services.SearchTypeContents}
+	res, err := f.svc.Search(req)
+	require.NoError(t, err)
+	titles := make([]string, len(res.Contents))
+	for i, c := range res.Contents {
+		titles[i] = c.Title
+	}
+	return titles
+}
+
+func TestSearch_ContentsRankingAndVisibility(t *testing.T) {
+	f := setupSearch(t)
+
+	assert.Equal(t, []string{"Aquarelle du port", "Paysage", "Buste en bronze"},
+		searchTitles(t, f, services.SearchRequest{Query: "Aquarelle"}),
+		"titre, puis texte, puis tag ; jamais les contenus non approuvés ni adultes")
+	assert.Contains(t, searchTitles(t, f, services.SearchRequest{Query: "aquarelle", IncludeMature: true}), "Nu à l'aquarelle")
+	assert.Equal(t, []string{"Paysage", "Buste en bronze"}, searchTitles(t, f, services.SearchRequest{Query: "bob"}),
+		"le nom du créateur fait partie des champs recherchés")
+
+	res, err := f.svc.Search(services.SearchRequest{Query: "port", Types: []string{services.SearchTypeContents}})
+	require.NoError(t, err)
+	require.Len(t, res.Contents, 1)
+	hit := res.Contents[0]
+	assert.Equal(t, "Aquarelle du <mark>port</mark>", hit.TitleHighlight)
+	assert.Equal(t, "/api/contents/"+hit.ID.String()+"/image", hit.ThumbnailURL)
+	assert.Empty(t, res.Creators, "seul le type demandé est cherché")
+
+	f.content(f.alice, `<img src=x onerror="alert(1)"> Quai & phare`, models.ContentStatusApproved, time.Time{})
+	res, err = f.svc.Search(services.SearchRequest{Query: "quai", Types: []string{services.SearchTypeContents}})
+	require.NoError(t, err)
+	require.Len(t, res.Contents, 1)
+	assert.Equal(t, `&lt;img src=x onerror=&quot;alert(1)&quot;&gt; <mark>Quai</mark> &amp; phare`, res.Contents[0].TitleHighlight,
+		"le texte saisi est échappé, seules les balises de surlignage sont du HTML")
+}
+
+func TestSearch_FiltersAndPagination(t *testing.T) {
+	f := setupSearch(t)
+	price := func(v int) *int { return &v }
+	at := func(day int) *time.Time { d := f.base.AddDate(0, 0, day); return &d }
+
+	assert.Equal(t, []string{"Paysage", "Buste en bronze"},
+		searchTitles(t, f, services.SearchRequest{Query: "aquarelle", MinPrice: price(10)}))
+	assert.Equal(t, []string{"Aquarelle du port", "Paysage"},
+		searchTitles(t, f, services.SearchRequest{Query: "aquarelle", MaxPrice: price(12)}))
+	assert.Equal(t, []string{"Buste en bronze"},
+		searchTitles(t, f, services.SearchRequest{Query: "aquarelle", From: at(1), To: at(4)}))
+
+	res, err := f.svc.Search(services.SearchRequest{Query: "aquarelle", Types: []string{services.SearchTypeContents}, Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, res.Contents, 2)
+	assert.Equal(t, int64(3), res.TotalContents)
+	res, err = f.svc.Search(services.SearchRequest{Query: "aquarelle", Types: []string{services.SearchTypeContents}, Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	require.Len(t, res.Contents, 1)
+	assert.Equal(t, "Buste en bronze", res.Contents[0].Title)
+
+	for _, req := range []services.SearchRequest{
+		{Query: "a", MinPrice: price(20), MaxPrice: price(10)},
+		{Query: "a", From: at(3), To: at(1)},
+		{Query: "a", Types: []string{"users"}},
+	} {
+		_, err := f.svc.Search(req)
+		assert.ErrorIs(t, err, services.ErrInvalidSearchFilter)
+	}
+	_, err = f.svc.Search(services.SearchRequest{Query: "   "})
+	assert.ErrorIs(t, err, services.ErrInvalidSearchQuery)
+}
+
+func TestSearch_Creators(t *testing.T) {
+	f := setupSearch(t)
+	f.subscription(f.viewer, f.alice, time.Now().Add(-time.Hour), time.Now().Add(24*time.Hour), models.SubscriptionStatusActive)
+
+	res, err := f.svc.Search(services.SearchRequest{Query: "encre", ViewerID: f.viewer.ID})
+	require.NoError(t, err)
+	require.Len(t, res.Creators, 1, "seuls les créateurs sont proposés")
+	got := res.Creators[0]
+	assert.Equal(t, "alice_encre", got.Username)
+	assert.True(t, got.IsFollowed)
+	assert.Equal(t, "Illustratrice, aquarelles et <mark>encre</mark> de Chine", got.BioHighlight)
+
+	res, err = f.svc.Search(services.SearchRequest{Query: "sculpture", Types: []string{services.SearchTypeCreators}})
+	require.NoError(t, err)
+	require.Len(t, res.Creators, 1)
+	assert.Equal(t, "bob", res.Creators[0].Username)
+	assert.False(t, res.Creators[0].IsFollowed)
+	assert.Equal(t, int64(1), res.TotalCreators)
+}
+
+func TestSearch_Suggest(t *testing.T) {
+	f := setupSearch(t)
+
+	got, err := f.svc.Suggest(context.Background(), "  AQ", false)
+	require.NoError(t, err)
+	assert.False(t, got.Partial)
+	require.Len(t, got.Tags, 1)
+	assert.Equal(t, "aquarelle", got.Tags[0].Name)
+	require.Len(t, got.Contents, 1, "titres approuvés et tous publics seulement")
+	assert.Equal(t, "Aquarelle du port", got.Contents[0].Title)
+	assert.Empty(t, got.Creators)
+
+	got, err = f.svc.Suggest(context.Background(), "ali", false)
+	require.NoError(t, err)
+	require.Len(t, got.Creators, 1)
+	assert.Equal(t, "alice_encre", got.Creators[0].Username)
+
+	got, err = f.svc.Suggest(context.Background(), "bro", false)
+	require.NoError(t, err)
+	require.Len(t, got.Contents, 1, "un mot du titre peut commencer par le préfixe")
+	assert.Equal(t, "Buste en bronze", got.Contents[0].Title)
+
+	got, err = f.svc.Suggest(context.Background(), "%", false)
+	require.NoError(t, err)
+	assert.Empty(t, got.Contents, "les jokers LIKE sont échappés")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err = f.svc.Suggest(ctx, "aq", false)
+	require.NoError(t, err)
+	assert.True(t, got.Partial, "hors budget, les sources en retard sont omises")
+}
+
+func TestSearch_RecentAndMisses(t *testing.T) {
+	f := setupSearch(t)
+	for _, q := range []string{"Aquarelle", "bronze", "  AQUARELLE ", "licorne", "licorne"} {
+		_, err := f.svc.Search(services.SearchRequest{Query: q, ViewerID: f.viewer.ID})
+		require.NoError(t, err)
+	}
+	_, err := f.svc.Search(services.SearchRequest{Query: "licorne", ViewerID: f.viewer.ID, Offset: 20})
+	require.NoError(t, err)
+	_, err = f.svc.Search(services.SearchRequest{Query: "dragon"})
+	require.NoError(t, err)
+
+	recent, err := f.svc.RecentSearches(f.viewer.ID)
+	require.NoError(t, err)
+	queries := make([]string, len(recent))
+	for i, r := range recent {
+		queries[i] = r.Query
+	}
+	assert.ElementsMatch(t, []string{"licorne", "aquarelle", "bronze"}, queries, "une entrée par recherche normalisée")
+
+	misses, err := f.svc.SearchMisses(30, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, misses, 2)
+	assert.Equal(t, "licorne", misses[0].Query)
+	assert.Equal(t, int64(2), misses[0].Searches, "les pages suivantes ne comptent pas")
+	assert.Equal(t, "dragon", misses[1].Query)
+
+	old := time.Now().AddDate(0, 0, -100)
+	f.create(&models.SearchMiss{Query: "oublié", Searches: 3, FirstSeenAt: old, LastSeenAt: old})
+	purged, err := f.svc.PurgeMisses()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged, "seules les recherches plus tapées depuis 90 jours sont purgées")
+	misses, err = f.svc.SearchMisses(366, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, misses, 2)
+
+	assert.ErrorIs(t, f.svc.DeleteRecentSearch(f.alice.ID, recent[0].ID), services.ErrRecentSearchMissing,
+		"on ne supprime que ses propres recherches")
+	require.NoError(t, f.svc.DeleteRecentSearch(f.viewer.ID, recent[0].ID))
+	recent, err = f.svc.RecentSearches(f.viewer.ID)
+	require.NoError(t, err)
+	assert.Len(t, recent, 2)
+
+	require.NoError(t, f.svc.ClearRecentSearches(f.viewer.ID))
+	recent, err = f.svc.RecentSearches(f.viewer.ID)
+	require.NoError(t, err)
+	assert.Empty(t, recent)
+}
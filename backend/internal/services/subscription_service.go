@@ -12,11 +12,18 @@ import (
 )
 
 type SubscriptionService struct {
-	repo *repositories.SubscriptionRepository
+	repo      *repositories.SubscriptionRepository
+	sanctions *SanctionService
+	events    *ContentEventService
 }
 
-func NewSubscriptionService(repo *repositories.SubscriptionRepository) *SubscriptionService {
-	return &SubscriptionService{repo: repo}
+func NewSubscriptionService(repo *repositories.SubscriptionRepository, sanctions *SanctionService) *SubscriptionService {
+	return &SubscriptionService{repo: repo, sanctions: sanctions}
+}
+
+// SetEventRecorder branche l'attribution des abonnements au dernier contenu vu.
+func (s *SubscriptionService) SetEventRecorder(events *ContentEventService) {
+	s.events = events
 }
 
 // Subscribe permet à un abonné de s'abonner à un créateur (30€ fixe)
@@ -50,6 +57,15 @@ func (s *SubscriptionService) Subscribe(creatorID, userID uuid.UUID) error {
 		return errors.New("impossible de s'abonner à soi-même")
 	}
 
+	// Pas de nouvel abonnement payant à un créateur banni ou suspendu.
+	if err := s.sanctions.CheckAccess(creatorID); err != nil {
+		logger.LogBusinessEvent("subscription_to_sanctioned_creator", map[string]interface{}{
+			"subscriber_id": userID.String(),
+			"creator_id":    creatorID.String(),
+		})
+		return errors.New("ce créateur n'accepte pas de nouveaux abonnements")
+	}
+
 	now := time.Now()
 
 	tx := database.DB.Begin()
@@ -86,11 +102,12 @@ func (s *SubscriptionService) Subscribe(creatorID, userID uuid.UUID) error {
 		SubscriptionID: sub.ID,
 		Amount:         models.SubscriptionPriceCents,
 		PaidAt:         now,
-		Status:         "succeeded",
+		Status:         models.StatusSucceeded,
 	}
 
 	if err := tx.Create(payment).Error; err != nil {
 		tx.Rollback()
+		paymentsTotal.WithLabelValues(string(models.StatusFailed), paymentReasonInsert).Inc()
 		logger.LogError(err, "payment_creation_failed", map[string]interface{}{
 			"subscription_id": sub.ID.String(),
 			"amount_cents":    models.SubscriptionPriceCents,
@@ -99,6 +116,7 @@ func (s *SubscriptionService) Subscribe(creatorID, userID uuid.UUID) error {
 	}
 
 	if err := tx.Commit().Error; err != nil {
+		paymentsTotal.WithLabelValues(string(models.StatusFailed), paymentReasonCommit).Inc()
 		logger.LogError(err, "transaction_commit_failed", map[string]interface{}{
 			"subscription_id": sub.ID.String(),
 			"payment_id":      payment.ID.String(),
@@ -106,6 +124,10 @@ func (s *SubscriptionService) Subscribe(creatorID, userID uuid.UUID) error {
 		return err
 	}
 
+	subscriptionsCreated.Inc()
+	paymentsTotal.WithLabelValues(string(payment.Status), paymentReasonNone).Inc()
+	revenueCents.Add(float64(payment.Amount))
+
 	logger.LogBusinessEvent("subscription_created", map[string]interface{}{
 		"subscription_id": sub.ID.String(),
 		"payment_id":      payment.ID.String(),
@@ -123,6 +145,7 @@ func (s *SubscriptionService) Subscribe(creatorID, userID uuid.UUID) error {
 		"payment_method":  "internal",
 	})
 
+	s.events.RecordConversion(userID, creatorID)
 	return nil
 }
 
@@ -132,10 +155,19 @@ func (s *SubscriptionService) Unsubscribe(subscriberID, creatorID uuid.UUID) err
 		"creator_id":    creatorID.String(),
 	})
 
-	result := database.DB.
-		Where("subscriber_id = ? AND creator_id = ? AND status = ?",
-			subscriberID, creatorID, models.SubscriptionStatusActive).
-		Delete(&models.Subscription{})
+	// L'abonnement est résilié et non supprimé : les statistiques du
+	// créateur (désabonnements, durée de vie) en ont besoin. Un abonnement
+	// suspendu (créateur banni) peut aussi être résilié.
+	now := time.Now()
+	result := database.DB.Model(&models.Subscription{}).
+		Where("subscriber_id = ? AND creator_id = ? AND ((status = ? AND end_date > ?) OR status = ?)",
+			subscriberID, creatorID, models.SubscriptionStatusActive, now, models.SubscriptionStatusPaused).
+		Updates(map[string]interface{}{
+			"status":      models.SubscriptionStatusCanceled,
+			"end_date":    now,
+			"canceled_at": now,
+			"paused_at":   nil,
+		})
 
 	if result.Error != nil {
 		logger.LogError(result.Error, "unsubscription_failed", map[string]interface{}{
@@ -153,6 +185,8 @@ func (s *SubscriptionService) Unsubscribe(subscriberID, creatorID uuid.UUID) err
 		return errors.New("aucun abonnement actif trouvé")
 	}
 
+	subscriptionsCanceled.Add(float64(result.RowsAffected))
+
 	logger.LogBusinessEvent("unsubscription_success", map[string]interface{}{
 		"subscriber_id": subscriberID.String(),
 		"creator_id":    creatorID.String(),
@@ -244,16 +278,21 @@ func (s *SubscriptionService) GetUserSubscriptions(userID uuid.UUID) ([]models.S
 	return subscriptions, err
 }
 
-// GetCreatorStats retourne les statistiques d'un créateur
+// GetCreatorStats retourne les statistiques d'un créateur : abonnés en
+// cours et revenu mensuel récurrent réel (somme des prix des abonnements).
+// Le détail par période est servi par CreatorAnalyticsService.
 func (s *SubscriptionService) GetCreatorStats(creatorID uuid.UUID) (map[string]interface{}, error) {
-	var activeSubscriptions int64
-	var totalRevenue int64
+	var current struct {
+		Active   int64
+		MRRCents int64
+	}
 	now := time.Now()
 
 	err := database.DB.Model(&models.Subscription{}).
+		Select("COUNT(*) AS active, COALESCE(SUM(price), 0) AS mrr_cents").
 		Where("creator_id = ? AND status = ? AND start_date <= ? AND end_date > ?",
 			creatorID, models.SubscriptionStatusActive, now, now).
-		Count(&activeSubscriptions).Error
+		Scan(&current).Error
 
 	if err != nil {
 		logger.LogError(err, "get_creator_stats_error", map[string]interface{}{
@@ -262,18 +301,18 @@ func (s *SubscriptionService) GetCreatorStats(creatorID uuid.UUID) (map[string]i
 		return nil, err
 	}
 
-	totalRevenue = activeSubscriptions * 30
+	monthlyRevenue := float64(current.MRRCents) / 100
 
 	stats := map[string]interface{}{
-		"active_subscriptions": activeSubscriptions,
-		"monthly_revenue":      totalRevenue,
+		"active_subscriptions": current.Active,
+		"monthly_revenue":      monthlyRevenue,
 		"currency":             "EUR",
 	}
 
 	logger.LogBusinessEvent("creator_stats_retrieved", map[string]interface{}{
 		"creator_id":           creatorID.String(),
-		"active_subscriptions": activeSubscriptions,
-		"monthly_revenue":      totalRevenue,
+		"active_subscriptions": current.Active,
+		"monthly_revenue":      monthlyRevenue,
 	})
 
 	return stats, nil
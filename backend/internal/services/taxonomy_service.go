@@ -0,0 +1,328 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+const (
+	MaxTagsPerContent       = 10
+	maxTagLength            = 32
+	minTagLength            = 2
+	tagAutocompleteLimit    = 10
+	maxCollectionItems      = 500
+	defaultTaxonomyPageSize = 20
+	maxTaxonomyPageSize     = 100
+)
+
+var (
+	ErrContentNotFound    = errors.New("contenu non trouvé")
+	ErrTaxonomyForbidden  = errors.New("ce contenu ou cette collection ne vous appartient pas")
+	ErrInvalidTag         = errors.New("tag invalide")
+	ErrTooManyTags        = fmt.Errorf("%d tags maximum par contenu", MaxTagsPerContent)
+	ErrCategoryNotFound   = errors.New("catégorie introuvable")
+	ErrInvalidCategory    = errors.New("slug et nom de catégorie requis")
+	ErrCollectionNotFound = errors.New("collection introuvable")
+	ErrInvalidCollection  = errors.New("collection invalide")
+)
+
+// NormalizeTag met un tag sous forme canonique : minuscules, sans "#",
+// espaces et underscores remplacés par des tirets, lettres/chiffres/tirets
+// uniquement.
+func NormalizeTag(raw string) (string, error) {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), "#")
+	var b strings.Builder
+	lastDash := true
+	for _, r := range strings.ToLower(raw) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			lastDash = false
+		case r == '-' || r == '_' || unicode.IsSpace(r):
+			if !lastDash {
+				b.WriteRune('-')
+				lastDash = true
+			}
+		}
+	}
+	tag := strings.TrimRight(b.String(), "-")
+	if n := len([]rune(tag)); n < minTagLength || n > maxTagLength {
+		return "", fmt.Errorf("%w: %q (%d à %d caractères)", ErrInvalidTag, raw, minTagLength, maxTagLength)
+	}
+	return tag, nil
+}
+
+// NormalizeTags normalise et dédoublonne une liste de tags.
+func NormalizeTags(raw []string) ([]string, error) {
+	seen := make(map[string]bool, len(raw))
+	out := make([]string, 0, len(raw))
+	for _, r := range raw {
+		tag, err := NormalizeTag(r)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		out = append(out, tag)
+	}
+	if len(out) > MaxTagsPerContent {
+		return nil, ErrTooManyTags
+	}
+	return out, nil
+}
+
+// TaxonomyService gère les tags, les catégories et les collections.
+type TaxonomyService struct {
+	tags        *repositories.TagRepository
+	categories  *repositories.CategoryRepository
+	collections *repositories.CollectionRepository
+	contents    *repositories.ContentRepository
+}
+
+func NewTaxonomyService(
+	tags *repositories.TagRepository,
+	categories *repositories.CategoryRepository,
+	collections *repositories.CollectionRepository,
+	contents *repositories.ContentRepository,
+) *TaxonomyService {
+	return &TaxonomyService{
+		tags:        tags,
+		categories:  categories,
+		collections: collections,
+		contents:    contents,
+	}
+}
+
+// Pagination borne limit/offset pour les listes publiques.
+func Pagination(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultTaxonomyPageSize
+	}
+	if limit > maxTaxonomyPageSize {
+		limit = maxTaxonomyPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+func (s *TaxonomyService) ownedContent(requesterID, contentID uuid.UUID) (*models.Content, error) {
+	content, err := s.contents.FindByID(contentID)
+	if err != nil {
+		return nil, ErrContentNotFound
+	}
+	if content.CreatorID != requesterID {
+		return nil, ErrTaxonomyForbidden
+	}
+	return content, nil
+}
+
+// SetContentTags remplace les tags d'un contenu du créateur.
+func (s *TaxonomyService) SetContentTags(requesterID, contentID uuid.UUID, raw []string) ([]string, error) {
+	if _, err := s.ownedContent(requesterID, contentID); err != nil {
+		return nil, err
+	}
+	names, err := NormalizeTags(raw)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.tags.SetContentTags(contentID, names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// AutocompleteTags propose les tags existants commençant par q.
+func (s *TaxonomyService) AutocompleteTags(q string) ([]repositories.TagCount, error) {
+	prefix, err := NormalizeTag(q)
+	if err != nil {
+		// Préfixe trop court : une seule lettre reste une recherche valide.
+		prefix = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(q), "#"))
+		if prefix == "" || strings.ContainsAny(prefix, "%_") {
+			return []repositories.TagCount{}, nil
+		}
+	}
+	return s.tags.Autocomplete(prefix, tagAutocompleteLimit)
+}
+
+// ContentsByTag renvoie les contenus publiés portant un tag.
+func (s *TaxonomyService) ContentsByTag(tag string, limit, offset int, includeMature bool) ([]models.Content, error) {
+	name, err := NormalizeTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	limit, offset = Pagination(limit, offset)
+	return s.tags.FindPublishedByTag(name, limit, offset, includeMature)
+}
+
+// TagNames renvoie les tags de plusieurs contenus.
+func (s *TaxonomyService) TagNames(contentIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	return s.tags.NamesByContents(contentIDs)
+}
+
+// ListCategories renvoie la taxonomie.
+func (s *TaxonomyService) ListCategories() ([]models.Category, error) {
+	return s.categories.FindAll()
+}
+
+// SaveCategory crée ou met à jour une catégorie (admin).
+func (s *TaxonomyService) SaveCategory(cat *models.Category) error {
+	slug, err := NormalizeTag(cat.Slug)
+	if err != nil || strings.TrimSpace(cat.Name) == "" {
+		return ErrInvalidCategory
+	}
+	cat.Slug = slug
+	cat.Name = strings.TrimSpace(cat.Name)
+	if cat.ID == uuid.Nil {
+		return s.categories.Create(cat)
+	}
+	return s.categories.Update(cat)
+}
+
+// GetCategory renvoie une catégorie par ID.
+func (s *TaxonomyService) GetCategory(id uuid.UUID) (*models.Category, error) {
+	cat, err := s.categories.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if cat == nil {
+		return nil, ErrCategoryNotFound
+	}
+	return cat, nil
+}
+
+// DeleteCategory supprime une catégorie (admin).
+func (s *TaxonomyService) DeleteCategory(id uuid.UUID) error {
+	return s.categories.Delete(id)
+}
+
+// SetContentCategory classe un contenu du créateur ; un slug vide retire la catégorie.
+func (s *TaxonomyService) SetContentCategory(requesterID, contentID uuid.UUID, slug string) (*models.Category, error) {
+	content, err := s.ownedContent(requesterID, contentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var cat *models.Category
+	if slug != "" {
+		cat, err = s.categories.FindBySlug(slug)
+		if err != nil {
+			return nil, err
+		}
+		if cat == nil {
+			return nil, ErrCategoryNotFound
+		}
+		content.CategoryID = &cat.ID
+	} else {
+		content.CategoryID = nil
+	}
+	return cat, s.contents.Update(content)
+}
+
+// ContentsByCategory renvoie les contenus publiés d'une catégorie.
+func (s *TaxonomyService) ContentsByCategory(slug string, limit, offset int, includeMature bool) ([]models.Content, error) {
+	cat, err := s.categories.FindBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	if cat == nil {
+		return nil, ErrCategoryNotFound
+	}
+	limit, offset = Pagination(limit, offset)
+	return s.categories.FindPublishedByCategory(cat.ID, limit, offset, includeMature)
+}
+
+// SaveCollection crée une collection, ou modifie celle du créateur.
+func (s *TaxonomyService) SaveCollection(requesterID uuid.UUID, col *models.Collection) error {
+	col.Title = strings.TrimSpace(col.Title)
+	if col.Title == "" {
+		return fmt.Errorf("%w: titre requis", ErrInvalidCollection)
+	}
+	if col.ID == uuid.Nil {
+		col.CreatorID = requesterID
+		return s.collections.Create(col)
+	}
+	existing, err := s.ownedCollection(requesterID, col.ID)
+	if err != nil {
+		return err
+	}
+	existing.Title = col.Title
+	existing.Description = col.Description
+	if err := s.collections.Update(existing); err != nil {
+		return err
+	}
+	*col = *existing
+	return nil
+}
+
+// DeleteCollection supprime une collection du créateur.
+func (s *TaxonomyService) DeleteCollection(requesterID, id uuid.UUID) error {
+	if _, err := s.ownedCollection(requesterID, id); err != nil {
+		return err
+	}
+	return s.collections.Delete(id)
+}
+
+// SetCollectionItems fixe les contenus d'une collection et leur ordre. Seuls
+// les contenus du créateur peuvent y figurer.
+func (s *TaxonomyService) SetCollectionItems(requesterID, id uuid.UUID, contentIDs []uuid.UUID) error {
+	if _, err := s.ownedCollection(requesterID, id); err != nil {
+		return err
+	}
+	if len(contentIDs) > maxCollectionItems {
+		return fmt.Errorf("%w: %d contenus maximum", ErrInvalidCollection, maxCollectionItems)
+	}
+	seen := make(map[uuid.UUID]bool, len(contentIDs))
+	for _, contentID := range contentIDs {
+		if seen[contentID] {
+			return fmt.Errorf("%w: contenu %s en double", ErrInvalidCollection, contentID)
+		}
+		seen[contentID] = true
+		if _, err := s.ownedContent(requesterID, contentID); err != nil {
+			return err
+		}
+	}
+	return s.collections.SetItems(id, contentIDs)
+}
+
+// GetCollection renvoie une collection et ses contenus ordonnés. Le créateur
+// voit aussi ses contenus non publiés et adultes ; les autres ne reçoivent
+// les contenus adultes que si includeMature.
+func (s *TaxonomyService) GetCollection(requesterID uuid.UUID, id uuid.UUID, includeMature bool) (*models.Collection, []models.Content, error) {
+	col, err := s.collections.FindByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if col == nil {
+		return nil, nil, ErrCollectionNotFound
+	}
+	contents, err := s.collections.FindContents(id, requesterID != col.CreatorID, includeMature)
+	if err != nil {
+		return nil, nil, err
+	}
+	return col, contents, nil
+}
+
+func (s *TaxonomyService) ownedCollection(requesterID, id uuid.UUID) (*models.Collection, error) {
+	col, err := s.collections.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if col == nil {
+		return nil, ErrCollectionNotFound
+	}
+	if col.CreatorID != requesterID {
+		return nil, ErrTaxonomyForbidden
+	}
+	return col, nil
+}
@@ -0,0 +1,100 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := services.NormalizeTags([]string{"#Aquarelle", "  Art  Numérique ", "aquarelle", "low_poly"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aquarelle", "art-numérique", "low-poly"}, tags)
+
+	_, err = services.NormalizeTags([]string{"x"})
+	assert.True(t, errors.Is(err, services.ErrInvalidTag))
+
+	many := make([]string, services.MaxTagsPerContent+1)
+	for i := range many {
+		many[i] = fmt.Sprintf("tag%d", i)
+	}
+	_, err = services.NormalizeTags(many)
+	assert.True(t, errors.Is(err, services.ErrTooManyTags))
+}
+
+func setupTaxonomy(t *testing.T) (*services.TaxonomyService, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		NamingStrategy: schema.NamingStrategy{SingularTable: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, database.MigrateSQLite(db,
+		&models.User{}, &models.Content{}, &models.Tag{}, &models.ContentTag{},
+		&models.Category{}, &models.Collection{}, &models.CollectionItem{},
+	))
+	repositories.SetTestDB(db)
+
+	svc := services.NewTaxonomyService(
+		repositories.NewTagRepository(),
+		repositories.NewCategoryRepository(),
+		repositories.NewCollectionRepository(),
+		repositories.NewContentRepository(),
+	)
+	return svc, db
+}
+
+func TestTaxonomy_TagsAndCollections(t *testing.T) {
+	svc, db := setupTaxonomy(t)
+	creatorID := uuid.New()
+	other := uuid.New()
+
+	published := &models.Content{CreatorID: creatorID, Title: "A", Body: "a", Price: 1, FilePath: "a.jpg", Status: models.ContentStatusApproved}
+	draft := &models.Content{CreatorID: creatorID, Title: "B", Body: "b", Price: 1, FilePath: "b.jpg", Status: models.ContentStatusDraft}
+	require.NoError(t, db.Create(published).Error)
+	require.NoError(t, db.Create(draft).Error)
+
+	_, err := svc.SetContentTags(other, published.ID, []string{"paysage"})
+	assert.True(t, errors.Is(err, services.ErrTaxonomyForbidden))
+
+	_, err = svc.SetContentTags(creatorID, published.ID, []string{"Paysage", "pastel"})
+	require.NoError(t, err)
+	_, err = svc.SetContentTags(creatorID, draft.ID, []string{"paysage"})
+	require.NoError(t, err)
+
+	suggestions, err := svc.AutocompleteTags("pa")
+	require.NoError(t, err)
+	require.Len(t, suggestions, 2)
+	// Le brouillon n'est pas compté : égalité, tri alphabétique.
+	assert.Equal(t, "pastel", suggestions[0].Name)
+	assert.Equal(t, int64(1), suggestions[1].Count)
+
+	byTag, err := svc.ContentsByTag("#Paysage", 0, 0, false)
+	require.NoError(t, err)
+	require.Len(t, byTag, 1)
+	assert.Equal(t, published.ID, byTag[0].ID)
+
+	col := &models.Collection{Title: "Série"}
+	require.NoError(t, svc.SaveCollection(creatorID, col))
+	require.NoError(t, svc.SetCollectionItems(creatorID, col.ID, []uuid.UUID{draft.ID, published.ID}))
+
+	_, contents, err := svc.GetCollection(creatorID, col.ID, false)
+	require.NoError(t, err)
+	require.Len(t, contents, 2)
+	assert.Equal(t, draft.ID, contents[0].ID)
+
+	_, contents, err = svc.GetCollection(uuid.Nil, col.ID, false)
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	assert.Equal(t, published.ID, contents[0].ID)
+}
@@ -0,0 +1,116 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+// testStore est une base SQLite en mémoire, aux tables nommées comme en
+// production, branchée sur les dépôts. Ses méthodes créent les lignes dont
+// les tests ont besoin.
+type testStore struct {
+	tb testing.TB
+	db *gorm.DB
+}
+
+// newTestStore ouvre une base nommée et partagée entre les connexions (les
+// traitements en tâche de fond et les requêtes parallèles voient les mêmes
+// tables) et crée les tables des modèles donnés.
+func newTestStore(tb testing.TB, tables ...interface{}) *testStore {
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{
+		NamingStrategy: schema.NamingStrategy{SingularTable: true},
+	})
+	require.NoError(tb, err)
+	sqlDB, err := db.DB()
+	require.NoError(tb, err)
+	tb.Cleanup(func() { sqlDB.Close() })
+	require.NoError(tb, database.MigrateSQLite(db, tables...))
+	repositories.SetTestDB(db)
+	return &testStore{tb: tb, db: db}
+}
+
+// create enregistre des lignes quelconques.
+func (s *testStore) create(rows ...interface{}) {
+	s.tb.Helper()
+	for _, row := range rows {
+		require.NoError(s.tb, s.db.Create(row).Error)
+	}
+}
+
+// day renvoie minuit (UTC) du jour donné de 2026.
+func day(month time.Month, d int) time.Time {
+	return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// joined fixe la date d'inscription d'un utilisateur.
+func joined(at time.Time) func(*models.User) {
+	return func(u *models.User) { u.CreatedAt = at }
+}
+
+// user crée un utilisateur <name>@test ; edit ajuste les autres champs.
+func (s *testStore) user(name string, role models.Role, edit ...func(*models.User)) *models.User {
+	s.tb.Helper()
+	u := &models.User{Username: name, Email: name + "@test", HashedPassword: "x", Role: role}
+	for _, e := range edit {
+		e(u)
+	}
+	s.create(u)
+	return u
+}
+
+// priced fixe le prix d'un contenu.
+func priced(price int) func(*models.Content) {
+	return func(c *models.Content) { c.Price = price }
+}
+
+// content crée un contenu à 3 € publié à at (maintenant si at est nul) ;
+// edit ajuste les autres champs.
+func (s *testStore) content(creator *models.User, title, status string, at time.Time, edit ...func(*models.Content)) *models.Content {
+	s.tb.Helper()
+	c := &models.Content{CreatorID: creator.ID, Title: title, Body: "b", Price: 3, FilePath: "x.jpg",
+		Status: status, CreatedAt: at}
+	for _, e := range edit {
+		e(c)
+	}
+	s.create(c)
+	return c
+}
+
+// like enregistre le like de user sur c à at.
+func (s *testStore) like(c *models.Content, user *models.User, at time.Time) {
+	s.tb.Helper()
+	s.create(&models.Like{ContentID: c.ID, UserID: user.ID, CreatedAt: at})
+}
+
+// subscription crée l'abonnement de fan à creator de start à end.
+func (s *testStore) subscription(fan, creator *models.User, start, end time.Time, status string) *models.Subscription {
+	s.tb.Helper()
+	sub := &models.Subscription{CreatorID: creator.ID, SubscriberID: fan.ID, StartDate: start, EndDate: end,
+		PaymentID: uuid.New(), Price: models.SubscriptionPriceCents, Status: status}
+	s.create(sub)
+	return sub
+}
+
+// payment enregistre le paiement de sub à at.
+func (s *testStore) payment(sub *models.Subscription, at time.Time, status models.PaymentStatus) {
+	s.tb.Helper()
+	s.create(&models.Payment{SubscriptionID: sub.ID, Amount: models.SubscriptionPriceCents, PaidAt: at, Status: status})
+}
+
+// paid crée un abonnement actif de 30 jours à partir de at et son paiement.
+func (s *testStore) paid(fan, creator *models.User, at time.Time, status models.PaymentStatus) *models.Subscription {
+	s.tb.Helper()
+	sub := s.subscription(fan, creator, at, at.AddDate(0, 0, 30), models.SubscriptionStatusActive)
+	s.payment(sub, at, status)
+	return sub
+}
@@ -0,0 +1,287 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+)
+
+// TextInput est un texte soumis à la modération automatique.
+type TextInput struct {
+	Kind     string
+	AuthorID uuid.UUID
+	Text     string
+}
+
+// TextRating est l'évaluation d'un texte : le verdict le plus sévère rendu
+// par les règles et les raisons qui l'ont motivé.
+type TextRating struct {
+	Verdict string   `json:"verdict"`
+	Reasons []string `json:"reasons,omitempty"`
+	Score   float64  `json:"score"`
+}
+
+// Visibility renvoie le statut sous lequel enregistrer un texte accepté.
+func (r TextRating) Visibility() string {
+	if r.Verdict == models.TextVerdictHold {
+		return models.TextStatusHeld
+	}
+	return models.TextStatusVisible
+}
+
+func (r *TextRating) merge(other TextRating) {
+	if verdictSeverity(other.Verdict) > verdictSeverity(r.Verdict) {
+		r.Verdict = other.Verdict
+	}
+	r.Reasons = append(r.Reasons, other.Reasons...)
+	if other.Score > r.Score {
+		r.Score = other.Score
+	}
+}
+
+func verdictSeverity(verdict string) int {
+	switch verdict {
+	case models.TextVerdictBlock:
+		return 2
+	case models.TextVerdictHold:
+		return 1
+	}
+	return 0
+}
+
+func allowText() TextRating {
+	return TextRating{Verdict: models.TextVerdictAllow}
+}
+
+func rateText(verdict, reason string) TextRating {
+	return TextRating{Verdict: verdict, Reasons: []string{reason}}
+}
+
+// TextRule est un filtre de la chaîne de modération des textes.
+type TextRule interface {
+	Rate(in TextInput) TextRating
+}
+
+// TextClassifier estime la probabilité (0..1) qu'un texte soit abusif. Il
+// permet de brancher un modèle de classification local.
+type TextClassifier interface {
+	Classify(text string) (float64, error)
+}
+
+// TextModerator enchaîne les règles puis le classifieur éventuel ; le
+// verdict le plus sévère l'emporte.
+type TextModerator struct {
+	rules      []TextRule
+	classifier TextClassifier
+	holdScore  float64
+	blockScore float64
+}
+
+func NewTextModerator(rules ...TextRule) *TextModerator {
+	return &TextModerator{rules: rules}
+}
+
+// WithClassifier ajoute un classifieur : un score supérieur à holdScore met
+// le texte en attente, supérieur à blockScore le bloque.
+func (m *TextModerator) WithClassifier(c TextClassifier, holdScore, blockScore float64) *TextModerator {
+	m.classifier, m.holdScore, m.blockScore = c, holdScore, blockScore
+	return m
+}
+
+// Rate évalue un texte. Sans modérateur configuré, tout texte est accepté.
+func (m *TextModerator) Rate(in TextInput) TextRating {
+	rating := allowText()
+	if m == nil {
+		return rating
+	}
+	for _, rule := range m.rules {
+		rating.merge(rule.Rate(in))
+	}
+	if m.classifier != nil {
+		score, err := m.classifier.Classify(in.Text)
+		if err != nil {
+			log.Printf("⚠️ Classification de texte impossible: %v", err)
+			return rating
+		}
+		switch {
+		case score >= m.blockScore:
+			rating.merge(TextRating{Verdict: models.TextVerdictBlock, Reasons: []string{"classifier"}, Score: score})
+		case score >= m.holdScore:
+			rating.merge(TextRating{Verdict: models.TextVerdictHold, Reasons: []string{"classifier"}, Score: score})
+		default:
+			rating.merge(TextRating{Verdict: models.TextVerdictAllow, Score: score})
+		}
+	}
+	return rating
+}
+
+// BlocklistRule bloque ou retient les textes contenant des termes interdits.
+// Un terme préfixé par "re:" est une expression régulière, sinon il doit
+// apparaître comme mot entier, sans tenir compte de la casse.
+type BlocklistRule struct {
+	block []*regexp.Regexp
+	hold  []*regexp.Regexp
+}
+
+func NewBlocklistRule(block, hold []string) (*BlocklistRule, error) {
+	b, err := compileTerms(block)
+	if err != nil {
+		return nil, err
+	}
+	h, err := compileTerms(hold)
+	if err != nil {
+		return nil, err
+	}
+	return &BlocklistRule{block: b, hold: h}, nil
+}
+
+func compileTerms(terms []string) ([]*regexp.Regexp, error) {
+	out := make([]*regexp.Regexp, 0, len(terms))
+	for _, term := range terms {
+		pattern := `(?i)(?:^|[^\p{L}\p{N}])` + regexp.QuoteMeta(term) + `(?:$|[^\p{L}\p{N}])`
+		if expr, ok := strings.CutPrefix(term, "re:"); ok {
+			pattern = "(?i)" + expr
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("terme de modération %q invalide: %w", term, err)
+		}
+		out = append(out, re)
+	}
+	return out, nil
+}
+
+func (r *BlocklistRule) Rate(in TextInput) TextRating {
+	for _, re := range r.block {
+		if re.MatchString(in.Text) {
+			return rateText(models.TextVerdictBlock, "blocklist")
+		}
+	}
+	for _, re := range r.hold {
+		if re.MatchString(in.Text) {
+			return rateText(models.TextVerdictHold, "watchlist")
+		}
+	}
+	return allowText()
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://|\bwww\.|\b[a-z0-9-]+\.(?:com|net|org|io|ru|xyz|top|link|click)\b`)
+
+// Seuils des heuristiques anti-spam.
+const (
+	shoutingMinLetters = 20
+	shoutingRatio      = 0.8
+	floodRunLength     = 12
+)
+
+// SpamRule retient les textes chargés en liens, écrits en majuscules ou
+// saturés de caractères répétés.
+type SpamRule struct {
+	MaxLinks int
+}
+
+func (r SpamRule) Rate(in TextInput) TextRating {
+	rating := allowText()
+	if links := linkPattern.FindAllStringIndex(in.Text, -1); len(links) > r.MaxLinks {
+		rating.merge(rateText(models.TextVerdictHold, "links"))
+	}
+
+	letters, upper, run, longest := 0, 0, 0, 0
+	var prev rune
+	for _, ch := range in.Text {
+		if unicode.IsLetter(ch) {
+			letters++
+			if unicode.IsUpper(ch) {
+				upper++
+			}
+		}
+		if ch == prev && !unicode.IsSpace(ch) {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+		prev = ch
+	}
+	if letters >= shoutingMinLetters && float64(upper) > shoutingRatio*float64(letters) {
+		rating.merge(rateText(models.TextVerdictHold, "shouting"))
+	}
+	if longest >= floodRunLength {
+		rating.merge(rateText(models.TextVerdictHold, "flood"))
+	}
+	return rating
+}
+
+// Bornes de la mémoire de RepeatRule : textes retenus par auteur, et nombre
+// d'auteurs au-delà duquel les auteurs inactifs sont oubliés.
+const (
+	repeatMaxPerAuthor = 50
+	repeatMaxAuthors   = 10000
+)
+
+type repeatSeen struct {
+	text string
+	at   time.Time
+}
+
+// RepeatRule détecte un même texte envoyé plusieurs fois par un auteur dans
+// la fenêtre : au-delà de Limit il est retenu, au-delà du double bloqué.
+type RepeatRule struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mu   sync.Mutex
+	seen map[uuid.UUID][]repeatSeen
+}
+
+func NewRepeatRule(limit int, window time.Duration) *RepeatRule {
+	return &RepeatRule{limit: limit, window: window, now: time.Now, seen: map[uuid.UUID][]repeatSeen{}}
+}
+
+func (r *RepeatRule) Rate(in TextInput) TextRating {
+	key := strings.Join(strings.Fields(strings.ToLower(in.Text)), " ")
+	now := r.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.seen) > repeatMaxAuthors {
+		for author, entries := range r.seen {
+			if now.Sub(entries[len(entries)-1].at) > r.window {
+				delete(r.seen, author)
+			}
+		}
+	}
+
+	kept := r.seen[in.AuthorID][:0]
+	count := 0
+	for _, e := range r.seen[in.AuthorID] {
+		if now.Sub(e.at) > r.window {
+			continue
+		}
+		kept = append(kept, e)
+		if e.text == key {
+			count++
+		}
+	}
+	if len(kept) >= repeatMaxPerAuthor {
+		kept = kept[1:]
+	}
+	r.seen[in.AuthorID] = append(kept, repeatSeen{text: key, at: now})
+
+	switch {
+	case count >= 2*r.limit:
+		return rateText(models.TextVerdictBlock, "repeated")
+	case count >= r.limit:
+		return rateText(models.TextVerdictHold, "repeated")
+	}
+	return allowText()
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var (
+	ErrEmptyText          = errors.New("le texte ne peut pas être vide")
+	ErrTextTooLong        = errors.New("le texte est trop long")
+	ErrTextBlocked        = errors.New("ce texte enfreint les règles de la communauté")
+	ErrTextReviewNotFound = errors.New("texte à modérer non trouvé")
+	ErrTextReviewClosed   = errors.New("ce texte a déjà été modéré")
+)
+
+const (
+	// maxTextLength borne les commentaires et les messages.
+	maxTextLength = 5000
+	// textReviewExcerpt est la longueur de l'extrait conservé dans la file.
+	textReviewExcerpt = 1000
+	textRepeatWindow  = 10 * time.Minute
+)
+
+// TextModerationService filtre les textes publiés (commentaires, messages,
+// descriptions de contenus) et gère la file des textes retenus.
+type TextModerationService struct {
+	moderator     *TextModerator
+	reviews       *repositories.TextReviewRepository
+	comments      *repositories.CommentRepository
+	messages      *repositories.MessageRepository
+	moderation    *ModerationService
+	notifications *NotificationService
+	now           func() time.Time
+}
+
+func NewTextModerationService(
+	moderator *TextModerator,
+	reviews *repositories.TextReviewRepository,
+	comments *repositories.CommentRepository,
+	messages *repositories.MessageRepository,
+	moderation *ModerationService,
+	notifications *NotificationService,
+) *TextModerationService {
+	return &TextModerationService{
+		moderator:     moderator,
+		reviews:       reviews,
+		comments:      comments,
+		messages:      messages,
+		moderation:    moderation,
+		notifications: notifications,
+		now:           time.Now,
+	}
+}
+
+// NewDefaultTextModerator assemble les règles configurées : liste de termes,
+// heuristiques anti-spam et détection des répétitions.
+func NewDefaultTextModerator(block, hold []string, maxLinks, repeatLimit int) (*TextModerator, error) {
+	blocklist, err := NewBlocklistRule(block, hold)
+	if err != nil {
+		return nil, err
+	}
+	return NewTextModerator(blocklist, SpamRule{MaxLinks: maxLinks}, NewRepeatRule(repeatLimit, textRepeatWindow)), nil
+}
+
+// Screen nettoie et évalue un texte avant son enregistrement. Un texte vide
+// ou trop long est refusé, un texte bloqué renvoie ErrTextBlocked. Sans
+// service configuré, seules ces vérifications de forme s'appliquent.
+func (s *TextModerationService) Screen(kind string, authorID uuid.UUID, text string) (string, TextRating, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", allowText(), ErrEmptyText
+	}
+	if kind != models.TextTargetContent && utf8.RuneCountInString(text) > maxTextLength {
+		return "", allowText(), ErrTextTooLong
+	}
+	if s == nil {
+		return text, allowText(), nil
+	}
+
+	rating := s.moderator.Rate(TextInput{Kind: kind, AuthorID: authorID, Text: text})
+	if rating.Verdict == models.TextVerdictBlock {
+		log.Printf("🚫 Texte (%s) de %s bloqué : %s", kind, authorID, strings.Join(rating.Reasons, ", "))
+		logger.LogSecurity("text_blocked", map[string]interface{}{
+			"kind":      kind,
+			"author_id": authorID.String(),
+			"reasons":   rating.Reasons,
+		})
+		return text, rating, ErrTextBlocked
+	}
+	return text, rating, nil
+}
+
+// Hold place un texte retenu dans la file de modération. Sans effet si le
+// verdict n'est pas une mise en attente.
+func (s *TextModerationService) Hold(kind string, targetID, authorID uuid.UUID, text string, rating TextRating) error {
+	if err := repositories.InTransaction(s.HoldStep(kind, targetID, authorID, text, rating)); err != nil {
+		return fmt.Errorf("file de modération: %w", err)
+	}
+	return nil
+}
+
+// HoldStep prépare la mise en file d'un texte retenu, à exécuter dans la
+// transaction qui enregistre le texte : un texte masqué n'existe jamais sans
+// son entrée dans la file. Renvoie nil si le texte n'est pas retenu.
+func (s *TextModerationService) HoldStep(kind string, targetID, authorID uuid.UUID, text string, rating TextRating) repositories.TxStep {
+	if s == nil || rating.Verdict != models.TextVerdictHold {
+		return nil
+	}
+	excerpt := text
+	if utf8.RuneCountInString(excerpt) > textReviewExcerpt {
+		excerpt = string([]rune(excerpt)[:textReviewExcerpt]) + "…"
+	}
+	review := &models.TextReview{
+		TargetType: kind,
+		TargetID:   targetID,
+		AuthorID:   authorID,
+		Excerpt:    excerpt,
+		Reasons:    strings.Join(rating.Reasons, ","),
+		Score:      rating.Score,
+		Status:     models.TextReviewPending,
+	}
+	log.Printf("⏸️ Texte (%s) %s de %s retenu : %s", kind, targetID, authorID, review.Reasons)
+	return s.reviews.CreateStep(review)
+}
+
+// Queue liste la file de modération des textes.
+func (s *TextModerationService) Queue(status, kind string, limit, offset int) ([]models.TextReview, int64, error) {
+	limit, offset = Pagination(limit, offset)
+	return s.reviews.Find(status, kind, limit, offset)
+}
+
+// Approve publie un texte retenu. Pour un contenu, l'approbation du texte
+// vaut approbation du contenu s'il attend encore la modération.
+func (s *TextModerationService) Approve(id, moderatorID uuid.UUID) (*models.TextReview, error) {
+	review, err := s.pendingReview(id)
+	if err != nil {
+		return nil, err
+	}
+	switch review.TargetType {
+	case models.TextTargetComment:
+		err = s.comments.UpdateStatus(review.TargetID, models.TextStatusVisible)
+	case models.TextTargetMessage:
+		err = s.messages.UpdateStatus(review.TargetID, models.TextStatusVisible)
+	case models.TextTargetContent:
+		// Un brouillon ou un contenu déjà tranché garde son statut : seul le
+		// texte est validé.
+		if _, err = s.moderation.Approve(review.TargetID, moderatorID, ""); errors.Is(err, ErrInvalidTransition) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.close(review, moderatorID, models.TextReviewApproved)
+}
+
+// Reject écarte un texte retenu : le commentaire ou le message reste masqué,
+// le contenu est rejeté avec le motif donné.
+func (s *TextModerationService) Reject(id, moderatorID uuid.UUID, reasonCode, note string) (*models.TextReview, error) {
+	review, err := s.pendingReview(id)
+	if err != nil {
+		return nil, err
+	}
+	switch review.TargetType {
+	case models.TextTargetComment:
+		err = s.comments.UpdateStatus(review.TargetID, models.TextStatusRemoved)
+	case models.TextTargetMessage:
+		err = s.messages.UpdateStatus(review.TargetID, models.TextStatusRemoved)
+	case models.TextTargetContent:
+		_, err = s.moderation.Reject(review.TargetID, moderatorID, reasonCode, note)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if review.TargetType != models.TextTargetContent {
+		s.notifications.Notify(review.AuthorID, models.NotificationTextRejected, nil,
+			"Votre "+textKindLabels[review.TargetType]+" a été retiré par la modération.")
+	}
+	return s.close(review, moderatorID, models.TextReviewRejected)
+}
+
+var textKindLabels = map[string]string{
+	models.TextTargetComment: "commentaire",
+	models.TextTargetMessage: "message",
+}
+
+func (s *TextModerationService) pendingReview(id uuid.UUID) (*models.TextReview, error) {
+	review, err := s.reviews.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, ErrTextReviewNotFound
+	}
+	if review.Status != models.TextReviewPending {
+		return nil, ErrTextReviewClosed
+	}
+	return review, nil
+}
+
+func (s *TextModerationService) close(review *models.TextReview, moderatorID uuid.UUID, status string) (*models.TextReview, error) {
+	now := s.now()
+	review.Status = status
+	review.ReviewedBy = &moderatorID
+	review.ReviewedAt = &now
+	if err := s.reviews.Update(review); err != nil {
+		return nil, err
+	}
+	log.Printf("🛡️ Texte (%s) %s : %s par %s", review.TargetType, review.TargetID, status, moderatorID)
+	return review, nil
+}
@@ -0,0 +1,152 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+type fixedClassifier float64
+
+func (f fixedClassifier) Classify(string) (float64, error) { return float64(f), nil }
+
+func TestTextModerator_Rules(t *testing.T) {
+	moderator, err := services.NewDefaultTextModerator([]string{"arnaque", `re:c\W*o\W*n\W*n\W*a\W*r\W*d`}, []string{"telegram"}, 1, 2)
+	require.NoError(t, err)
+	rate := func(text string) services.TextRating {
+		return moderator.Rate(services.TextInput{Kind: models.TextTargetComment, Text: text})
+	}
+
+	assert.Equal(t, models.TextVerdictAllow, rate("Superbe aquarelle, bravo !").Verdict)
+	assert.Equal(t, models.TextVerdictBlock, rate("C'est une ARNAQUE").Verdict)
+	assert.Equal(t, models.TextVerdictAllow, rate("arnaqueur").Verdict, "mot entier uniquement")
+	assert.Equal(t, models.TextVerdictBlock, rate("espèce de c.o.n.n.a.r.d").Verdict)
+	assert.Equal(t, models.TextVerdictHold, rate("écris-moi sur Telegram").Verdict)
+	assert.Equal(t, models.TextVerdictHold, rate("https://a.example et www.b.example").Verdict)
+	assert.Equal(t, models.TextVerdictHold, rate("ACHETEZ MAINTENANT CETTE OFFRE INCROYABLE").Verdict)
+	assert.Equal(t, models.TextVerdictHold, rate("waouuuuuuuuuuuuuuuuuu").Verdict)
+
+	// Répétitions : au-delà de la limite retenu, au-delà du double bloqué.
+	verdicts := []string{}
+	for range 5 {
+		verdicts = append(verdicts, rate("Suivez   mon compte").Verdict)
+	}
+	assert.Equal(t, []string{"allow", "allow", "hold", "hold", "block"}, verdicts)
+
+	moderator.WithClassifier(fixedClassifier(0.7), 0.6, 0.9)
+	rating := rate("texte neutre")
+	assert.Equal(t, models.TextVerdictHold, rating.Verdict)
+	assert.Equal(t, []string{"classifier"}, rating.Reasons)
+	assert.InDelta(t, 0.7, rating.Score, 1e-9)
+}
+
+func TestTextModeration_HeldCommentsAndMessages(t *testing.T) {
+	_, contentRepo, db := setupContentLifecycle(t)
+	require.NoError(t, database.MigrateSQLite(db, &models.Comment{}, &models.CommentLike{}, &models.Message{},
+		&models.TextReview{}, &models.Notification{}, &models.ModerationDecision{}))
+	notifications := services.NewNotificationService(repositories.NewNotificationRepository())
+	moderation := services.NewModerationService(contentRepo, repositories.NewModerationRepository(), notifications)
+	commentRepo, messageRepo := repositories.NewCommentRepository(), repositories.NewMessageRepository()
+	moderator, err := services.NewDefaultTextModerator([]string{"arnaque"}, []string{"telegram"}, 2, 3)
+	require.NoError(t, err)
+	text := services.NewTextModerationService(moderator, repositories.NewTextReviewRepository(),
+		commentRepo, messageRepo, moderation, notifications)
+
+	userRepo := repositories.NewUserRepository()
+	comments := services.NewCommentService(commentRepo, repositories.NewCommentLikeRepository(), userRepo, nil)
+	comments.SetTextModeration(text)
+	messages := services.NewMessageService(messageRepo, userRepo, nil)
+	messages.SetTextModeration(text)
+
+	author := &models.User{Username: "fan", Email: "f@test", HashedPassword: "x", Role: models.RoleSubscriber}
+	creator := &models.User{Username: "artiste", Email: "c@test", HashedPassword: "x", Role: models.RoleCreator}
+	admin := &models.User{Username: "admin", Email: "a@test", HashedPassword: "x", Role: models.RoleAdmin}
+	for _, u := range []*models.User{author, creator, admin} {
+		require.NoError(t, db.Create(u).Error)
+	}
+	content := &models.Content{CreatorID: creator.ID, Title: "Aquarelle", Body: "b", Price: 3, FilePath: "a.jpg",
+		Status: models.ContentStatusApproved}
+	require.NoError(t, db.Create(content).Error)
+
+	_, err = comments.PostComment(content.ID, author.ID, "   ", nil)
+	assert.ErrorIs(t, err, services.ErrEmptyText)
+	_, err = comments.PostComment(content.ID, author.ID, strings.Repeat("a", 5001), nil)
+	assert.ErrorIs(t, err, services.ErrTextTooLong)
+	_, err = comments.PostComment(content.ID, author.ID, "quelle arnaque", nil)
+	assert.ErrorIs(t, err, services.ErrTextBlocked)
+
+	held, err := comments.PostComment(content.ID, author.ID, " contacte-moi sur telegram ", nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.TextStatusHeld, held.Status)
+	assert.Equal(t, "contacte-moi sur telegram", held.Text)
+	_, err = comments.PostComment(content.ID, author.ID, "Très belle œuvre", nil)
+	require.NoError(t, err)
+
+	visibleTo := func(viewer *models.User) int {
+		list, err := comments.FetchComments(content.ID, viewer.ID)
+		require.NoError(t, err)
+		return len(list)
+	}
+	assert.Equal(t, 2, visibleTo(author), "l'auteur voit son commentaire retenu")
+	assert.Equal(t, 1, visibleTo(creator))
+
+	msg, err := messages.SendMessage(author.ID, creator.ID, "On continue sur Telegram ?")
+	require.NoError(t, err)
+	assert.Equal(t, models.TextStatusHeld, msg.Status)
+	conv, err := messages.GetConversation(creator.ID, author.ID)
+	require.NoError(t, err)
+	assert.Empty(t, conv, "message retenu non remis")
+
+	queue, total, err := text.Queue(models.TextReviewPending, "", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, queue, 2)
+	assert.Equal(t, "watchlist", queue[0].Reasons)
+
+	_, err = text.Approve(queue[0].ID, admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, visibleTo(creator))
+	_, err = text.Approve(queue[0].ID, admin.ID)
+	assert.ErrorIs(t, err, services.ErrTextReviewClosed)
+
+	_, err = text.Reject(queue[1].ID, admin.ID, "", "")
+	require.NoError(t, err)
+	conv, err = messages.GetConversation(author.ID, creator.ID)
+	require.NoError(t, err)
+	assert.Empty(t, conv, "message retiré invisible, même pour l'expéditeur")
+	_, unread, err := notifications.List(author.ID, true, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), unread)
+
+	// Approuver le texte d'un contenu en attente publie le contenu.
+	pending := &models.Content{CreatorID: creator.ID, Title: "Telegram", Body: "b", Price: 3, FilePath: "b.jpg",
+		Status: models.ContentStatusPending}
+	require.NoError(t, db.Create(pending).Error)
+	rating := moderator.Rate(services.TextInput{Kind: models.TextTargetContent, Text: pending.Title})
+	require.NoError(t, text.Hold(models.TextTargetContent, pending.ID, creator.ID, pending.Title, rating))
+	queue, _, err = text.Queue(models.TextReviewPending, models.TextTargetContent, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, queue, 1)
+	_, err = text.Approve(queue[0].ID, admin.ID)
+	require.NoError(t, err)
+	published, err := contentRepo.FindByID(pending.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ContentStatusApproved, published.Status)
+
+	// Sans file de modération, un texte retenu n'est pas enregistré.
+	var before int64
+	require.NoError(t, db.Model(&models.Comment{}).Count(&before).Error)
+	require.NoError(t, db.Migrator().DropTable(&models.TextReview{}))
+	_, err = comments.PostComment(content.ID, author.ID, "rejoins-moi sur telegram", nil)
+	assert.Error(t, err)
+	var after int64
+	require.NoError(t, db.Model(&models.Comment{}).Count(&after).Error)
+	assert.Equal(t, before, after)
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var ErrInvalidTrendingWindow = errors.New("fenêtre de tendance invalide (24h, 7d ou 30d)")
+
+// trendingWindows associe chaque fenêtre à sa durée ; la demi-vie de la
+// décroissance vaut le quart de la fenêtre.
+var trendingWindows = map[string]time.Duration{
+	models.TrendingWindowDay:   24 * time.Hour,
+	models.TrendingWindowWeek:  7 * 24 * time.Hour,
+	models.TrendingWindowMonth: 30 * 24 * time.Hour,
+}
+
+// Poids des événements dans le score de tendance.
+const (
+	trendingLikeWeight         = 1.0
+	trendingCommentWeight      = 2.0
+	trendingViewWeight         = 0.2
+	trendingSubscriptionWeight = 5.0
+)
+
+// trendingRetention est la profondeur des agrégats conservés : la plus grande
+// fenêtre.
+const trendingRetention = 30 * 24 * time.Hour
+
+// TrendingService agrège l'activité en tranches horaires et en tire des
+// scores de tendance à décroissance exponentielle. Les scores sont calculés
+// par une tâche de fond et seulement lus par les requêtes.
+type TrendingService struct {
+	repo *repositories.TrendingRepository
+	mu   sync.Mutex
+}
+
+func NewTrendingService(repo *repositories.TrendingRepository) *TrendingService {
+	return &TrendingService{repo: repo}
+}
+
+// StartRefresher recalcule les tendances au démarrage puis à chaque intervalle.
+func (s *TrendingService) StartRefresher(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for now := time.Now(); ; now = <-ticker.C {
+			if err := s.Refresh(now); err != nil {
+				logger.LogError(err, "trending_refresh_failed", nil)
+			}
+		}
+	}()
+}
+
+// Refresh met à jour les agrégats horaires puis les scores de chaque
+// fenêtre. Seules les heures depuis le dernier agrégat (inclus, car il
+// pouvait être incomplet) sont recalculées.
+func (s *TrendingService) Refresh(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now = now.UTC()
+	oldest := now.Add(-trendingRetention).Truncate(time.Hour)
+	from := oldest
+	last, err := s.repo.LatestBucketHour()
+	if err != nil {
+		return err
+	}
+	if last != nil && last.After(from) {
+		from = last.UTC()
+	}
+	if err := s.rebuildBuckets(from, oldest); err != nil {
+		return err
+	}
+
+	for window, span := range trendingWindows {
+		if err := s.scoreWindow(window, span, now); err != nil {
+			return err
+		}
+	}
+	log.Printf("📈 Tendances recalculées (agrégats depuis %s)", from.Format(time.RFC3339))
+	return nil
+}
+
+type bucketKey struct {
+	hour      time.Time
+	creatorID uuid.UUID
+	contentID uuid.UUID
+}
+
+func (s *TrendingService) rebuildBuckets(from, purgeBefore time.Time) error {
+	buckets := map[bucketKey]*models.ActivityBucket{}
+	err := s.repo.EachActivityEvent(from, func(ev repositories.ActivityEvent) {
+		key := bucketKey{hour: ev.At.UTC().Truncate(time.Hour), creatorID: ev.CreatorID}
+		if ev.ContentID.Valid {
+			key.contentID = ev.ContentID.UUID
+		}
+		b := buckets[key]
+		if b == nil {
+			b = &models.ActivityBucket{Hour: key.hour, CreatorID: key.creatorID, ContentID: key.contentID}
+			buckets[key] = b
+		}
+		switch ev.Kind {
+		case repositories.ActivityLike:
+			b.Likes++
+		case repositories.ActivityComment:
+			b.Comments++
+		case repositories.ActivityView:
+			b.Views++
+		case repositories.ActivitySubscription:
+			b.Subscriptions++
+		}
+	})
+	if err != nil {
+		return err
+	}
+	list := make([]models.ActivityBucket, 0, len(buckets))
+	for _, b := range buckets {
+		list = append(list, *b)
+	}
+	return s.repo.ReplaceBuckets(from, purgeBefore, list)
+}
+
+// scoreWindow note chaque contenu et chaque créateur actif sur la fenêtre :
+// chaque tranche horaire compte pour la somme pondérée de ses événements,
+// divisée par deux toutes les demi-vies écoulées depuis le milieu de l'heure.
+func (s *TrendingService) scoreWindow(window string, span time.Duration, now time.Time) error {
+	halfLife := span / 4
+	contents := map[uuid.UUID]*models.TrendingScore{}
+	creators := map[uuid.UUID]*models.TrendingScore{}
+	score := func(scores map[uuid.UUID]*models.TrendingScore, kind string, id uuid.UUID, b models.ActivityBucket, value float64) {
+		ts := scores[id]
+		if ts == nil {
+			ts = &models.TrendingScore{Window: window, TargetType: kind, TargetID: id, ComputedAt: now}
+			scores[id] = ts
+		}
+		ts.Score += value
+		ts.Likes += b.Likes
+		ts.Comments += b.Comments
+		ts.Views += b.Views
+		ts.Subscriptions += b.Subscriptions
+	}
+
+	err := s.repo.EachBucketSince(now.Add(-span).Truncate(time.Hour), func(b models.ActivityBucket) {
+		age := max(now.Sub(b.Hour.Add(30*time.Minute)), 0)
+		decay := math.Pow(0.5, float64(age)/float64(halfLife))
+		value := decay * (trendingLikeWeight*float64(b.Likes) +
+			trendingCommentWeight*float64(b.Comments) +
+			trendingViewWeight*float64(b.Views) +
+			trendingSubscriptionWeight*float64(b.Subscriptions))
+		if b.ContentID != uuid.Nil {
+			score(contents, models.TrendingTargetContent, b.ContentID, b, value)
+		}
+		score(creators, models.TrendingTargetCreator, b.CreatorID, b, value)
+	})
+	if err != nil {
+		return err
+	}
+
+	list := make([]models.TrendingScore, 0, len(contents)+len(creators))
+	for _, ts := range contents {
+		list = append(list, *ts)
+	}
+	for _, ts := range creators {
+		list = append(list, *ts)
+	}
+	return s.repo.ReplaceScores(window, list)
+}
+
+// Contents liste les contenus en tendance sur la fenêtre (24h par défaut).
+func (s *TrendingService) Contents(window string, includeMature bool, limit, offset int) ([]repositories.TrendingContent, error) {
+	window, err := trendingWindow(window)
+	if err != nil {
+		return nil, err
+	}
+	limit, offset = Pagination(limit, offset)
+	list, err := s.repo.TopContents(window, includeMature, limit, offset)
+	if list == nil {
+		list = []repositories.TrendingContent{}
+	}
+	return list, err
+}
+
+// Creators liste les créateurs en tendance sur la fenêtre (24h par défaut).
+func (s *TrendingService) Creators(window string, limit, offset int) ([]repositories.TrendingCreator, error) {
+	window, err := trendingWindow(window)
+	if err != nil {
+		return nil, err
+	}
+	limit, offset = Pagination(limit, offset)
+	list, err := s.repo.TopCreators(window, limit, offset)
+	if list == nil {
+		list = []repositories.TrendingCreator{}
+	}
+	return list, err
+}
+
+func trendingWindow(window string) (string, error) {
+	if window == "" {
+		return models.TrendingWindowDay, nil
+	}
+	if _, ok := trendingWindows[window]; !ok {
+		return "", ErrInvalidTrendingWindow
+	}
+	return window, nil
+}
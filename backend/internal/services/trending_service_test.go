@@ -0,0 +1,152 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+var trendingNow = time.Date(2026, 4, 1, 12, 20, 0, 0, time.UTC)
+
+type trendingFixture struct {
+	*testStore
+	svc  *services.TrendingService
+	fans []*models.User
+}
+
+func setupTrending(t *testing.T) *trendingFixture {
+	f := &trendingFixture{testStore: newTestStore(t, &models.User{}, &models.Content{}, &models.Like{}, &models.Comment{},
+		&models.Subscription{}, &models.ContentEvent{}, &models.ActivityBucket{}, &models.TrendingScore{}),
+		svc: services.NewTrendingService(repositories.NewTrendingRepository())}
+	for i := range 12 {
+		f.fans = append(f.fans, f.user(fmt.Sprintf("fan%d", i), models.RoleSubscriber))
+	}
+	return f
+}
+
+// post crée un contenu ancien : seule son activité récente le fait remonter.
+func (f *trendingFixture) post(creator *models.User, title, status, maturity string) *models.Content {
+	return f.content(creator, title, status, trendingNow.Add(-60*24*time.Hour),
+		func(c *models.Content) { c.Maturity = maturity })
+}
+
+// likes fait liker c par les n premiers fans, il y a ago.
+func (f *trendingFixture) likes(c *models.Content, n int, ago time.Duration) {
+	for _, fan := range f.fans[:n] {
+		f.like(c, fan, trendingNow.Add(-ago))
+	}
+}
+
+func trendingTitles(t *testing.T, svc *services.TrendingService, window string, mature bool) []string {
+	list, err := svc.Contents(window, mature, 10, 0)
+	require.NoError(t, err)
+	titles := make([]string, len(list))
+	for i, c := range list {
+		titles[i] = c.Title
+	}
+	return titles
+}
+
+func TestTrending_Windows(t *testing.T) {
+	f := setupTrending(t)
+	alice, bob := f.user("alice", models.RoleCreator), f.user("bob", models.RoleCreator)
+	fresh := f.post(alice, "frais", models.ContentStatusApproved, models.MaturityGeneral)
+	steady := f.post(bob, "régulier", models.ContentStatusApproved, models.MaturityGeneral)
+	f.likes(fresh, 3, time.Hour)
+	f.likes(steady, 10, 6*24*time.Hour)
+
+	require.NoError(t, f.svc.Refresh(trendingNow))
+
+	assert.Equal(t, []string{"frais"}, trendingTitles(t, f.svc, models.TrendingWindowDay, false))
+	assert.Equal(t, []string{"frais", "régulier"}, trendingTitles(t, f.svc, models.TrendingWindowWeek, false),
+		"sur 7 jours, les likes anciens ont perdu l'essentiel de leur poids")
+	assert.Equal(t, []string{"régulier", "frais"}, trendingTitles(t, f.svc, models.TrendingWindowMonth, false),
+		"sur 30 jours, la demi-vie plus longue favorise le volume")
+	assert.Equal(t, trendingTitles(t, f.svc, models.TrendingWindowDay, false), trendingTitles(t, f.svc, "", false))
+
+	_, err := f.svc.Contents("1h", false, 10, 0)
+	assert.ErrorIs(t, err, services.ErrInvalidTrendingWindow)
+}
+
+func TestTrending_SignalsAndVisibility(t *testing.T) {
+	f := setupTrending(t)
+	alice, bob := f.user("alice", models.RoleCreator), f.user("bob", models.RoleCreator)
+	general := f.post(alice, "général", models.ContentStatusApproved, models.MaturityGeneral)
+	mature := f.post(alice, "adulte", models.ContentStatusApproved, models.MaturityMature)
+	pending := f.post(alice, "attente", models.ContentStatusPending, models.MaturityGeneral)
+	for _, c := range []*models.Content{general, mature, pending} {
+		f.likes(c, 1, 2*time.Hour)
+	}
+	f.create(
+		&models.Comment{ContentID: general.ID, AuthorID: f.fans[0].ID, Text: "bravo", CreatedAt: trendingNow.Add(-time.Hour)},
+		&models.Comment{ContentID: general.ID, AuthorID: f.fans[1].ID, Text: "retenu",
+			Status: models.TextStatusHeld, CreatedAt: trendingNow.Add(-time.Hour)},
+		&models.ContentEvent{ContentID: general.ID, UserID: &f.fans[2].ID,
+			Type: models.ContentEventView, OccurredAt: trendingNow.Add(-time.Hour)},
+	)
+	for _, fan := range f.fans[:4] {
+		f.subscription(fan, bob, trendingNow.Add(-3*time.Hour), trendingNow.Add(27*24*time.Hour), models.SubscriptionStatusActive)
+	}
+
+	require.NoError(t, f.svc.Refresh(trendingNow))
+
+	assert.Equal(t, []string{"général"}, trendingTitles(t, f.svc, models.TrendingWindowDay, false))
+	assert.ElementsMatch(t, []string{"général", "adulte"}, trendingTitles(t, f.svc, models.TrendingWindowDay, true))
+	list, err := f.svc.Contents(models.TrendingWindowDay, false, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), list[0].Likes)
+	assert.Equal(t, int64(1), list[0].Comments, "les commentaires retenus ne comptent pas")
+	assert.Equal(t, int64(1), list[0].Views)
+
+	creators, err := f.svc.Creators(models.TrendingWindowDay, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, creators, 2)
+	assert.Equal(t, "bob", creators[0].Username, "les nouveaux abonnements pèsent plus que quelques likes")
+	assert.Equal(t, int64(4), creators[0].Subscriptions)
+	assert.Equal(t, "alice", creators[1].Username)
+	assert.Equal(t, int64(3), creators[1].Likes)
+}
+
+func TestTrending_IncrementalRefresh(t *testing.T) {
+	f := setupTrending(t)
+	alice := f.user("alice", models.RoleCreator)
+	a := f.post(alice, "a", models.ContentStatusApproved, models.MaturityGeneral)
+	b := f.post(alice, "b", models.ContentStatusApproved, models.MaturityGeneral)
+	f.likes(a, 2, 3*time.Hour)
+	f.likes(b, 1, 10*time.Minute)
+	require.NoError(t, f.svc.Refresh(trendingNow))
+	assert.Equal(t, []string{"a", "b"}, trendingTitles(t, f.svc, models.TrendingWindowDay, false))
+
+	// Nouveaux likes dans l'heure en cours : l'agrégat de cette heure est
+	// recalculé sans doubler les précédents.
+	for _, fan := range f.fans[1:4] {
+		f.like(b, fan, trendingNow.Add(5*time.Minute))
+	}
+	require.NoError(t, f.svc.Refresh(trendingNow.Add(10*time.Minute)))
+	require.NoError(t, f.svc.Refresh(trendingNow.Add(10*time.Minute)))
+
+	list, err := f.svc.Contents(models.TrendingWindowDay, false, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "b", list[0].Title)
+	assert.Equal(t, int64(4), list[0].Likes)
+	assert.Equal(t, int64(2), list[1].Likes)
+
+	var buckets int64
+	require.NoError(t, f.db.Model(&models.ActivityBucket{}).Count(&buckets).Error)
+	assert.Equal(t, int64(2), buckets)
+
+	// Au-delà de la rétention, les agrégats sont purgés et le contenu sort
+	// de toutes les fenêtres.
+	require.NoError(t, f.svc.Refresh(trendingNow.Add(31*24*time.Hour)))
+	assert.Empty(t, trendingTitles(t, f.svc, models.TrendingWindowMonth, false))
+	require.NoError(t, f.db.Model(&models.ActivityBucket{}).Count(&buckets).Error)
+	assert.Zero(t, buckets)
+}
@@ -0,0 +1,473 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/config"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/logger"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+)
+
+var (
+	ErrUploadForbidden         = errors.New("seuls les créateurs peuvent envoyer du contenu")
+	ErrUploadSessionNotFound   = errors.New("session d'upload introuvable ou expirée")
+	ErrUploadInvalidSession    = errors.New("paramètres de session d'upload invalides")
+	ErrUploadOffsetMismatch    = errors.New("offset ne correspondant pas à l'état de l'upload")
+	ErrUploadChecksumMismatch  = errors.New("somme de contrôle invalide")
+	ErrUploadChecksumAlgorithm = errors.New("algorithme de somme de contrôle non supporté (sha256 uniquement)")
+	ErrUploadIncomplete        = errors.New("upload incomplet")
+	ErrUploadCompleted         = errors.New("upload déjà finalisé")
+	ErrUploadFinalizing        = errors.New("finalisation de l'upload déjà en cours")
+)
+
+var sha256Hex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// CreateUploadInput décrit le fichier attendu et le contenu à créer une fois
+// l'upload finalisé.
+type CreateUploadInput struct {
+	Filename string                 `json:"filename"`
+	Size     int64                  `json:"size"`
+	Checksum string                 `json:"checksum"`
+	Title    string                 `json:"title"`
+	Body     string                 `json:"body"`
+	Price    int                    `json:"price"`
+	Preview  models.PreviewSettings `json:"preview"`
+
+	Draft     bool       `json:"draft"`
+	PublishAt *time.Time `json:"publish_at"`
+	Maturity  string     `json:"maturity"`
+}
+
+// UploadService implémente les uploads reprenables : création d'une session,
+// envoi de morceaux à un offset donné, finalisation en Content. Les requêtes
+// d'une même session peuvent arriver sur des réplicas différentes : les
+// fichiers sont sur le volume partagé et l'offset n'avance que par une mise à
+// jour conditionnelle en base.
+type UploadService struct {
+	repo       *repositories.UploadSessionRepository
+	userRepo   *repositories.UserRepository
+	contentSvc *ContentService
+	tmpPath    string
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+func NewUploadService(
+	repo *repositories.UploadSessionRepository,
+	userRepo *repositories.UserRepository,
+	contentSvc *ContentService,
+	tmpPath string,
+) *UploadService {
+	ttl := config.C.UploadSessionTTL
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	return &UploadService{
+		repo:       repo,
+		userRepo:   userRepo,
+		contentSvc: contentSvc,
+		tmpPath:    tmpPath,
+		ttl:        ttl,
+		now:        time.Now,
+	}
+}
+
+// MaxUploadBytes renvoie la taille maximale d'un upload reprenable pour un
+// rôle (0 si le rôle ne peut pas publier).
+func MaxUploadBytes(role models.Role) int64 {
+	switch role {
+	case models.RoleAdmin:
+		if config.C.ResumableMaxBytesAdmin > 0 {
+			return config.C.ResumableMaxBytesAdmin
+		}
+		return 2 << 30
+	case models.RoleCreator:
+		if config.C.ResumableMaxBytesCreator > 0 {
+			return config.C.ResumableMaxBytesCreator
+		}
+		return 512 << 20
+	default:
+		return 0
+	}
+}
+
+// CreateSession ouvre une session d'upload et réserve le fichier temporaire.
+func (s *UploadService) CreateSession(userID uuid.UUID, in CreateUploadInput) (*models.UploadSession, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil || user == nil {
+		return nil, ErrUploadForbidden
+	}
+	maxBytes := MaxUploadBytes(user.Role)
+	if maxBytes == 0 {
+		return nil, ErrUploadForbidden
+	}
+	if err := s.contentSvc.CanPost(userID); err != nil {
+		return nil, err
+	}
+	if in.Size <= 0 || in.Filename == "" || in.Title == "" || in.Body == "" || in.Price <= 0 {
+		return nil, fmt.Errorf("%w: champs requis manquants", ErrUploadInvalidSession)
+	}
+	if in.Size > maxBytes {
+		return nil, fmt.Errorf("%w (max %d Mo pour le rôle %s)", ErrUploadTooLarge, maxBytes>>20, user.Role)
+	}
+	in.Checksum = strings.ToLower(strings.TrimSpace(in.Checksum))
+	if in.Checksum != "" && !sha256Hex.MatchString(in.Checksum) {
+		return nil, fmt.Errorf("%w: checksum SHA-256 hexadécimal attendu", ErrUploadInvalidSession)
+	}
+	if err := NormalizePreview(&in.Preview); err != nil {
+		return nil, err
+	}
+	if err := (ContentPublication{Draft: in.Draft, PublishAt: in.PublishAt, Maturity: in.Maturity}).Validate(s.now()); err != nil {
+		return nil, err
+	}
+
+	session := &models.UploadSession{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Filename:  filepath.Base(in.Filename),
+		Size:      in.Size,
+		Checksum:  in.Checksum,
+		Status:    models.UploadSessionStatusUploading,
+		Title:     in.Title,
+		Body:      in.Body,
+		Price:     in.Price,
+		Preview:   in.Preview,
+		Draft:     in.Draft,
+		PublishAt: in.PublishAt,
+		Maturity:  in.Maturity,
+		ExpiresAt: s.now().Add(s.ttl),
+	}
+
+	if err := os.MkdirAll(s.tmpPath, 0o700); err != nil {
+		return nil, fmt.Errorf("mkdir: %w", err)
+	}
+	f, err := os.OpenFile(s.partPath(session.ID), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	f.Close()
+
+	if err := s.repo.Create(session); err != nil {
+		os.Remove(s.partPath(session.ID))
+		return nil, err
+	}
+
+	log.Printf("📤 Session d'upload créée - id: %s | user: %s | taille: %d", session.ID, userID, session.Size)
+	return session, nil
+}
+
+// GetSession renvoie une session appartenant à l'utilisateur.
+func (s *UploadService) GetSession(userID, id uuid.UUID) (*models.UploadSession, error) {
+	session, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil || session.UserID != userID || s.now().After(session.ExpiresAt) {
+		return nil, ErrUploadSessionNotFound
+	}
+	return session, nil
+}
+
+// WriteChunk écrit un morceau à l'offset courant de la session. checksum est
+// optionnel et suit le format de l'en-tête tus Upload-Checksum
+// ("sha256 <base64>") ; en cas d'échec le morceau est annulé.
+//
+// Le morceau est d'abord reçu dans un fichier à part : seul l'envoi qui fait
+// avancer l'offset en base le recopie dans le fichier de la session, un envoi
+// concurrent au même offset ne peut donc pas l'écraser.
+func (s *UploadService) WriteChunk(userID, id uuid.UUID, offset int64, chunk io.Reader, checksum string) (int64, error) {
+	session, err := s.GetSession(userID, id)
+	if err != nil {
+		return 0, err
+	}
+	if session.Status != models.UploadSessionStatusUploading {
+		return session.Offset, ErrUploadCompleted
+	}
+	if offset != session.Offset {
+		return session.Offset, ErrUploadOffsetMismatch
+	}
+
+	var expected []byte
+	if checksum != "" {
+		algo, value, _ := strings.Cut(strings.TrimSpace(checksum), " ")
+		if !strings.EqualFold(algo, "sha256") {
+			return session.Offset, ErrUploadChecksumAlgorithm
+		}
+		expected, err = base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return session.Offset, fmt.Errorf("%w: encodage base64 attendu", ErrUploadChecksumMismatch)
+		}
+	}
+
+	staged, err := os.CreateTemp(s.tmpPath, id.String()+".*.chunk")
+	if err != nil {
+		return session.Offset, err
+	}
+	defer func() {
+		staged.Close()
+		os.Remove(staged.Name())
+	}()
+
+	remaining := session.Size - offset
+	hash := sha256.New()
+	n, copyErr := io.Copy(io.MultiWriter(staged, hash), io.LimitReader(chunk, remaining+1))
+	if n > remaining {
+		return offset, fmt.Errorf("%w: le morceau dépasse la taille déclarée", ErrUploadTooLarge)
+	}
+	if expected != nil && (copyErr != nil || !bytes.Equal(hash.Sum(nil), expected)) {
+		if copyErr != nil {
+			return offset, copyErr
+		}
+		return offset, ErrUploadChecksumMismatch
+	}
+	if n == 0 {
+		return offset, copyErr
+	}
+
+	// Sans checksum, les octets reçus avant une coupure sont conservés pour
+	// que le client reprenne là où la connexion s'est arrêtée.
+	advanced, err := s.repo.Advance(id, offset, offset+n, s.now().Add(s.ttl))
+	if err != nil {
+		return offset, err
+	}
+	if !advanced {
+		current, err := s.GetSession(userID, id)
+		if err != nil {
+			return offset, err
+		}
+		return current.Offset, ErrUploadOffsetMismatch
+	}
+	if err := s.appendStaged(id, staged, offset); err != nil {
+		// L'offset est rendu pour que le client renvoie le morceau.
+		if _, rbErr := s.repo.Advance(id, offset+n, offset, s.now().Add(s.ttl)); rbErr != nil {
+			logger.LogError(rbErr, "upload_offset_rollback_failed", map[string]interface{}{"session_id": id.String()})
+		}
+		return offset, err
+	}
+	if copyErr != nil {
+		return offset + n, copyErr
+	}
+	return offset + n, nil
+}
+
+// appendStaged recopie un morceau reçu à l'offset donné du fichier de session.
+func (s *UploadService) appendStaged(id uuid.UUID, staged *os.File, offset int64) error {
+	if _, err := staged.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.partPath(id), os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := io.Copy(f, staged); err != nil {
+		f.Truncate(offset)
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Finalize vérifie le fichier complet puis le transforme en Content. Un
+// second appel renvoie le contenu déjà créé ; un appel concurrent, sur cette
+// réplica ou une autre, reçoit ErrUploadFinalizing.
+func (s *UploadService) Finalize(userID, id uuid.UUID) (*models.Content, error) {
+	session, err := s.GetSession(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status == models.UploadSessionStatusCompleted && session.ContentID != nil {
+		return s.contentSvc.GetContentByID(*session.ContentID)
+	}
+	if session.Status == models.UploadSessionStatusFinalizing {
+		return nil, ErrUploadFinalizing
+	}
+	if session.Offset != session.Size {
+		return nil, fmt.Errorf("%w: %d/%d octets reçus", ErrUploadIncomplete, session.Offset, session.Size)
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil || user == nil {
+		return nil, ErrUploadForbidden
+	}
+
+	claimed, err := s.repo.Transition(id, models.UploadSessionStatusUploading, models.UploadSessionStatusFinalizing)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrUploadFinalizing
+	}
+	content, err := s.finalize(session, user)
+	if err != nil {
+		// La session redevient finalisable (fichier corrigé, sanction levée…).
+		if _, rbErr := s.repo.Transition(id, models.UploadSessionStatusFinalizing, models.UploadSessionStatusUploading); rbErr != nil {
+			logger.LogError(rbErr, "upload_finalize_release_failed", map[string]interface{}{"session_id": id.String()})
+		}
+		return nil, err
+	}
+
+	session.Status = models.UploadSessionStatusCompleted
+	session.ContentID = &content.ID
+	if err := s.repo.Update(session); err != nil {
+		log.Printf("⚠️ Mise à jour session d'upload %s: %v", id, err)
+	}
+	os.Remove(s.partPath(id))
+
+	logger.LogContent("content_created", userID.String(), content.ID.String(), map[string]interface{}{
+		"title":          content.Title,
+		"upload_session": id.String(),
+		"size":           session.Size,
+	})
+	return content, nil
+}
+
+// finalize contrôle le type et la somme du fichier reçu puis crée le contenu.
+func (s *UploadService) finalize(session *models.UploadSession, user *models.User) (*models.Content, error) {
+	f, err := os.Open(s.partPath(session.ID))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	header := make([]byte, SniffLen)
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	contentType, ext, err := SniffUploadType(header[:n])
+	if err != nil {
+		return nil, err
+	}
+
+	// Le fichier est haché en flux : il n'est jamais chargé en entier.
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return nil, err
+	}
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if session.Checksum != "" && sum != session.Checksum {
+		return nil, ErrUploadChecksumMismatch
+	}
+
+	// Une date de publication dépassée pendant l'upload vaut publication immédiate.
+	publication := ContentPublication{Draft: session.Draft, PublishAt: session.PublishAt, Maturity: session.Maturity}
+	if publication.PublishAt != nil && !publication.PublishAt.After(s.now()) {
+		publication.PublishAt = nil
+	}
+	if IsImageType(contentType) {
+		return s.finalizeImage(f, session, user, publication)
+	}
+	return s.finalizeMedia(f, session, user, publication, MediaFile{
+		Path: s.partPath(session.ID), Ext: ext, ContentType: contentType, SHA256: sum,
+	})
+}
+
+// finalizeImage décode et ré-encode une image JPEG ou PNG. Le décodage se
+// fait en mémoire : les images restent soumises à la limite des uploads
+// d'images, seuls les PSD et vidéos profitent de la limite par rôle.
+func (s *UploadService) finalizeImage(f *os.File, session *models.UploadSession, user *models.User, publication ContentPublication) (*models.Content, error) {
+	limits := DefaultUploadLimits()
+	if session.Size > limits.MaxBytes {
+		return nil, fmt.Errorf("%w (max %d Mo pour une image)", ErrUploadTooLarge, limits.MaxBytes>>20)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	validated, err := ValidateImageUpload(f, limits)
+	if err != nil {
+		return nil, err
+	}
+	return s.contentSvc.CreateContentFromImage(
+		user.ID, user.Username, session.Title, session.Body, session.Price, validated, session.Preview, publication,
+	)
+}
+
+// finalizeMedia valide la structure d'un PSD ou d'une vidéo sur disque puis
+// déplace le fichier reçu vers les contenus du créateur.
+func (s *UploadService) finalizeMedia(f *os.File, session *models.UploadSession, user *models.User, publication ContentPublication, media MediaFile) (*models.Content, error) {
+	width, height, err := ValidateMediaFile(f, session.Size, media.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	media.Width, media.Height = width, height
+	return s.contentSvc.CreateContentFromMedia(
+		user.ID, user.Username, session.Title, session.Body, session.Price, media, session.Preview, publication,
+	)
+}
+
+// Abort annule une session et supprime les morceaux reçus.
+func (s *UploadService) Abort(userID, id uuid.UUID) error {
+	if _, err := s.GetSession(userID, id); err != nil {
+		return err
+	}
+	if err := os.Remove(s.partPath(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// CleanupExpired supprime les sessions expirées et leurs fichiers temporaires.
+func (s *UploadService) CleanupExpired() (int, error) {
+	sessions, err := s.repo.FindExpired(s.now())
+	if err != nil {
+		return 0, err
+	}
+	for _, session := range sessions {
+		if err := os.Remove(s.partPath(session.ID)); err != nil && !os.IsNotExist(err) {
+			log.Printf("⚠️ Suppression fichier d'upload %s: %v", session.ID, err)
+		}
+		// Morceaux laissés par une réplica arrêtée en pleine réception.
+		chunks, _ := filepath.Glob(filepath.Join(s.tmpPath, session.ID.String()+".*.chunk"))
+		for _, chunk := range chunks {
+			os.Remove(chunk)
+		}
+		if err := s.repo.Delete(session.ID); err != nil {
+			return 0, err
+		}
+	}
+	return len(sessions), nil
+}
+
+// StartCleanup lance le nettoyage périodique des sessions abandonnées.
+func (s *UploadService) StartCleanup(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			n, err := s.CleanupExpired()
+			if err != nil {
+				logger.LogError(err, "upload_cleanup_failed", nil)
+				continue
+			}
+			if n > 0 {
+				log.Printf("🧹 %d session(s) d'upload expirée(s) supprimée(s)", n)
+			}
+		}
+	}()
+}
+
+func (s *UploadService) partPath(id uuid.UUID) string {
+	return filepath.Join(s.tmpPath, id.String()+".part")
+}
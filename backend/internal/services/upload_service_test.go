@@ -0,0 +1,218 @@
+package services_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/database"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/models"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/repositories"
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+// setupUploadService renvoie aussi les dossiers temporaire et des contenus.
+func setupUploadService(t *testing.T, role models.Role) (*services.UploadService, uuid.UUID, string, string) {
+	// Base nommée et partagée : les tests concurrents voient la même base
+	// depuis chaque connexion.
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	require.NoError(t, database.MigrateSQLite(db, &models.User{}, &models.Content{}, &models.UploadSession{}))
+	repositories.SetTestDB(db)
+
+	user := &models.User{ID: uuid.New(), Username: "artist", Email: "artist@exemple.com", HashedPassword: "x", Role: role}
+	require.NoError(t, db.Create(user).Error)
+
+	uploadDir := t.TempDir()
+	tmpDir := t.TempDir()
+	contentSvc := services.NewContentService(repositories.NewContentRepository(), repositories.NewContentRevisionRepository(), uploadDir, nil, nil, nil)
+	svc := services.NewUploadService(repositories.NewUploadSessionRepository(), repositories.NewUserRepository(), contentSvc, tmpDir)
+	return svc, user.ID, tmpDir, uploadDir
+}
+
+func chunkChecksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return "sha256 " + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestUploadService_ResumeAndFinalize(t *testing.T) {
+	svc, userID, tmpDir, _ := setupUploadService(t, models.RoleCreator)
+	file := encodeTestJPEG(t, 200, 150)
+	sum := sha256.Sum256(file)
+
+	session, err := svc.CreateSession(userID, services.CreateUploadInput{
+		Filename: "art.jpg", Size: int64(len(file)), Checksum: hex.EncodeToString(sum[:]),
+		Title: "Titre", Body: "Description", Price: 5,
+	})
+	require.NoError(t, err)
+
+	half := len(file) / 2
+	offset, err := svc.WriteChunk(userID, session.ID, 0, bytes.NewReader(file[:half]), chunkChecksum(file[:half]))
+	require.NoError(t, err)
+	assert.Equal(t, int64(half), offset)
+
+	// Un morceau corrompu est rejeté sans faire avancer l'offset.
+	_, err = svc.WriteChunk(userID, session.ID, offset, bytes.NewReader(file[half:]), chunkChecksum([]byte("autre")))
+	assert.True(t, errors.Is(err, services.ErrUploadChecksumMismatch))
+
+	// Mauvais offset : le client doit reprendre à l'offset renvoyé.
+	_, err = svc.WriteChunk(userID, session.ID, 0, bytes.NewReader(file[half:]), "")
+	assert.True(t, errors.Is(err, services.ErrUploadOffsetMismatch))
+
+	_, err = svc.Finalize(userID, session.ID)
+	assert.True(t, errors.Is(err, services.ErrUploadIncomplete))
+
+	offset, err = svc.WriteChunk(userID, session.ID, offset, bytes.NewReader(file[half:]), chunkChecksum(file[half:]))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(file)), offset)
+
+	content, err := svc.Finalize(userID, session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Titre", content.Title)
+	assert.Equal(t, 200, content.Width)
+
+	_, err = os.Stat(filepath.Join(tmpDir, session.ID.String()+".part"))
+	assert.True(t, os.IsNotExist(err))
+
+	again, err := svc.Finalize(userID, session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, content.ID, again.ID)
+}
+
+func TestUploadService_RoleLimits(t *testing.T) {
+	svc, userID, _, _ := setupUploadService(t, models.RoleSubscriber)
+	_, err := svc.CreateSession(userID, services.CreateUploadInput{
+		Filename: "art.jpg", Size: 10, Title: "T", Body: "B", Price: 1,
+	})
+	assert.True(t, errors.Is(err, services.ErrUploadForbidden))
+
+	svc, userID, _, _ = setupUploadService(t, models.RoleCreator)
+	_, err = svc.CreateSession(userID, services.CreateUploadInput{
+		Filename: "art.jpg", Size: services.MaxUploadBytes(models.RoleCreator) + 1, Title: "T", Body: "B", Price: 1,
+	})
+	assert.True(t, errors.Is(err, services.ErrUploadTooLarge))
+}
+
+func TestUploadService_FinalizeVideoKeepsFileAsIs(t *testing.T) {
+	svc, userID, tmpDir, uploadDir := setupUploadService(t, models.RoleCreator)
+	file := testMP4("mp42")
+	sum := sha256.Sum256(file)
+
+	session, err := svc.CreateSession(userID, services.CreateUploadInput{
+		Filename: "making-of.mp4", Size: int64(len(file)), Checksum: hex.EncodeToString(sum[:]),
+		Title: "Making-of", Body: "Vidéo", Price: 5,
+	})
+	require.NoError(t, err)
+	_, err = svc.WriteChunk(userID, session.ID, 0, bytes.NewReader(file), "")
+	require.NoError(t, err)
+
+	content, err := svc.Finalize(userID, session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, services.MediaTypeMP4, content.MediaType)
+	assert.Equal(t, hex.EncodeToString(sum[:]), content.SHA256)
+	assert.Equal(t, ".mp4", filepath.Ext(content.FilePath))
+
+	stored, err := os.ReadFile(filepath.Join(uploadDir, content.FilePath))
+	require.NoError(t, err)
+	assert.Equal(t, file, stored)
+	_, err = os.Stat(filepath.Join(tmpDir, session.ID.String()+".part"))
+	assert.True(t, os.IsNotExist(err), "fichier déplacé, pas copié")
+}
+
+func TestUploadService_ReplicasShareSessionState(t *testing.T) {
+	svc, userID, tmpDir, uploadDir := setupUploadService(t, models.RoleCreator)
+	// Deuxième réplica : même base, même volume partagé.
+	other := services.NewUploadService(repositories.NewUploadSessionRepository(), repositories.NewUserRepository(),
+		services.NewContentService(repositories.NewContentRepository(), repositories.NewContentRevisionRepository(), uploadDir, nil, nil, nil),
+		tmpDir)
+	replicas := []*services.UploadService{svc, other}
+	file := encodeTestJPEG(t, 120, 80)
+	half := len(file) / 2
+
+	session, err := svc.CreateSession(userID, services.CreateUploadInput{
+		Filename: "art.jpg", Size: int64(len(file)), Title: "Titre", Body: "Description", Price: 5,
+	})
+	require.NoError(t, err)
+
+	// Deux morceaux différents envoyés au même offset sur chaque réplica : un
+	// seul fait avancer l'offset et lui seul est écrit dans le fichier.
+	chunks := [][]byte{file[:half], bytes.Repeat([]byte{0xff}, half)}
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		won [][]byte
+	)
+	for i, r := range replicas {
+		wg.Add(1)
+		go func(r *services.UploadService, chunk []byte) {
+			defer wg.Done()
+			_, err := r.WriteChunk(userID, session.ID, 0, bytes.NewReader(chunk), "")
+			if err != nil {
+				assert.ErrorIs(t, err, services.ErrUploadOffsetMismatch)
+				return
+			}
+			mu.Lock()
+			won = append(won, chunk)
+			mu.Unlock()
+		}(r, chunks[i])
+	}
+	wg.Wait()
+	require.Len(t, won, 1)
+	current, err := other.GetSession(userID, session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(half), current.Offset)
+	part, err := os.ReadFile(filepath.Join(tmpDir, session.ID.String()+".part"))
+	require.NoError(t, err)
+	assert.Equal(t, won[0], part[:half])
+	require.NoError(t, other.Abort(userID, session.ID))
+
+	// La finalisation demandée aux deux réplicas ne crée qu'un contenu.
+	session, err = svc.CreateSession(userID, services.CreateUploadInput{
+		Filename: "art.jpg", Size: int64(len(file)), Title: "Titre", Body: "Description", Price: 5,
+	})
+	require.NoError(t, err)
+	_, err = svc.WriteChunk(userID, session.ID, 0, bytes.NewReader(file[:half]), "")
+	require.NoError(t, err)
+	_, err = other.WriteChunk(userID, session.ID, int64(half), bytes.NewReader(file[half:]), "")
+	require.NoError(t, err)
+
+	ids := make([]uuid.UUID, len(replicas))
+	for i, r := range replicas {
+		wg.Add(1)
+		go func(i int, r *services.UploadService) {
+			defer wg.Done()
+			content, err := r.Finalize(userID, session.ID)
+			if err != nil {
+				assert.ErrorIs(t, err, services.ErrUploadFinalizing)
+				return
+			}
+			ids[i] = content.ID
+		}(i, r)
+	}
+	wg.Wait()
+	content, err := other.Finalize(userID, session.ID)
+	require.NoError(t, err)
+	for _, id := range ids {
+		if id != uuid.Nil {
+			assert.Equal(t, content.ID, id)
+		}
+	}
+	var count int64
+	require.NoError(t, database.DB.Model(&models.Content{}).Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+}
@@ -0,0 +1,355 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/config"
+)
+
+var (
+	ErrUploadTooLarge      = errors.New("fichier trop volumineux")
+	ErrUploadUnsupported   = errors.New("format de fichier non autorisé")
+	ErrUploadDimensions    = errors.New("dimensions de l'image trop grandes")
+	ErrUploadCorruptedFile = errors.New("image corrompue ou illisible")
+)
+
+// UploadLimits borne la taille des fichiers et des images acceptés.
+type UploadLimits struct {
+	MaxBytes     int64
+	MaxPixels    int64
+	MaxDimension int
+}
+
+// DefaultUploadLimits lit les limites de la configuration.
+func DefaultUploadLimits() UploadLimits {
+	l := UploadLimits{
+		MaxBytes:     config.C.UploadMaxBytes,
+		MaxPixels:    config.C.UploadMaxPixels,
+		MaxDimension: config.C.UploadMaxDimension,
+	}
+	if l.MaxBytes <= 0 {
+		l.MaxBytes = 25 << 20
+	}
+	if l.MaxPixels <= 0 {
+		l.MaxPixels = 50_000_000
+	}
+	if l.MaxDimension <= 0 {
+		l.MaxDimension = 12000
+	}
+	return l
+}
+
+// ValidatedImage est une image vérifiée et ré-encodée, sans métadonnées.
+type ValidatedImage struct {
+	Data        []byte
+	Ext         string
+	ContentType string
+	Width       int
+	Height      int
+	SHA256      string
+	PHash       uint64
+}
+
+// ValidateImageUpload vérifie un fichier envoyé : type réel par magic bytes,
+// taille en octets, dimensions (lues dans l'en-tête avant tout décodage
+// complet pour éviter les bombes de décompression), puis décode et ré-encode
+// l'image. Le ré-encodage supprime les métadonnées EXIF/XMP (dont la
+// géolocalisation) ; l'orientation EXIF est appliquée aux pixels avant.
+func ValidateImageUpload(r io.Reader, limits UploadLimits) (*ValidatedImage, error) {
+	raw, err := io.ReadAll(io.LimitReader(r, limits.MaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("lecture du fichier: %w", err)
+	}
+	if int64(len(raw)) > limits.MaxBytes {
+		return nil, fmt.Errorf("%w (max %d Mo)", ErrUploadTooLarge, limits.MaxBytes>>20)
+	}
+
+	contentType := http.DetectContentType(raw)
+	var ext string
+	switch contentType {
+	case "image/jpeg":
+		ext = ".jpg"
+	case "image/png":
+		ext = ".png"
+	default:
+		return nil, fmt.Errorf("%w (JPEG ou PNG attendu): type détecté %s", ErrUploadUnsupported, contentType)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUploadCorruptedFile, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, fmt.Errorf("%w: dimensions nulles", ErrUploadCorruptedFile)
+	}
+	if cfg.Width > limits.MaxDimension || cfg.Height > limits.MaxDimension ||
+		int64(cfg.Width)*int64(cfg.Height) > limits.MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d (max %d px de côté, %d px au total)",
+			ErrUploadDimensions, cfg.Width, cfg.Height, limits.MaxDimension, limits.MaxPixels)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUploadCorruptedFile, err)
+	}
+
+	var buf bytes.Buffer
+	if ext == ".jpg" {
+		img = applyEXIFOrientation(img, jpegOrientation(raw))
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 92})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ré-encodage de l'image: %w", err)
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	b := img.Bounds()
+	return &ValidatedImage{
+		Data:        buf.Bytes(),
+		Ext:         ext,
+		ContentType: contentType,
+		Width:       b.Dx(),
+		Height:      b.Dy(),
+		SHA256:      hex.EncodeToString(sum[:]),
+		PHash:       DifferenceHash(img),
+	}, nil
+}
+
+// Formats volumineux acceptés par les uploads reprenables. Ils sont conservés
+// tels quels : ni ré-encodage, ni aperçu, ni marquage forensique.
+const (
+	MediaTypePSD       = "image/vnd.adobe.photoshop"
+	MediaTypeMP4       = "video/mp4"
+	MediaTypeQuickTime = "video/quicktime"
+	MediaTypeWebM      = "video/webm"
+)
+
+// SniffLen est le nombre d'octets d'en-tête lus pour reconnaître un format,
+// comme http.DetectContentType.
+const SniffLen = 512
+
+// SniffUploadType reconnaît le format d'un upload à ses premiers octets :
+// JPEG et PNG, ou PSD et vidéos (MP4, MOV, WebM).
+func SniffUploadType(header []byte) (contentType, ext string, err error) {
+	if len(header) >= 4 && string(header[:4]) == "8BPS" {
+		return MediaTypePSD, ".psd", nil
+	}
+	if len(header) >= 12 && string(header[4:8]) == "ftyp" {
+		switch string(header[8:12]) {
+		case "qt  ":
+			return MediaTypeQuickTime, ".mov", nil
+		case "isom", "iso2", "iso4", "iso5", "iso6", "mp41", "mp42", "avc1", "M4V ", "dash":
+			return MediaTypeMP4, ".mp4", nil
+		}
+	}
+	switch contentType = http.DetectContentType(header); contentType {
+	case "image/jpeg":
+		return contentType, ".jpg", nil
+	case "image/png":
+		return contentType, ".png", nil
+	case MediaTypeWebM:
+		return contentType, ".webm", nil
+	}
+	return "", "", fmt.Errorf("%w: type détecté %s", ErrUploadUnsupported, contentType)
+}
+
+// IsImageType indique si le format passe par la chaîne image (décodage,
+// ré-encodage, aperçus, marquage).
+func IsImageType(contentType string) bool {
+	return contentType == "image/jpeg" || contentType == "image/png"
+}
+
+// MediaFile est un fichier PSD ou vidéo validé sur sa structure, à déplacer
+// tel quel dans le dossier du créateur.
+type MediaFile struct {
+	Path        string
+	Ext         string
+	ContentType string
+	SHA256      string
+	Width       int
+	Height      int
+}
+
+// ValidateMediaFile vérifie la structure d'un fichier PSD ou vidéo en lisant
+// ses en-têtes sur disque, sans le charger en mémoire. Les dimensions ne sont
+// connues que pour les PSD.
+func ValidateMediaFile(f io.ReaderAt, size int64, contentType string) (width, height int, err error) {
+	switch contentType {
+	case MediaTypePSD:
+		return validatePSD(f)
+	case MediaTypeMP4, MediaTypeQuickTime:
+		return 0, 0, validateISOBMFF(f, size)
+	case MediaTypeWebM:
+		// La signature EBML, seule vérification possible sans démultiplexer,
+		// a été contrôlée par SniffUploadType.
+		return 0, 0, nil
+	}
+	return 0, 0, fmt.Errorf("%w: %s", ErrUploadUnsupported, contentType)
+}
+
+// validatePSD lit l'en-tête de 26 octets d'un PSD (version 1) ou PSB (version 2).
+func validatePSD(f io.ReaderAt) (int, int, error) {
+	header := make([]byte, 26)
+	if _, err := f.ReadAt(header, 0); err != nil {
+		return 0, 0, fmt.Errorf("%w: en-tête PSD tronqué", ErrUploadCorruptedFile)
+	}
+	version := binary.BigEndian.Uint16(header[4:6])
+	channels := binary.BigEndian.Uint16(header[12:14])
+	height := binary.BigEndian.Uint32(header[14:18])
+	width := binary.BigEndian.Uint32(header[18:22])
+	maxSide := uint32(30000)
+	if version == 2 {
+		maxSide = 300000
+	}
+	if (version != 1 && version != 2) || channels < 1 || channels > 56 ||
+		width == 0 || height == 0 || width > maxSide || height > maxSide {
+		return 0, 0, fmt.Errorf("%w: en-tête PSD invalide", ErrUploadCorruptedFile)
+	}
+	return int(width), int(height), nil
+}
+
+// validateISOBMFF parcourt les boîtes de premier niveau d'un MP4 ou MOV :
+// elles doivent couvrir exactement le fichier et contenir les métadonnées
+// de lecture (moov).
+func validateISOBMFF(f io.ReaderAt, size int64) error {
+	header := make([]byte, 16)
+	hasMoov := false
+	for offset := int64(0); offset < size; {
+		if _, err := f.ReadAt(header[:8], offset); err != nil {
+			return fmt.Errorf("%w: boîte vidéo tronquée", ErrUploadCorruptedFile)
+		}
+		boxSize, headerLen := int64(binary.BigEndian.Uint32(header[:4])), int64(8)
+		switch boxSize {
+		case 0:
+			boxSize = size - offset
+		case 1:
+			if _, err := f.ReadAt(header[8:16], offset+8); err != nil {
+				return fmt.Errorf("%w: boîte vidéo tronquée", ErrUploadCorruptedFile)
+			}
+			boxSize, headerLen = int64(binary.BigEndian.Uint64(header[8:16])), 16
+		}
+		if boxSize < headerLen || boxSize > size-offset {
+			return fmt.Errorf("%w: taille de boîte vidéo invalide", ErrUploadCorruptedFile)
+		}
+		if string(header[4:8]) == "moov" {
+			hasMoov = true
+		}
+		offset += boxSize
+	}
+	if !hasMoov {
+		return fmt.Errorf("%w: métadonnées vidéo (moov) absentes", ErrUploadCorruptedFile)
+	}
+	return nil
+}
+
+// jpegOrientation renvoie la valeur du tag EXIF Orientation (1 par défaut).
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xD9 || marker == 0xDA {
+			return 1
+		}
+		size := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if size < 2 || i+2+size > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+size]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+		i += 2 + size
+	}
+	return 1
+}
+
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			v := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if v >= 1 && v <= 8 {
+				return v
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// applyEXIFOrientation redresse l'image selon le tag Orientation EXIF, qui
+// disparaît au ré-encodage.
+func applyEXIFOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+	src := toRGBA(img)
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			si := src.PixOffset(x, y)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+	return dst
+}
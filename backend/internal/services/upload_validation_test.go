@@ -0,0 +1,148 @@
+package services_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/richard-lam-webdev/ArtFans/backend/internal/services"
+)
+
+var testLimits = services.UploadLimits{MaxBytes: 1 << 20, MaxPixels: 1_000_000, MaxDimension: 2000}
+
+func encodeTestJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+// withExifOrientation insère un segment APP1 EXIF (big-endian) portant le
+// tag Orientation juste après le marqueur SOI.
+func withExifOrientation(data []byte, orientation byte) []byte {
+	tiff := []byte{
+		'M', 'M', 0x00, 0x2A, 0x00, 0x00, 0x00, 0x08,
+		0x00, 0x01,
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, orientation, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+	}
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	size := len(payload) + 2
+	segment := append([]byte{0xFF, 0xE1, byte(size >> 8), byte(size)}, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func TestValidateImageUpload_StripsExifAndAppliesOrientation(t *testing.T) {
+	raw := withExifOrientation(encodeTestJPEG(t, 120, 80), 6)
+
+	v, err := services.ValidateImageUpload(bytes.NewReader(raw), testLimits)
+	require.NoError(t, err)
+
+	assert.Equal(t, ".jpg", v.Ext)
+	assert.Equal(t, 80, v.Width)
+	assert.Equal(t, 120, v.Height)
+	assert.False(t, bytes.Contains(v.Data, []byte("Exif")))
+
+	sum := sha256.Sum256(v.Data)
+	assert.Equal(t, hex.EncodeToString(sum[:]), v.SHA256)
+}
+
+func TestValidateImageUpload_DetectsTypeByMagicBytes(t *testing.T) {
+	_, err := services.ValidateImageUpload(bytes.NewReader([]byte("<?php echo 'pwned'; ?>")), testLimits)
+	assert.True(t, errors.Is(err, services.ErrUploadUnsupported))
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 10, 10))))
+	v, err := services.ValidateImageUpload(&buf, testLimits)
+	require.NoError(t, err)
+	assert.Equal(t, ".png", v.Ext)
+}
+
+func TestValidateImageUpload_Limits(t *testing.T) {
+	raw := encodeTestJPEG(t, 300, 200)
+
+	_, err := services.ValidateImageUpload(bytes.NewReader(raw), services.UploadLimits{MaxBytes: 100, MaxPixels: 1_000_000, MaxDimension: 2000})
+	assert.True(t, errors.Is(err, services.ErrUploadTooLarge))
+
+	_, err = services.ValidateImageUpload(bytes.NewReader(raw), services.UploadLimits{MaxBytes: 1 << 20, MaxPixels: 1_000_000, MaxDimension: 250})
+	assert.True(t, errors.Is(err, services.ErrUploadDimensions))
+
+	_, err = services.ValidateImageUpload(bytes.NewReader(raw), services.UploadLimits{MaxBytes: 1 << 20, MaxPixels: 50_000, MaxDimension: 2000})
+	assert.True(t, errors.Is(err, services.ErrUploadDimensions))
+}
+
+func TestValidateImageUpload_RejectsTruncatedImage(t *testing.T) {
+	raw := encodeTestJPEG(t, 300, 200)
+	_, err := services.ValidateImageUpload(bytes.NewReader(raw[:len(raw)/2]), testLimits)
+	assert.True(t, errors.Is(err, services.ErrUploadCorruptedFile))
+}
+
+// isoBox construit une boîte MP4/MOV de premier niveau.
+func isoBox(kind string, payload []byte) []byte {
+	box := binary.BigEndian.AppendUint32(nil, uint32(8+len(payload)))
+	box = append(box, kind...)
+	return append(box, payload...)
+}
+
+func testMP4(brand string) []byte {
+	file := isoBox("ftyp", []byte(brand+"\x00\x00\x02\x00isommp41"))
+	file = append(file, isoBox("moov", make([]byte, 32))...)
+	return append(file, isoBox("mdat", bytes.Repeat([]byte{0x42}, 4096))...)
+}
+
+func TestSniffUploadType_LargeFormats(t *testing.T) {
+	psd := append([]byte("8BPS\x00\x01\x00\x00\x00\x00\x00\x00\x00\x03"), 0, 0, 0x0F, 0xA0, 0, 0, 0x0B, 0xB8, 0, 8, 0, 3)
+	cases := map[string][]byte{
+		services.MediaTypePSD:       psd,
+		services.MediaTypeMP4:       testMP4("isom"),
+		services.MediaTypeQuickTime: testMP4("qt  "),
+		services.MediaTypeWebM:      {0x1A, 0x45, 0xDF, 0xA3, 0x9F, 0x42, 0x86, 0x81, 0x01, 0x42, 0x82, 0x84, 'w', 'e', 'b', 'm'},
+		"image/jpeg":                encodeTestJPEG(t, 10, 10),
+	}
+	for want, raw := range cases {
+		got, _, err := services.SniffUploadType(raw)
+		require.NoError(t, err, want)
+		assert.Equal(t, want, got)
+	}
+
+	_, _, err := services.SniffUploadType([]byte("MZ\x90\x00 exécutable"))
+	assert.True(t, errors.Is(err, services.ErrUploadUnsupported))
+
+	w, h, err := services.ValidateMediaFile(bytes.NewReader(psd), int64(len(psd)), services.MediaTypePSD)
+	require.NoError(t, err)
+	assert.Equal(t, 3000, w)
+	assert.Equal(t, 4000, h)
+}
+
+func TestValidateMediaFile_RejectsTruncatedVideo(t *testing.T) {
+	mp4 := testMP4("isom")
+	_, _, err := services.ValidateMediaFile(bytes.NewReader(mp4), int64(len(mp4)), services.MediaTypeMP4)
+	require.NoError(t, err)
+
+	truncated := mp4[:len(mp4)-100]
+	_, _, err = services.ValidateMediaFile(bytes.NewReader(truncated), int64(len(truncated)), services.MediaTypeMP4)
+	assert.True(t, errors.Is(err, services.ErrUploadCorruptedFile))
+
+	noMoov := append(isoBox("ftyp", []byte("isom\x00\x00\x02\x00")), isoBox("mdat", make([]byte, 64))...)
+	_, _, err = services.ValidateMediaFile(bytes.NewReader(noMoov), int64(len(noMoov)), services.MediaTypeMP4)
+	assert.True(t, errors.Is(err, services.ErrUploadCorruptedFile))
+}
@@ -58,11 +58,11 @@ class AdminContentProvider extends ChangeNotifier {
     }
   }
 
-  Future<void> rejectContent(String id) async {
+  Future<void> rejectContent(String id, String reasonCode, String note) async {
     _status = AdminContentStatus.loading;
     notifyListeners();
     try {
-      await _service.rejectContent(id);
+      await _service.rejectContent(id, reasonCode, note);
       await fetchContents();
     } catch (e) {
       _status = AdminContentStatus.error;
@@ -1,25 +1,36 @@
-import 'package:shared_preferences/shared_preferences.dart';
+import 'dart:convert';
 
+import 'package:flutter_secure_storage/flutter_secure_storage.dart';
+import 'package:http/http.dart' as http;
+
+import '../services/api_service.dart';
+
+/// Historique de recherche, tenu par le serveur : chaque recherche lancée
+/// via /api/search y est enregistrée.
 class RecentSearchProvider {
-  static const _key = 'recent_searches';
-  static const _max = 10;
+  final _storage = const FlutterSecureStorage();
 
-  Future<List<String>> all() async {
-    final prefs = await SharedPreferences.getInstance();
-    return prefs.getStringList(_key) ?? <String>[];
+  Future<Map<String, String>> _headers() async {
+    final token = await _storage.read(key: 'jwt_token');
+    return {'Authorization': 'Bearer $token'};
   }
 
-  Future<void> add(String q) async {
-    final prefs = await SharedPreferences.getInstance();
-    final list = prefs.getStringList(_key) ?? <String>[];
-    list.remove(q);
-    list.insert(0, q);
-    final truncated = list.take(_max).toList();
-    await prefs.setStringList(_key, truncated);
+  Future<List<String>> all() async {
+    final res = await http.get(
+      Uri.parse('${ApiService.baseUrl}/api/search/recent'),
+      headers: await _headers(),
+    );
+    if (res.statusCode != 200) return <String>[];
+    final searches = jsonDecode(res.body)['searches'] as List<dynamic>? ?? [];
+    return searches
+        .map((e) => (e as Map<String, dynamic>)['query'] as String)
+        .toList();
   }
 
   Future<void> clear() async {
-    final prefs = await SharedPreferences.getInstance();
-    await prefs.remove(_key);
+    await http.delete(
+      Uri.parse('${ApiService.baseUrl}/api/search/recent'),
+      headers: await _headers(),
+    );
   }
 }
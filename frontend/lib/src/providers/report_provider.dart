@@ -32,14 +32,21 @@ class ReportProvider with ChangeNotifier {
     notifyListeners();
   }
 
-  Future<void> submitReport(String contentId, {String? reason}) async {
+  Future<void> submitReport(
+    String contentId,
+    String reasonCode, {
+    String? details,
+  }) async {
     _loading = true;
     _error = null;
     notifyListeners();
 
     try {
-      await reportService.reportContent(contentId, reason: reason);
-      await fetchReports();
+      await reportService.reportContent(
+        contentId,
+        reasonCode,
+        details: details,
+      );
     } catch (e) {
       _error = e.toString();
       rethrow;
@@ -48,4 +55,14 @@ class ReportProvider with ChangeNotifier {
       notifyListeners();
     }
   }
+
+  Future<void> resolveReport(String reportId, String action) async {
+    await reportService.resolveReport(reportId, action);
+    await fetchReports();
+  }
+
+  Future<void> dismissReport(String reportId) async {
+    await reportService.dismissReport(reportId);
+    await fetchReports();
+  }
 }
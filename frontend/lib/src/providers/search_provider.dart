@@ -56,7 +56,7 @@ class Content {
   factory Content.fromJson(Map<String, dynamic> j) => Content(
     id: j['id'] as String,
     title: j['title'] as String? ?? '',
-    thumbnailUrl: j['thumbnail_url'] as String? ?? '',
+    thumbnailUrl: '${ApiService.baseUrl}${j['thumbnail_url'] as String? ?? ''}',
     creatorName: j['creator_name'] as String? ?? '',
   );
 }
@@ -356,9 +356,83 @@ class _UsersTab extends StatelessWidget {
   }
 }
 
+// Motifs de rejet acceptés par l'API (models.ModerationReasonLabels).
+const _rejectionReasons = {
+  'copyright': 'Atteinte aux droits d\'auteur',
+  'unlabeled_adult': 'Contenu adulte non signalé',
+  'illegal': 'Contenu illégal',
+  'harassment': 'Harcèlement',
+  'spam': 'Spam',
+  'low_quality': 'Qualité insuffisante',
+  'other': 'Autre',
+};
+
 class _ContentsTab extends StatelessWidget {
   const _ContentsTab();
 
+  Future<void> _askRejection(
+    BuildContext context,
+    AdminContentProvider prov,
+    String id,
+  ) async {
+    String reason = _rejectionReasons.keys.first;
+    final noteCtrl = TextEditingController();
+    final confirmed = await showDialog<bool>(
+      context: context,
+      builder:
+          (ctx) => StatefulBuilder(
+            builder:
+                (ctx, setState) => AlertDialog(
+                  title: const Text('Rejeter le contenu'),
+                  content: Column(
+                    mainAxisSize: MainAxisSize.min,
+                    children: [
+                      DropdownButton<String>(
+                        value: reason,
+                        isExpanded: true,
+                        items:
+                            _rejectionReasons.entries
+                                .map(
+                                  (e) => DropdownMenuItem(
+                                    value: e.key,
+                                    child: Text(e.value),
+                                  ),
+                                )
+                                .toList(),
+                        onChanged: (v) => setState(() => reason = v ?? reason),
+                      ),
+                      TextField(
+                        controller: noteCtrl,
+                        maxLines: 3,
+                        decoration: InputDecoration(
+                          labelText:
+                              reason == 'other'
+                                  ? 'Note pour le créateur (obligatoire)'
+                                  : 'Note pour le créateur',
+                        ),
+                      ),
+                    ],
+                  ),
+                  actions: [
+                    TextButton(
+                      onPressed: () => Navigator.pop(ctx, false),
+                      child: const Text('Annuler'),
+                    ),
+                    TextButton(
+                      onPressed: () => Navigator.pop(ctx, true),
+                      child: const Text('Rejeter'),
+                    ),
+                  ],
+                ),
+          ),
+    );
+    final note = noteCtrl.text.trim();
+    noteCtrl.dispose();
+    if (confirmed == true) {
+      await prov.rejectContent(id, reason, note);
+    }
+  }
+
   @override
   Widget build(BuildContext context) {
     final prov = context.watch<AdminContentProvider>();
@@ -406,7 +480,7 @@ class _ContentsTab extends StatelessWidget {
                     ),
                     IconButton(
                       icon: const Icon(Icons.close, color: Colors.red),
-                      onPressed: () => prov.rejectContent(id),
+                      onPressed: () => _askRejection(context, prov, id),
                     ),
                     IconButton(
                       icon: const Icon(Icons.delete, color: Colors.redAccent),
@@ -4,6 +4,7 @@ import 'package:provider/provider.dart';
 
 import '../services/content_service.dart';
 import '../utils/snackbar_util.dart';
+import '../widgets/protected_image.dart';
 import '../providers/theme_provider.dart';
 
 class EditContentScreen extends StatefulWidget {
@@ -16,10 +17,6 @@ class EditContentScreen extends StatefulWidget {
 }
 
 class _EditContentScreenState extends State<EditContentScreen> {
-    final String _baseUrl = '';
-
-  String get baseUrl => _baseUrl;
-
   final ContentService _contentService = ContentService();
 
   final _formKey = GlobalKey<FormState>();
@@ -140,15 +137,9 @@ class _EditContentScreenState extends State<EditContentScreen> {
                                   padding: const EdgeInsets.only(bottom: 16),
                                   child: ClipRRect(
                                     borderRadius: BorderRadius.circular(8),
-                                    child: Image.network(
-                                      '$baseUrl/uploads/$_filePath',
-                                      height: 200,
-                                      fit: BoxFit.cover,
-                                      errorBuilder:
-                                          (_, __, ___) => const Text(
-                                            "Image introuvable",
-                                            style: TextStyle(color: Colors.red),
-                                          ),
+                                    child: ProtectedImage(
+                                      contentId: widget.contentId,
+                                      isSubscribed: true,
                                     ),
                                   ),
                                 ),
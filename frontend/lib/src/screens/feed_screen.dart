@@ -20,10 +20,20 @@ class FeedScreen extends StatefulWidget {
   State<FeedScreen> createState() => _FeedScreenState();
 }
 
+const _feedModes = {
+  'foryou': 'Pour vous',
+  'following': 'Abonnements',
+  'latest': 'Récents',
+};
+
 class _FeedScreenState extends State<FeedScreen> {
   final ContentService _contentService = ContentService();
+  final ScrollController _scrollController = ScrollController();
   List<Map<String, dynamic>> _feed = [];
+  String _mode = 'foryou';
+  String? _nextCursor;
   bool _loading = true;
+  bool _loadingMore = false;
   late DateTime _pageLoadStart;
 
   @override
@@ -34,19 +44,35 @@ class _FeedScreenState extends State<FeedScreen> {
       final loadTime = DateTime.now().difference(_pageLoadStart).inMilliseconds;
       MetricsService.reportPageLoad('feed', loadTime);
     });
+    _scrollController.addListener(_onScroll);
     _fetchFeed();
   }
 
+  @override
+  void dispose() {
+    _scrollController.dispose();
+    super.dispose();
+  }
+
+  void _onScroll() {
+    if (_scrollController.position.extentAfter < 600) {
+      _fetchMore();
+    }
+  }
+
   Future<void> _fetchFeed() async {
     setState(() => _loading = true);
     try {
-      final result = await _contentService.fetchFeed();
+      final page = await _contentService.fetchFeed(mode: _mode);
       if (!mounted) return;
 
-      context.read<SubscriptionProvider>().initializeFeedSubscriptions(result);
+      context.read<SubscriptionProvider>().initializeFeedSubscriptions(
+        page.items,
+      );
 
       setState(() {
-        _feed = result;
+        _feed = page.items;
+        _nextCursor = page.nextCursor;
         _loading = false;
       });
     } catch (e) {
@@ -57,13 +83,57 @@ class _FeedScreenState extends State<FeedScreen> {
     }
   }
 
+  Future<void> _fetchMore() async {
+    if (_loadingMore || _nextCursor == null) return;
+    setState(() => _loadingMore = true);
+    try {
+      final page = await _contentService.fetchFeed(
+        mode: _mode,
+        cursor: _nextCursor,
+      );
+      if (!mounted) return;
+
+      context.read<SubscriptionProvider>().initializeFeedSubscriptions(
+        page.items,
+      );
+
+      setState(() {
+        _feed.addAll(page.items);
+        _nextCursor = page.nextCursor;
+        _loadingMore = false;
+      });
+    } catch (e) {
+      if (!mounted) return;
+      showCustomSnackBar(context, "Erreur : $e", type: SnackBarType.error);
+      setState(() => _loadingMore = false);
+    }
+  }
+
   @override
   Widget build(BuildContext context) {
     final auth = context.read<AuthProvider>();
     return Scaffold(
       appBar: AppBar(
-        title: const Text("Fil d'actualité"),
+        title: Text(_feedModes[_mode]!),
         actions: [
+          PopupMenuButton<String>(
+            tooltip: 'Type de fil',
+            icon: const Icon(Icons.tune),
+            initialValue: _mode,
+            onSelected: (mode) {
+              if (mode == _mode) return;
+              setState(() {
+                _mode = mode;
+                _nextCursor = null;
+              });
+              _fetchFeed();
+            },
+            itemBuilder:
+                (_) => [
+                  for (final entry in _feedModes.entries)
+                    PopupMenuItem(value: entry.key, child: Text(entry.value)),
+                ],
+          ),
           IconButton(
             tooltip: 'Recherche',
             icon: const Icon(Icons.search_outlined),
@@ -142,8 +212,15 @@ class _FeedScreenState extends State<FeedScreen> {
               : RefreshIndicator(
                 onRefresh: _fetchFeed,
                 child: ListView.builder(
-                  itemCount: _feed.length,
+                  controller: _scrollController,
+                  itemCount: _feed.length + (_loadingMore ? 1 : 0),
                   itemBuilder: (context, index) {
+                    if (index == _feed.length) {
+                      return const Padding(
+                        padding: EdgeInsets.all(16),
+                        child: Center(child: CircularProgressIndicator()),
+                      );
+                    }
                     final item = _feed[index];
                     return FeedCard(
                       key: ValueKey(item['id']),
@@ -36,10 +36,11 @@ class _ReportsModerationScreenState extends State<ReportsModerationScreen> {
             constraints: BoxConstraints(minWidth: constraints.maxWidth),
             child: DataTable(
               columns: const [
-                DataColumn(label: Text('Contenu')),
-                DataColumn(label: Text('Signalant')),
-                DataColumn(label: Text('Raison')),
-                DataColumn(label: Text('Signalé')),
+                DataColumn(label: Text('Type')),
+                DataColumn(label: Text('Cible')),
+                DataColumn(label: Text('Signalements')),
+                DataColumn(label: Text('Motifs')),
+                DataColumn(label: Text('Dernier')),
                 DataColumn(label: Text('Action')),
               ],
               rows: reports.map((r) => _buildDataRow(r)).toList(),
@@ -50,23 +51,84 @@ class _ReportsModerationScreenState extends State<ReportsModerationScreen> {
     );
   }
 
-  DataRow _buildDataRow(Map<String, dynamic> r) {
-    final contentId = r['target_content_id'] as String;
-    final reporterId = r['reporter_id'] as String;
-    final reason = (r['reason'] as String?) ?? '';
-    final createdAt = DateTime.parse(r['created_at'] as String);
+  static const _targetLabels = {
+    'content': 'Contenu',
+    'comment': 'Commentaire',
+    'message': 'Message',
+    'user': 'Utilisateur',
+  };
+
+  DataRow _buildDataRow(Map<String, dynamic> g) {
+    final targetType = g['target_type'] as String;
+    final targetId = g['target_id'] as String;
+    final count = g['report_count'] as int? ?? 0;
+    final reasons = (g['reason_counts'] as Map<String, dynamic>? ?? {})
+        .entries
+        .map((e) => '${e.key} (${e.value})')
+        .join(', ');
+    final lastAt = DateTime.parse(g['last_reported_at'] as String);
+    final reports = g['reports'] as List<dynamic>? ?? [];
+    final reportId =
+        reports.isNotEmpty
+            ? (reports.first as Map<String, dynamic>)['id'] as String
+            : null;
 
     return DataRow(
       cells: [
-        DataCell(Text(contentId)),
-        DataCell(Text(reporterId)),
-        DataCell(Text(reason)),
-        DataCell(Text(timeago.format(createdAt, locale: 'fr'))),
-        DataCell(_buildDeleteButton(contentId)),
+        DataCell(Text(_targetLabels[targetType] ?? targetType)),
+        DataCell(Text(targetId)),
+        DataCell(Text('$count')),
+        DataCell(Text(reasons)),
+        DataCell(Text(timeago.format(lastAt, locale: 'fr'))),
+        DataCell(
+          Row(
+            children: [
+              if (reportId != null && targetType == 'content')
+                IconButton(
+                  tooltip: 'Rejeter le contenu',
+                  icon: const Icon(Icons.block, color: Colors.orange),
+                  onPressed: () => _decide(reportId, 'content_rejected'),
+                ),
+              if (reportId != null && targetType == 'comment')
+                IconButton(
+                  tooltip: 'Supprimer le commentaire',
+                  icon: const Icon(Icons.comments_disabled, color: Colors.red),
+                  onPressed: () => _decide(reportId, 'comment_deleted'),
+                ),
+              if (reportId != null)
+                IconButton(
+                  tooltip: 'Classer sans suite',
+                  icon: const Icon(Icons.done, color: Colors.grey),
+                  onPressed: () => _decide(reportId, null),
+                ),
+              if (targetType == 'content') _buildDeleteButton(targetId),
+            ],
+          ),
+        ),
       ],
     );
   }
 
+  Future<void> _decide(String reportId, String? action) async {
+    final prov = context.read<ReportProvider>();
+    try {
+      if (action == null) {
+        await prov.dismissReport(reportId);
+      } else {
+        await prov.resolveReport(reportId, action);
+      }
+      if (!mounted) return;
+      showCustomSnackBar(
+        context,
+        'Signalement traité',
+        type: SnackBarType.success,
+      );
+    } catch (e) {
+      if (!mounted) return;
+      showCustomSnackBar(context, 'Erreur : $e', type: SnackBarType.error);
+    }
+  }
+
   Widget _buildDeleteButton(String contentId) {
     return IconButton(
       icon: const Icon(Icons.delete, color: Colors.red),
@@ -310,7 +310,16 @@ class ContentCard extends StatelessWidget {
         children: [
           AspectRatio(
             aspectRatio: 1,
-            child: Image.network(content.thumbnailUrl, fit: BoxFit.cover),
+            child: Image.network(
+              content.thumbnailUrl,
+              fit: BoxFit.cover,
+              headers: {
+                'Authorization':
+                    'Bearer ${context.read<AuthProvider>().token ?? ''}',
+              },
+              errorBuilder:
+                  (_, __, ___) => const Icon(Icons.image_not_supported),
+            ),
           ),
           const SizedBox(height: 4),
           Text(
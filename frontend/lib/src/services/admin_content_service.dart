@@ -56,13 +56,19 @@ class AdminContentService {
     }
   }
 
-  Future<void> rejectContent(String id) async {
+  /// Rejette un contenu ; [reasonCode] est obligatoire, [note] est transmise
+  /// au créateur (obligatoire pour le motif "other").
+  Future<void> rejectContent(String id, String reasonCode, String note) async {
     final token = await _secureStorage.read(key: 'jwt_token');
     if (token == null) throw Exception('Pas de token');
     final uri = Uri.parse('$_baseUrl/api/admin/contents/$id/reject');
     final res = await http.put(
       uri,
-      headers: {'Authorization': 'Bearer $token'},
+      headers: {
+        'Authorization': 'Bearer $token',
+        'Content-Type': 'application/json',
+      },
+      body: jsonEncode({'reason_code': reasonCode, 'note': note}),
     );
     if (res.statusCode != 200) {
       final body = jsonDecode(res.body) as Map<String, dynamic>;
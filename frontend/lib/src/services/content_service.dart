@@ -81,7 +81,7 @@ class ContentService {
     if (token == null) throw Exception("Token JWT manquant");
 
     final response = await http.get(
-      Uri.parse("$_baseUrl/api/contents"),
+      Uri.parse("$_baseUrl/api/creator/contents"),
       headers: {
         'Authorization': 'Bearer $token',
         'Content-Type': 'application/json',
@@ -153,10 +153,22 @@ class ContentService {
     }
   }
 
-  Future<List<Map<String, dynamic>>> fetchFeed() async {
+  /// Récupère une page du fil ; [cursor] est le `next_cursor` de la page
+  /// précédente (null pour la première). `nextCursor` est null en fin de fil.
+  /// [mode] : 'foryou' (classé, par défaut), 'following' ou 'latest'.
+  Future<({List<Map<String, dynamic>> items, String? nextCursor})> fetchFeed({
+    String mode = 'foryou',
+    String? cursor,
+    int limit = 20,
+  }) async {
     final token = await _getToken();
+    final query = {
+      'mode': mode,
+      'limit': '$limit',
+      if (cursor != null && cursor.isNotEmpty) 'cursor': cursor,
+    };
     final response = await http.get(
-      Uri.parse("$_baseUrl/api/feed"),
+      Uri.parse("$_baseUrl/api/feed").replace(queryParameters: query),
       headers: {
         'Authorization': 'Bearer $token',
         'Content-Type': 'application/json',
@@ -168,29 +180,13 @@ class ContentService {
 
     final body = jsonDecode(response.body);
     final feed = body['feed'];
-    if (feed is! List) return [];
-
-    return List<Map<String, dynamic>>.from(feed);
-  }
-
-  Future<Map<String, dynamic>> fetchFeedPaginated({
-    int page = 1,
-    int limit = 10,
-  }) async {
-    final token = await _getToken();
-    final response = await http.get(
-      Uri.parse("$_baseUrl/api/feed?page=$page&limit=$limit"),
-      headers: {
-        'Authorization': 'Bearer $token',
-        'Content-Type': 'application/json',
-      },
+    return (
+      items:
+          feed is List
+              ? List<Map<String, dynamic>>.from(feed)
+              : <Map<String, dynamic>>[],
+      nextCursor: body['next_cursor'] as String?,
     );
-
-    if (response.statusCode != 200) {
-      throw Exception("Erreur ${response.statusCode} : ${response.body}");
-    }
-
-    return jsonDecode(response.body);
   }
 
   Future<void> subscribe(String creatorId) async {
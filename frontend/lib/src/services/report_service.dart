@@ -10,13 +10,17 @@ class ReportService {
     : _secureStorage = const FlutterSecureStorage(),
       _baseUrl = '';
 
-  Future<void> reportContent(String contentId, {String? reason}) async {
+  Future<void> reportContent(
+    String contentId,
+    String reasonCode, {
+    String? details,
+  }) async {
     final token = await _secureStorage.read(key: 'jwt_token');
 
     if (token == null) throw Exception('Pas de token');
 
     final uri = Uri.parse('$_baseUrl/api/contents/$contentId/report');
-    final body = reason != null ? {'reason': reason} : {};
+    final body = {'reason_code': reasonCode, 'details': details ?? ''};
 
     final res = await http.post(
       uri,
@@ -39,11 +43,14 @@ class ReportService {
     }
   }
 
-  Future<List<Map<String, dynamic>>> fetchReports() async {
+  /// Renvoie les cibles signalées, regroupées, avec leurs signalements.
+  Future<List<Map<String, dynamic>>> fetchReports({
+    String status = 'open',
+  }) async {
     final token = await _secureStorage.read(key: 'jwt_token');
     if (token == null) throw Exception('Pas de token');
 
-    final uri = Uri.parse('$_baseUrl/api/admin/reports');
+    final uri = Uri.parse('$_baseUrl/api/admin/reports?status=$status');
     final res = await http.get(
       uri,
       headers: {
@@ -64,7 +71,44 @@ class ReportService {
     }
 
     final data = jsonDecode(res.body) as Map<String, dynamic>;
-    final list = data['reports'] as List<dynamic>? ?? [];
+    final list = data['targets'] as List<dynamic>? ?? [];
     return List<Map<String, dynamic>>.from(list);
   }
+
+  /// Clôt un signalement ; [action] vaut no_action, content_rejected ou
+  /// comment_deleted.
+  Future<void> resolveReport(String reportId, String action) =>
+      _decide(reportId, 'resolve', {'action': action});
+
+  Future<void> dismissReport(String reportId) =>
+      _decide(reportId, 'dismiss', {});
+
+  Future<void> _decide(
+    String reportId,
+    String decision,
+    Map<String, dynamic> body,
+  ) async {
+    final token = await _secureStorage.read(key: 'jwt_token');
+    if (token == null) throw Exception('Pas de token');
+
+    final uri = Uri.parse('$_baseUrl/api/admin/reports/$reportId/$decision');
+    final res = await http.put(
+      uri,
+      headers: {
+        'Content-Type': 'application/json',
+        'Authorization': 'Bearer $token',
+      },
+      body: jsonEncode(body),
+    );
+    if (res.statusCode != 200) {
+      String msg;
+      try {
+        final err = jsonDecode(res.body) as Map<String, dynamic>;
+        msg = err['error'] ?? 'Erreur HTTP ${res.statusCode}';
+      } catch (_) {
+        msg = 'Erreur HTTP ${res.statusCode}';
+      }
+      throw Exception(msg);
+    }
+  }
 }
@@ -243,18 +243,25 @@ class _FeedCardState extends State<FeedCard> {
   }
 
   Future<void> _reportContent() async {
-    final reasons = ['Inapproprié', 'Spam', 'Autre'];
+    const reasons = {
+      'unlabeled_adult': 'Contenu adulte non signalé',
+      'copyright': 'Atteinte aux droits d\'auteur',
+      'harassment': 'Harcèlement ou contenu haineux',
+      'illegal': 'Contenu illégal',
+      'spam': 'Spam ou publicité',
+      'scam': 'Arnaque',
+    };
     final selected = await showDialog<String>(
       context: context,
       builder:
           (ctx) => SimpleDialog(
             title: const Text('Signaler ce contenu'),
             children:
-                reasons
+                reasons.entries
                     .map(
                       (r) => SimpleDialogOption(
-                        child: Text(r),
-                        onPressed: () => Navigator.of(ctx).pop(r),
+                        child: Text(r.value),
+                        onPressed: () => Navigator.of(ctx).pop(r.key),
                       ),
                     )
                     .toList(),
@@ -267,7 +274,7 @@ class _FeedCardState extends State<FeedCard> {
       final reportProvider = context.read<ReportProvider>();
       await reportProvider.submitReport(
         widget.content['id'] as String,
-        reason: selected,
+        selected,
       );
 
       if (!mounted) return;